@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"kvstore/server"
+
+	"google.golang.org/grpc"
+)
+
+// Mutex is a distributed lock held by a session. Waiters are served in the
+// order they asked.
+type Mutex struct {
+	locks   server.LockClient
+	session *Session
+	name    string
+	key     string
+	token   int64
+}
+
+// NewMutex returns the lock name, taken on behalf of s.
+func NewMutex(conn grpc.ClientConnInterface, s *Session, name string) *Mutex {
+	return &Mutex{locks: server.NewLockClient(conn), session: s, name: name}
+}
+
+// Lock blocks until the lock is held or ctx is done.
+func (m *Mutex) Lock(ctx context.Context) error {
+	resp, err := m.locks.Lock(ctx, &server.LockRequest{Name: m.name, Lease: m.session.Lease()})
+	if err != nil {
+		return err
+	}
+	m.key, m.token = resp.Key, resp.FencingToken
+	return nil
+}
+
+// Unlock releases the lock.
+func (m *Mutex) Unlock(ctx context.Context) error {
+	_, err := m.locks.Unlock(ctx, &server.UnlockRequest{Key: m.key})
+	if err == nil {
+		m.key, m.token = "", 0
+	}
+	return err
+}
+
+// Token returns the fencing token of the held lock. Resources guarded by the
+// lock should refuse requests carrying a lower token than one already seen.
+func (m *Mutex) Token() int64 {
+	return m.token
+}
+
+// Key returns the key holding the lock, empty when it is not held.
+func (m *Mutex) Key() string {
+	return m.key
+}
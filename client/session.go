@@ -0,0 +1,79 @@
+// Package client holds helpers for applications using the store over gRPC.
+package client
+
+import (
+	"context"
+	"kvstore/server"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// Session is a lease kept alive in the background for as long as the
+// application runs. Locks and elections taken through it are released when
+// the session is closed or its keep-alives stop reaching the store.
+type Session struct {
+	leases server.LeaseClient
+	lease  int64
+	cancel context.CancelFunc
+	done   chan struct{} // closed once the lease is no longer kept alive
+	once   sync.Once
+}
+
+// NewSession grants a lease with ttl and starts keeping it alive.
+func NewSession(conn grpc.ClientConnInterface, ttl time.Duration) (*Session, error) {
+	leases := server.NewLeaseClient(conn)
+	resp, err := leases.Grant(context.Background(), &server.LeaseGrantRequest{TtlMs: ttl.Milliseconds()})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := leases.KeepAlive(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	s := &Session{leases: leases, lease: resp.Id, cancel: cancel, done: make(chan struct{})}
+	go s.keepAlive(ctx, stream, ttl/3)
+	return s, nil
+}
+
+func (s *Session) keepAlive(ctx context.Context, stream server.Lease_KeepAliveClient, every time.Duration) {
+	defer s.once.Do(func() { close(s.done) })
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+
+	for {
+		if err := stream.Send(&server.LeaseKeepAliveRequest{Id: s.lease}); err != nil {
+			return
+		}
+		resp, err := stream.Recv()
+		if err != nil || resp.TtlMs == 0 {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Lease returns the id of the session's lease.
+func (s *Session) Lease() int64 {
+	return s.lease
+}
+
+// Done is closed when the session ends, after which what it held is gone.
+func (s *Session) Done() <-chan struct{} {
+	return s.done
+}
+
+// Close stops the keep-alives and revokes the lease.
+func (s *Session) Close() error {
+	s.cancel()
+	_, err := s.leases.Revoke(context.Background(), &server.LeaseRevokeRequest{Id: s.lease})
+	return err
+}
@@ -1,7 +1,48 @@
 package iface
 
+import "kvstore/storage"
+
+// RequestMeta describes who sent a request. Peers fill it through gRPC
+// metadata when forwarding or replicating, clients leave it empty.
+type RequestMeta struct {
+	RequesterID int           // id of the sending node, 0 for clients
+	Epoch       uint64        // leader epoch the request was issued under, 0 for clients
+	Leader      int           // leader of Epoch as the sender knows it, 0 for clients
+	ClientID    string        // optional client session for deduplicating retries
+	Seq         uint64        // client sequence number, increasing per ClientID
+	Replicated  bool          // write of a cross-cluster replication link, allowed on a read-only standby
+	Stamp       storage.Stamp // revision, time and writer the leader gave a replicated write
+	Revoked     []string      // keys the leader deleted with a replicated lease revoke
+}
+
 type NodeAPI interface {
-	HandlePut(requesterID int, key, value string) error
+	HandlePut(meta RequestMeta, key, value string) error
 	HandleGet(key string) (string, error)
-	HandleDelete(requesterID int, key string) error
+	HandleGetMeta(key string) (string, storage.KeyMeta, error)
+	HandleGetAt(key string, revision int64) (string, storage.KeyMeta, error)
+	HandleScan(start, end string, limit int, revision int64) ([]storage.Entry, int64, bool, error)
+	HandleScanKeys(start, end string, limit int, revision int64) ([]string, int64, bool, error)
+	HandleCount(groupOnly bool) (int64, error)
+	HandleDeleteRange(meta RequestMeta, start, end string, groupOnly bool) (int64, error)
+	HandleCompact(meta RequestMeta, revision int64) error
+	HandleDelete(meta RequestMeta, key string) error
+	HandleTxn(meta RequestMeta, txn Txn) (bool, map[string]storage.KeyState, error)
+	HandleCompareAndSwap(meta RequestMeta, key, expected, value string) (bool, storage.KeyState, error)
+	HandleConditionalPut(meta RequestMeta, key, value string, cond Condition) (bool, storage.KeyState, error)
+	HandleConditionalDelete(meta RequestMeta, key string, cond Condition) (bool, storage.KeyState, error)
+	HandleIncrement(meta RequestMeta, key string, delta int64) (int64, error)
+	GetEpoch() uint64
+	GossipAPI
+	ShardingAPI
+	AdminAPI
+	LeaderlessAPI
+	CRDTAPI
+	ChainAPI
+	ReplicationAPI
+	WatchAPI
+	LeaseAPI
+	LockAPI
+	ElectionAPI
+	BatchAPI
+	CollectionAPI
 }
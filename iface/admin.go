@@ -0,0 +1,41 @@
+package iface
+
+import (
+	"kvstore/storage"
+	"time"
+)
+
+// States of a RangeMove
+const (
+	MOVE_PENDING = "pending"
+	MOVE_RUNNING = "running"
+	MOVE_DONE    = "done"
+	MOVE_FAILED  = "failed"
+)
+
+// RangeMove is one step of a rebalance: hand Range from group From to group To.
+type RangeMove struct {
+	Range KeyRange
+	From  int
+	To    int
+	State string
+	Keys  int64 // keys transferred
+	Bytes int64 // key and value bytes transferred
+	Error string
+}
+
+// RangeChunk is part of a range streamed between groups: the whole state of
+// some of its keys, and the time left on the leases they are attached to.
+type RangeChunk struct {
+	Records []storage.KeyRecord
+	Leases  map[int64]time.Duration
+}
+
+type AdminAPI interface {
+	PlanRebalance() ([]RangeMove, error)
+	StartRebalance(bytesPerSecond int64) ([]RangeMove, error)
+	RebalanceStatus() ([]RangeMove, bool)
+	HandleMoveRange(r KeyRange, toShard int, toAddr string, bytesPerSecond int64) (keys, bytes int64, err error)
+	HandleInstallRange(meta RequestMeta, r KeyRange, chunk RangeChunk) error
+	HandleCommitRange(r KeyRange) (ShardGroup, error)
+}
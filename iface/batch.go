@@ -0,0 +1,18 @@
+package iface
+
+import "kvstore/storage"
+
+// GetResult is the outcome of reading one key of a batch.
+type GetResult struct {
+	Key   string
+	Value string
+	Found bool
+	Meta  storage.KeyMeta
+	Err   error
+}
+
+type BatchAPI interface {
+	HandleBatchGet(keys []string) []GetResult
+	HandleBatchWrite(meta RequestMeta, ops []storage.BatchOp) []error
+	HandleBulkLoad(meta RequestMeta, records []storage.BatchOp, overwrite bool) (int, int, []error)
+}
@@ -0,0 +1,22 @@
+package iface
+
+import "kvstore/storage"
+
+// ChainWrite is a write travelling from the head to the tail of a chain.
+// Version is assigned by the head and orders the writes to one key.
+type ChainWrite struct {
+	Op        string
+	Key       string
+	Value     string
+	ClientID  string
+	Seq       uint64
+	Version   uint64
+	Ops       []storage.BatchOp // writes of a transaction, applied together
+	Succeeded bool              // whether Ops is the transaction's success branch
+	Stamp     storage.Stamp     // assigned by the head along with Version
+}
+
+type ChainAPI interface {
+	HandleChainWrite(meta RequestMeta, w ChainWrite) error
+	HandleChainRead(key string, revision int64) (string, storage.KeyMeta, error)
+}
@@ -0,0 +1,7 @@
+package iface
+
+import "kvstore/storage"
+
+type CollectionAPI interface {
+	HandleCollection(meta RequestMeta, op storage.CollectionOp) (storage.CollectionResult, error)
+}
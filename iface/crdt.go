@@ -0,0 +1,14 @@
+package iface
+
+import "kvstore/storage"
+
+type CRDTAPI interface {
+	HandleCounterIncrement(key string, delta int64) (*storage.CRDT, error)
+	HandleSetAdd(key string, members []string) (*storage.CRDT, error)
+	HandleSetRemove(key string, members []string) (*storage.CRDT, error)
+	HandleRegisterSet(key, value string) (*storage.CRDT, error)
+	HandleMapPut(key, field, value string) (*storage.CRDT, error)
+	HandleMapRemove(key, field string) (*storage.CRDT, error)
+	HandleCRDTRead(key string) (*storage.CRDT, error)
+	HandleCRDTSync(states map[string]*storage.CRDT) map[string]*storage.CRDT
+}
@@ -0,0 +1,21 @@
+package iface
+
+import "context"
+
+// LeaderKey identifies a leadership: the key a candidate campaigned with and
+// the revision that created it, which changes if the key is lost and written
+// again.
+type LeaderKey struct {
+	Name     string
+	Key      string
+	Revision int64
+	Lease    int64
+}
+
+type ElectionAPI interface {
+	HandleCampaign(ctx context.Context, name string, lease int64, value string) (LeaderKey, error)
+	HandleProclaim(meta RequestMeta, leader LeaderKey, value string) error
+	HandleResign(meta RequestMeta, leader LeaderKey) error
+	HandleLeader(name string) (LeaderKey, string, error)
+	HandleObserve(ctx context.Context, name string, send func(LeaderKey, string) error) error
+}
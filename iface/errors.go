@@ -0,0 +1,15 @@
+package iface
+
+import "errors"
+
+var (
+	ErrStaleEpoch  = errors.New("request from a stale leader epoch")
+	ErrNotLeader   = errors.New("node is not the leader of its group")
+	ErrUnsupported = errors.New("operation not supported by the cluster configuration")
+	ErrBusy        = errors.New("another operation is already in progress")
+	ErrQuorum      = errors.New("not enough replicas answered")
+	ErrReadOnly    = errors.New("cluster is a read-only standby")
+	ErrNoLeader    = errors.New("election has no leader")
+	ErrNotElected  = errors.New("candidate does not lead the election")
+	ErrStaleSeq    = errors.New("client sequence number is too old to deduplicate")
+)
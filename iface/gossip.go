@@ -0,0 +1,22 @@
+package iface
+
+const (
+	ALIVE   = 0
+	SUSPECT = 1
+	DEAD    = 2
+)
+
+// Member is a cluster member as seen by the gossip protocol.
+type Member struct {
+	ID          int
+	Addr        string
+	Status      int    // ALIVE, SUSPECT or DEAD
+	Incarnation uint64 // bumped by the member itself to refute suspicion
+	Shard       int    // replica group the member belongs to
+}
+
+type GossipAPI interface {
+	HandlePing(from Member, updates []Member) []Member
+	HandlePingReq(from, target Member, updates []Member) (bool, []Member)
+	HandleJoin(member Member) []Member
+}
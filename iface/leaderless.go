@@ -0,0 +1,12 @@
+package iface
+
+import "kvstore/storage"
+
+type LeaderlessAPI interface {
+	Leaderless() bool
+	HandleVersionedGet(key string) ([]storage.Sibling, storage.VectorClock, error)
+	HandleVersionedPut(key, value string, context storage.VectorClock) (storage.VectorClock, error)
+	HandleVersionedDelete(key string, context storage.VectorClock) (storage.VectorClock, error)
+	HandleStore(key string, siblings []storage.Sibling, hintFor int) error
+	HandleFetch(key string) []storage.Sibling
+}
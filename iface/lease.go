@@ -0,0 +1,19 @@
+package iface
+
+import "time"
+
+// LeaseInfo describes a lease as its group's leader sees it.
+type LeaseInfo struct {
+	ID        int64
+	TTL       time.Duration
+	Remaining time.Duration // until the lease expires without a keep-alive
+	Keys      []string
+}
+
+type LeaseAPI interface {
+	HandleLeaseGrant(meta RequestMeta, id int64, ttl time.Duration) (int64, error)
+	HandleLeaseRevoke(meta RequestMeta, id int64) error
+	HandleLeaseKeepAlive(id int64) (time.Duration, error)
+	HandleLeaseTimeToLive(id int64) (LeaseInfo, error)
+	HandlePutWithLease(meta RequestMeta, key, value string, lease int64) error
+}
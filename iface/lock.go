@@ -0,0 +1,8 @@
+package iface
+
+import "context"
+
+type LockAPI interface {
+	HandleLock(ctx context.Context, name string, lease int64) (string, int64, error)
+	HandleUnlock(meta RequestMeta, key string) error
+}
@@ -0,0 +1,49 @@
+package iface
+
+import (
+	"context"
+	"kvstore/storage"
+	"time"
+)
+
+// Kinds of LogEntry besides the put and delete of a committed write
+const (
+	LOG_RESET     = "reset"     // drop the keys under the prefix, a snapshot follows
+	LOG_HEARTBEAT = "heartbeat" // no write; the stream has delivered up to Index
+)
+
+// LogEntry is an entry of a node's committed operation log. Indexes grow by
+// one per write within the log named by LogID.
+type LogEntry struct {
+	LogID     uint64
+	Index     uint64
+	Op        string
+	Key       string
+	Value     string
+	Committed time.Time
+	HeadIndex uint64 // last index of the source log when the entry was sent
+	Snapshot  bool
+	Ops       []storage.BatchOp   // writes of a transaction, applied together
+	Records   []storage.KeyRecord // install: the collections installed whole, or removed
+}
+
+// ReplicationStatus describes the link of a standby cluster to its primary.
+type ReplicationStatus struct {
+	Source      string
+	Prefix      string
+	Running     bool
+	LogID       uint64
+	Applied     uint64 // last source index applied
+	SourceIndex uint64
+	LagEntries  uint64
+	Lag         time.Duration // age of the last applied write while entries remain
+	Error       string
+	ReadOnly    bool
+}
+
+type ReplicationAPI interface {
+	HandleTail(ctx context.Context, logID, from uint64, prefix string, send func(LogEntry) error) error
+	StartReplication(source, prefix string) (ReplicationStatus, error)
+	ReplicationStatus() ReplicationStatus
+	Promote() (ReplicationStatus, error)
+}
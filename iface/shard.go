@@ -0,0 +1,55 @@
+package iface
+
+// Partitioning schemes
+const (
+	HASH  = "hash"
+	RANGE = "range"
+)
+
+// Replication modes
+const (
+	SINGLE_LEADER = "leader"
+	LEADERLESS    = "leaderless"
+	CHAIN         = "chain"
+)
+
+// KeyRange is the interval [Start, End); an empty End is unbounded.
+type KeyRange struct {
+	Start string
+	End   string
+}
+
+func (r KeyRange) Contains(key string) bool {
+	return key >= r.Start && (r.End == "" || key < r.End)
+}
+
+// ShardGroup is a replica group owning part of the keyspace.
+type ShardGroup struct {
+	ID         int
+	LeaderID   int
+	LeaderAddr string
+	Version    uint64     // bumped by the group leader when its ranges change
+	Ranges     []KeyRange // owned ranges under RANGE partitioning
+	RESPAddr   string     // leader's Redis protocol listener, where other groups redirect Redis clients
+}
+
+// ClusterMetadata is the routing table shared by every node. The version
+// with the highest number wins when nodes exchange it; within a version each
+// group's entry is merged by its own Version.
+type ClusterMetadata struct {
+	Version      uint64
+	VirtualNodes int    // points per shard on the hash ring
+	Partitioning string // HASH (default) or RANGE
+	Shards       []ShardGroup
+	Replication  string // SINGLE_LEADER (default), LEADERLESS or CHAIN
+	Replicas     int    // LEADERLESS: N replicas per key
+	ReadQuorum   int    // LEADERLESS: R
+	WriteQuorum  int    // LEADERLESS: W
+	ReadOnly     bool   // standby of a cross-cluster replication link, only the link writes
+}
+
+type ShardingAPI interface {
+	ClusterMetadata() ClusterMetadata
+	MergeClusterMetadata(md ClusterMetadata) bool
+	KeyOwner(key string) (ShardGroup, bool)
+}
@@ -0,0 +1,36 @@
+package iface
+
+import "kvstore/storage"
+
+// Txn is a multi-key transaction: Success is applied when every compare
+// holds, Failure otherwise. The leader replicates the branch it applied as a
+// Resolved transaction carried in Success, with Succeeded telling which branch
+// it was, so replicas never evaluate the compares against their own state.
+type Txn struct {
+	Compares  []storage.Compare
+	Success   []storage.BatchOp
+	Failure   []storage.BatchOp
+	Resolved  bool
+	Succeeded bool
+	Current   map[string]storage.KeyState // compared keys as the leader evaluated them
+}
+
+// Condition makes a put or delete depend on the current state of the key.
+type Condition struct {
+	IfAbsent  bool  // only when the key does not exist
+	IfVersion int64 // only when the key is at this version, when set
+}
+
+// Keys returns every key the transaction reads or writes.
+func (t *Txn) Keys() []string {
+	var keys []string
+	for _, c := range t.Compares {
+		keys = append(keys, c.Key)
+	}
+	for _, ops := range [][]storage.BatchOp{t.Success, t.Failure} {
+		for _, op := range ops {
+			keys = append(keys, op.Key)
+		}
+	}
+	return keys
+}
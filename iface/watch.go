@@ -0,0 +1,30 @@
+package iface
+
+import (
+	"context"
+	"kvstore/storage"
+	"time"
+)
+
+// WatchRequest selects the keys in [Start, End) to watch; an empty End is
+// unbounded. Changes from StartRevision on are replayed first, 0 starts with
+// the next write. ProgressInterval, when set, is how long the watch may stay
+// silent before a progress notification is sent.
+type WatchRequest struct {
+	Start            string
+	End              string
+	StartRevision    int64
+	ProgressInterval time.Duration
+}
+
+// WatchResponse carries the changes of one write, or no changes but the
+// revision the watch has delivered up to when Progress is set.
+type WatchResponse struct {
+	Events   []storage.Event
+	Revision int64
+	Progress bool
+}
+
+type WatchAPI interface {
+	HandleWatch(ctx context.Context, req WatchRequest, send func(WatchResponse) error) error
+}
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"kvstore/iface"
 	"kvstore/node"
 	"log"
 	"os"
@@ -11,16 +13,52 @@ import (
 )
 
 func main() {
+	replication := flag.String("replication", iface.SINGLE_LEADER, "replication mode of the cluster: leader, leaderless or chain")
+	respAddr := flag.String("resp", "", "address of a Redis protocol listener on the first node, none when empty")
+	memcacheAddr := flag.String("memcache", "", "address of a memcached protocol listener on the first node, none when empty")
+	flag.Parse()
+
 	fmt.Println("Starting KV Store Node...")
 
 	// Should me managed by a cluster manager
 	n := node.NewNode(1, "localhost:50051", node.LEADER)
 	n2 := node.NewNode(2, "localhost:50052", node.FOLLOWER)
 	n3 := node.NewNode(3, "localhost:50053", node.FOLLOWER)
-	n.AddFollower(2, "localhost:50052")
-	n.AddFollower(3, "localhost:50053")
-	n2.SetLeader(1, "localhost:50051")
-	n3.SetLeader(1, "localhost:50051")
+
+	// A single shard group owns the whole ring; more groups can be added by
+	// publishing a newer metadata version
+	n.SetClusterMetadata(iface.ClusterMetadata{
+		Version:      1,
+		VirtualNodes: 64,
+		Shards:       []iface.ShardGroup{{ID: 0, LeaderID: 1, LeaderAddr: "localhost:50051"}},
+		Replication:  *replication,
+	})
+
+	// Each node has an HTTP gateway, on 8051 for localhost:50051 and so on
+	for i, member := range []*node.Node{n, n2, n3} {
+		member.ServeGateway(fmt.Sprintf("localhost:%d", 8051+i))
+	}
+	if *respAddr != "" {
+		n.ServeRESP(*respAddr)
+	}
+	if *memcacheAddr != "" {
+		n.ServeMemcache(*memcacheAddr)
+	}
+
+	// Followers discover the cluster by gossip through the first node
+	for _, member := range []*node.Node{n2, n3} {
+		if err := member.Join("localhost:50051"); err != nil {
+			log.Fatalf("Node %d could not join: %v", member.GetID(), err)
+		}
+	}
+
+	// Every leadership change starts a new epoch, above anything persisted
+	epoch := max(n.GetEpoch(), n2.GetEpoch(), n3.GetEpoch()) + 1
+	for _, member := range []*node.Node{n, n2, n3} {
+		if err := member.SetLeader(1, "localhost:50051", epoch); err != nil {
+			log.Fatalf("Node %d refused leader: %v", member.GetID(), err)
+		}
+	}
 
 	log.Printf("Node %d created as %s", n.GetID(), getStateString(n.GetState()))
 	log.Printf("Node %d created as %s", n2.GetID(), getStateString(n2.GetState()))
@@ -42,6 +80,18 @@ func main() {
 	# Delete a key:
 	grpcurl -plaintext -d '{\"key\":\"hello\"}' localhost:50051 KVStore/Delete
 
+	Or through the HTTP gateway of any node, followers forwarding writes:
+	curl -X PUT -d '{"value":"world"}' localhost:8052/v1/kv/hello
+	curl localhost:8051/v1/kv/hello
+	curl 'localhost:8053/v1/kv?prefix=he&limit=10'
+	curl -X DELETE localhost:8051/v1/kv/hello
+
+	Started with -resp localhost:6379, the first node also speaks the Redis protocol:
+	redis-cli -p 6379 SET hello world
+
+	Started with -memcache localhost:11211, it speaks the memcached text protocol too:
+	printf 'set hello 0 0 5\r\nworld\r\n' | nc localhost 11211
+
 	Press Ctrl+C to stop the node
 	`)
 
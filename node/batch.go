@@ -0,0 +1,146 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"kvstore/iface"
+	"kvstore/server"
+	"kvstore/storage"
+	"time"
+)
+
+// HandleBatchGet reads keys, in order, with a result for each. Keys of other
+// shards are read with one request per shard.
+func (n *Node) HandleBatchGet(keys []string) []iface.GetResult {
+	results := make([]iface.GetResult, len(keys))
+	for addr, group := range n.groupByOwner(keys) {
+		if addr == "" {
+			for _, i := range group {
+				value, meta, err := n.HandleGetAt(keys[i], 0)
+				results[i] = iface.GetResult{Key: keys[i], Value: value, Found: err == nil && meta.Version > 0, Meta: meta, Err: err}
+			}
+			continue
+		}
+		remote := make([]string, len(group))
+		for j, i := range group {
+			remote[j] = keys[i]
+		}
+		for j, res := range batchGetRemote(addr, remote) {
+			results[group[j]] = res
+		}
+	}
+	return results
+}
+
+// HandleBatchWrite applies ops and returns an error for each, nil when it was
+// applied. The ops of each shard are applied together as one transaction,
+// so they are replicated as a single entry and fail or succeed together.
+func (n *Node) HandleBatchWrite(meta iface.RequestMeta, ops []storage.BatchOp) []error {
+	errs, _ := n.writeByOwner(meta, ops)
+	return errs
+}
+
+// HandleBulkLoad puts records as HandleBatchWrite does. Unless overwrite is
+// set, records of keys that exist are skipped, and so are the repeats of a
+// key within records. It returns how many records were written and skipped,
+// and an error for each record, nil unless it failed. Each shard's records
+// are replicated as one transaction entry, also into an empty group: an
+// install would need the group to stay empty while it is shipped, which
+// concurrent writes do not guarantee.
+func (n *Node) HandleBulkLoad(meta iface.RequestMeta, records []storage.BatchOp, overwrite bool) (int, int, []error) {
+	ops := make([]storage.BatchOp, 0, len(records))
+	for _, r := range records {
+		ops = append(ops, storage.BatchOp{Key: r.Key, Value: r.Value, IfAbsent: !overwrite})
+	}
+	errs, current := n.writeByOwner(meta, ops)
+
+	loaded, skipped := 0, 0
+	seen := make(map[string]bool)
+	for i, op := range ops {
+		switch {
+		case errs[i] != nil:
+		case !overwrite && (current[op.Key].Exists || seen[op.Key]):
+			skipped++
+		default:
+			loaded++
+		}
+		seen[op.Key] = true
+	}
+	return loaded, skipped, errs
+}
+
+// writeByOwner applies ops as one transaction per shard. Each op is validated
+// first and left out of its shard's transaction when invalid, so a
+// transaction fails as a whole only for reasons of its shard. It returns an
+// error for each op and the keys the transactions compared, IfAbsent puts
+// included.
+func (n *Node) writeByOwner(meta iface.RequestMeta, ops []storage.BatchOp) ([]error, map[string]storage.KeyState) {
+	errs := make([]error, len(ops))
+	current := make(map[string]storage.KeyState)
+	keys := make([]string, len(ops))
+	for i, op := range ops {
+		keys[i] = op.Key
+	}
+	for _, group := range n.groupByOwner(keys) {
+		var batch []storage.BatchOp
+		var valid []int
+		for _, i := range group {
+			if err := ops[i].Validate(); err != nil {
+				errs[i] = err
+				continue
+			}
+			batch, valid = append(batch, ops[i]), append(valid, i)
+		}
+		if len(batch) == 0 {
+			continue
+		}
+		_, states, err := n.HandleTxn(meta, iface.Txn{Success: batch})
+		for _, i := range valid {
+			errs[i] = err
+		}
+		for key, st := range states {
+			current[key] = st
+		}
+	}
+	return errs, current
+}
+
+// groupByOwner returns the positions of keys by the address of the shard
+// leader owning them, "" for this node's own shard.
+func (n *Node) groupByOwner(keys []string) map[string][]int {
+	groups := make(map[string][]int)
+	for i, key := range keys {
+		addr, _ := n.route(key)
+		groups[addr] = append(groups[addr], i)
+	}
+	return groups
+}
+
+func batchGetRemote(addr string, keys []string) []iface.GetResult {
+	results := make([]iface.GetResult, len(keys))
+	fail := func(err error) []iface.GetResult {
+		for i, key := range keys {
+			results[i] = iface.GetResult{Key: key, Err: err}
+		}
+		return results
+	}
+	conn, err := dial(addr)
+	if err != nil {
+		return fail(err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	resp, err := server.NewKVStoreClient(conn).BatchGet(ctx, &server.BatchGetRequest{Keys: keys})
+	if err != nil {
+		return fail(err)
+	}
+	if len(resp.Results) != len(keys) {
+		return fail(fmt.Errorf("shard leader at %s answered %d of %d keys", addr, len(resp.Results), len(keys)))
+	}
+	for i, res := range resp.Results {
+		results[i] = server.GetResultFromProto(res)
+	}
+	return results
+}
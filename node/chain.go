@@ -0,0 +1,356 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"kvstore/iface"
+	"kvstore/server"
+	"kvstore/storage"
+	"log"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	chainHopTimeout = time.Second        // allowance per remaining node of the chain
+	chainSkipTTL    = 2 * suspectTimeout // long enough for gossip to declare the member dead
+)
+
+// chainMode reports whether the replica group replicates along a chain
+// instead of broadcasting from the leader.
+func (n *Node) chainMode() bool {
+	return n.ClusterMetadata().Replication == iface.CHAIN
+}
+
+// chain returns the members of the replica group in chain order: the leader
+// is the head, the others follow by id. Members skipped after failing to
+// answer are left out, which is how the chain is reconfigured around them,
+// until this node has brought them up to date again.
+func (n *Node) chain() []replicaTarget {
+	leader, leaderAddr, _ := n.leaderInfo()
+	peers := n.peers()
+
+	ids := []int{n.id}
+	for id := range peers {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	n.chainMu.Lock()
+	defer n.chainMu.Unlock()
+
+	chain := []replicaTarget{{id: leader, addr: leaderAddr}}
+	for _, id := range ids {
+		if id == leader {
+			continue
+		}
+		if skipped, ok := n.chainSkipped[id]; ok {
+			if time.Since(skipped) >= chainSkipTTL && !n.chainSyncing[id] {
+				n.chainSyncing[id] = true
+				go n.resyncChainMember(id, peers[id])
+			}
+			continue
+		}
+		chain = append(chain, replicaTarget{id: id, addr: peers[id]})
+	}
+	return chain
+}
+
+// successor returns the next node of the chain and how many nodes remain
+// after this one. ok is false on the tail.
+func (n *Node) successor() (next replicaTarget, remaining int, ok bool) {
+	chain := n.chain()
+	for i, t := range chain {
+		if t.id == n.id && i+1 < len(chain) {
+			return chain[i+1], len(chain) - i - 1, true
+		}
+	}
+	return replicaTarget{}, 0, false
+}
+
+// skipChainMember removes an unreachable member from the chain and reports it
+// to the failure detector. The member misses the writes sent down the chain
+// meanwhile, so it only comes back through resyncChainMember.
+func (n *Node) skipChainMember(id int) {
+	n.chainMu.Lock()
+	n.chainSkipped[id] = time.Now()
+	n.chainMu.Unlock()
+
+	if m, changed := n.members.suspect(id); changed {
+		n.memberChanged(m)
+	}
+}
+
+// resyncChainMember brings a skipped member up to date and puts it back in
+// the chain behind this node. It sends a snapshot of the keys, then the keys
+// the chain wrote meanwhile, and the last of those with writes held off so
+// none is missed between the transfer and the member's return. Only this
+// node sends the member writes, and it holds everything the member had. On
+// failure the member stays out and is tried again after chainSkipTTL.
+func (n *Node) resyncChainMember(id int, addr string) {
+	defer func() {
+		n.chainMu.Lock()
+		delete(n.chainSyncing, id)
+		n.chainMu.Unlock()
+	}()
+	fail := func(err error) {
+		log.Printf("Node %d could not bring chain member %d at %s up to date: %v", n.id, id, addr, err)
+		n.chainMu.Lock()
+		n.chainSkipped[id] = time.Now()
+		n.chainMu.Unlock()
+	}
+	if addr == "" {
+		return // gone from the group; it is caught up once it joins again
+	}
+
+	// Snapshot, with the keys deleted since the member may still hold
+	written, version := n.chainWritesSince(0)
+	records := n.storage.ExportRange("", "")
+	held := make(map[string]bool, len(records))
+	for _, r := range records {
+		held[r.Key] = true
+	}
+	var deleted []string
+	for _, key := range written {
+		if !held[key] {
+			deleted = append(deleted, key)
+		}
+	}
+	if err := n.sendChainRecords(addr, append(records, n.storage.ExportKeys(deleted)...)); err != nil {
+		fail(err)
+		return
+	}
+
+	// Catch up while writes keep flowing
+	for round := 0; round < catchUpRounds; round++ {
+		written, version = n.chainWritesSince(version)
+		if err := n.sendChainRecords(addr, n.storage.ExportKeys(written)); err != nil {
+			fail(err)
+			return
+		}
+		if len(written) < catchUpThreshold {
+			break
+		}
+	}
+
+	// Hold off writes, ship the rest and readmit the member
+	n.rangeMu.Lock()
+	defer n.rangeMu.Unlock()
+	written, _ = n.chainWritesSince(version)
+	if err := n.sendChainRecords(addr, n.storage.ExportKeys(written)); err != nil {
+		fail(err)
+		return
+	}
+	n.chainMu.Lock()
+	delete(n.chainSkipped, id)
+	n.chainMu.Unlock()
+	log.Printf("Node %d brought chain member %d at %s up to date and put it back in the chain", n.id, id, addr)
+}
+
+// chainWritesSince returns the keys of the chain writes this node applied
+// after version, in key order, and the version reached.
+func (n *Node) chainWritesSince(version uint64) ([]string, uint64) {
+	n.chainMu.Lock()
+	defer n.chainMu.Unlock()
+
+	var keys []string
+	for key, v := range n.chainApplied {
+		if v > version {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, n.chainVersion
+}
+
+// sendChainRecords installs records on the member at addr, on behalf of the
+// head like the chain writes.
+func (n *Node) sendChainRecords(addr string, records []storage.KeyRecord) error {
+	leader, _, epoch := n.leaderInfo()
+	for i := 0; i < len(records); i += transferChunkKeys {
+		ctx, cancel := peerContextWithTimeout(leader, leader, epoch, chainHopTimeout)
+		err := sendOperation(ctx, addr, operation{kind: INSTALL, records: records[i:min(i+transferChunkKeys, len(records))]})
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// headWrite applies op on the head, stamping it with the next version, and
+// returns once the tail has applied it as well. The head resolves a
+// transaction and sends down the chain the writes of the branch it applied.
+// A retry is sent down the chain again, as the first attempt may not have
+// reached the tail.
+func (n *Node) headWrite(op operation) error {
+	w := iface.ChainWrite{Op: op.kind, Key: op.key, Value: op.value, ClientID: op.clientID, Seq: op.seq, Stamp: op.stamp}
+	op.chain = &w
+	duplicate, err := n.sessions.apply(&op, func() error {
+		return outcome(op, n.oplog.commit(&op, func() error {
+			n.chainMu.Lock()
+			defer n.chainMu.Unlock()
+			n.chainVersion++
+			w.Version = n.chainVersion
+
+			if op.kind != TXN {
+				return n.applyChainWriteLocked(&w)
+			}
+			if op.stamp.Writer == 0 {
+				op.stamp.Writer = n.id
+			}
+			st, err := n.applyTxn(op.txn, op.stamp)
+			if err != nil {
+				return err
+			}
+			w.Ops, w.Succeeded, w.Stamp = op.txn.Success, op.txn.Succeeded, st
+			for _, o := range w.Ops {
+				n.chainApplied[o.Key] = w.Version
+			}
+			return nil
+		}))
+	})
+	if err != nil && !errors.Is(err, errCompareFailed) {
+		return err
+	}
+	if !duplicate {
+		n.trackWrites(op)
+	} else if w.Version == 0 {
+		return err // applied before chain replication was on
+	}
+	if perr := n.propagate(w); perr != nil {
+		return perr
+	}
+	return err
+}
+
+// HandleChainWrite applies a write received from the predecessor and passes
+// it on. A write already applied is still passed on, as the head sends a
+// retried write down the chain again and the nodes below may have missed it.
+// The write is kept with the client's session so that this node resends it
+// too if it becomes the head.
+func (n *Node) HandleChainWrite(meta iface.RequestMeta, w iface.ChainWrite) error {
+	n.rangeMu.RLock()
+	defer n.rangeMu.RUnlock()
+
+	if err := n.checkEpoch(meta); err != nil {
+		return err
+	}
+	op := operation{kind: w.Op, clientID: w.ClientID, seq: w.Seq, chain: &w}
+	if w.Op == TXN {
+		op.txn = &iface.Txn{Success: w.Ops, Resolved: true, Succeeded: w.Succeeded}
+	}
+	_, err := n.sessions.apply(&op, func() error {
+		if err := n.applyChainWrite(&w); err != nil {
+			return err
+		}
+		if w.Op == TXN && !w.Succeeded {
+			return errCompareFailed
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errCompareFailed) {
+		return err
+	}
+	return n.propagate(w)
+}
+
+// applyChainWrite applies w unless a write with the same or a newer version
+// of the key was applied already, so every node keeps the head's order. The
+// writes of a transaction are checked key by key. w's stamp is completed as
+// the head applies it.
+func (n *Node) applyChainWrite(w *iface.ChainWrite) error {
+	n.chainMu.Lock()
+	defer n.chainMu.Unlock()
+	return n.applyChainWriteLocked(w)
+}
+
+// applyChainWriteLocked is applyChainWrite for a caller holding chainMu.
+func (n *Node) applyChainWriteLocked(w *iface.ChainWrite) error {
+	if w.Version > n.chainVersion {
+		n.chainVersion = w.Version // a new head continues after the old one
+	}
+	if w.Op == TXN {
+		var ops []storage.BatchOp
+		for _, o := range w.Ops {
+			if w.Version > n.chainApplied[o.Key] {
+				n.chainApplied[o.Key] = w.Version
+				ops = append(ops, o)
+			}
+		}
+		_, err := n.storage.ApplyBatch(ops, w.Stamp)
+		return err
+	}
+	if w.Version <= n.chainApplied[w.Key] {
+		return nil
+	}
+	n.chainApplied[w.Key] = w.Version
+	op := operation{kind: w.Op, key: w.Key, value: w.Value, clientID: w.ClientID, seq: w.Seq, stamp: w.Stamp}
+	err := n.applyLocal(&op)
+	w.Stamp = op.stamp
+	return err
+}
+
+// propagate sends w to the successor and waits for the acknowledgement that
+// comes back from the tail. Unreachable successors are skipped and the write
+// is sent to the next node instead.
+func (n *Node) propagate(w iface.ChainWrite) error {
+	// Writes travel on behalf of the head so the epoch check downstream
+	// never mistakes this node for the leader.
+	leader, _, epoch := n.leaderInfo()
+	for {
+		next, remaining, ok := n.successor()
+		if !ok {
+			return nil // tail
+		}
+		ctx, cancel := peerContextWithTimeout(leader, leader, epoch, time.Duration(remaining)*chainHopTimeout)
+		err := sendChainWrite(ctx, next.addr, w)
+		cancel()
+		if status.Code(err) != codes.Unavailable {
+			return err
+		}
+		log.Printf("Node %d: chain successor %d at %s is unreachable, reconfiguring around it: %v", n.id, next.id, next.addr, err)
+		n.skipChainMember(next.id)
+	}
+}
+
+// chainRead serves a read from the tail, which only holds writes the whole
+// chain has applied.
+func (n *Node) chainRead(key string, revision int64) (string, storage.KeyMeta, error) {
+	for {
+		chain := n.chain()
+		tail := chain[len(chain)-1]
+		if tail.id == n.id {
+			return n.HandleChainRead(key, revision)
+		}
+		value, meta, err := readFromTail(tail.addr, key, revision)
+		if status.Code(err) != codes.Unavailable {
+			return value, meta, err
+		}
+		log.Printf("Node %d: chain tail %d at %s is unreachable, reconfiguring around it: %v", n.id, tail.id, tail.addr, err)
+		n.skipChainMember(tail.id)
+	}
+}
+
+func (n *Node) HandleChainRead(key string, revision int64) (string, storage.KeyMeta, error) {
+	return n.storage.GetAt(key, revision)
+}
+
+func readFromTail(addr, key string, revision int64) (string, storage.KeyMeta, error) {
+	conn, err := dial(addr)
+	if err != nil {
+		return "", storage.KeyMeta{}, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	resp, err := server.NewChainClient(conn).Read(ctx, &server.GetRequest{Key: key, Revision: revision})
+	if err != nil {
+		return "", storage.KeyMeta{}, err
+	}
+	return resp.Value, server.KeyMetaFromProto(resp.Meta), nil
+}
@@ -0,0 +1,64 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"kvstore/iface"
+	"kvstore/server"
+	"kvstore/storage"
+	"time"
+)
+
+// collectionCall is a collection write as it travels between nodes, with its
+// result once applied.
+type collectionCall struct {
+	op      storage.CollectionOp
+	result  storage.CollectionResult
+	applied bool // result is final; replicating the write leaves it alone
+}
+
+// HandleCollection runs a collection command. Writes are applied on the leader
+// of the group owning the key and replicated as the collection they left,
+// stamped with the write's revision, so replicas install the latest state
+// whatever order the writes reach them in; reads are served from this node's
+// copy, or by the owner of the key when it belongs to another shard.
+// Collections live in MemoryStorage next to plain values. They have no
+// history or leases and are not watched, but standby clusters tail them and
+// a rebalance moves them with their range.
+func (n *Node) HandleCollection(meta iface.RequestMeta, op storage.CollectionOp) (storage.CollectionResult, error) {
+	if n.Leaderless() || n.chainMode() {
+		return storage.CollectionResult{}, fmt.Errorf("%w: collections need single-leader replication", iface.ErrUnsupported)
+	}
+	if op.Writes() {
+		call := &collectionCall{op: op}
+		err := n.handleWrite(meta, operation{kind: COLLECTION, key: op.Key, clientID: meta.ClientID, seq: meta.Seq, replicated: meta.Replicated, coll: call})
+		return call.result, err
+	}
+
+	addr, remote := n.route(op.Key)
+	for _, key := range op.Keys {
+		if a, r := n.route(key); a != addr || r != remote {
+			return storage.CollectionResult{}, fmt.Errorf("%w: keys %q and %q belong to different shards", iface.ErrUnsupported, op.Key, key)
+		}
+	}
+	if remote {
+		return readCollectionRemote(addr, op)
+	}
+	return n.storage.ReadCollection(op)
+}
+
+func readCollectionRemote(addr string, op storage.CollectionOp) (storage.CollectionResult, error) {
+	conn, err := dial(addr)
+	if err != nil {
+		return storage.CollectionResult{}, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	resp, err := server.NewCollectionsClient(conn).Apply(ctx, server.CollectionOpToProto(op))
+	if err != nil {
+		return storage.CollectionResult{}, err
+	}
+	return server.CollectionResultFromProto(resp), nil
+}
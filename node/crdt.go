@@ -0,0 +1,260 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"kvstore/iface"
+	"kvstore/server"
+	"kvstore/storage"
+	"log"
+	"math/rand"
+	"time"
+)
+
+const antiEntropyInterval = 5 * time.Second
+
+// crdtTargets returns the replicas of a CRDT key with the read and write
+// quorums. Leaderless clusters use the key's preference list; otherwise the
+// replica group holds every CRDT and the local copy is enough to answer.
+func (n *Node) crdtTargets(key string) ([]replicaTarget, int, int) {
+	if md := n.ClusterMetadata(); md.Replication == iface.LEADERLESS {
+		_, r, w := quorum(md)
+		return n.preferenceList(key, md), r, w
+	}
+	targets := []replicaTarget{{id: n.id}}
+	for id, addr := range n.peers() {
+		targets = append(targets, replicaTarget{id: id, addr: addr})
+	}
+	return targets, 1, 1
+}
+
+// readCRDT merges the local state of key with the states of r replicas.
+func (n *Node) readCRDT(key string, targets []replicaTarget, r int) (*storage.CRDT, error) {
+	state := n.crdts.Get(key)
+
+	answered := 0
+	var remote []replicaTarget
+	for _, t := range targets {
+		if t.id == n.id {
+			answered++
+		} else {
+			remote = append(remote, t)
+		}
+	}
+	if answered >= r {
+		return state, nil
+	}
+
+	replies := make(chan map[string]*storage.CRDT, len(remote))
+	for _, t := range remote {
+		go func(addr string) {
+			reply, err := syncCRDTs(addr, map[string]*storage.CRDT{key: nil})
+			if err != nil {
+				reply = nil
+			}
+			replies <- reply
+		}(t.addr)
+	}
+	for range remote {
+		reply := <-replies
+		if reply == nil {
+			continue
+		}
+		answered++
+		if other := reply[key]; other != nil {
+			if state == nil {
+				state = other
+			} else if err := state.Merge(other); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if answered < r {
+		return nil, fmt.Errorf("%w: %d of %d reads answered", iface.ErrQuorum, answered, r)
+	}
+	return state, nil
+}
+
+// updateCRDT applies an operation to the merged state of key and pushes the
+// result to the replicas, which merge it into theirs. Operations of a node
+// are serialized so its own counter entries and tags never go backwards.
+func (n *Node) updateCRDT(key, typ string, apply func(state *storage.CRDT)) (*storage.CRDT, error) {
+	if key == "" {
+		return nil, fmt.Errorf("%w: key cannot be empty", storage.ErrInvalidArgument)
+	}
+	n.crdtMu.Lock()
+	defer n.crdtMu.Unlock()
+
+	targets, r, w := n.crdtTargets(key)
+	state, err := n.readCRDT(key, targets, r)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		state, err = storage.NewCRDT(typ)
+		if err != nil {
+			return nil, err
+		}
+	} else if state.Type != typ {
+		return nil, storage.ErrWrongType
+	}
+
+	apply(state)
+	merged, err := n.crdts.Merge(key, state)
+	if err != nil {
+		return nil, err
+	}
+
+	acks := 0
+	results := make(chan error, len(targets))
+	for _, t := range targets {
+		if t.id == n.id {
+			acks++
+			continue
+		}
+		go func(addr string) {
+			_, err := syncCRDTs(addr, map[string]*storage.CRDT{key: merged})
+			results <- err
+		}(t.addr)
+	}
+	for range targets[acks:] {
+		if err := <-results; err == nil {
+			acks++
+		}
+	}
+	if acks < w {
+		return merged, fmt.Errorf("%w: %d of %d writes acknowledged", iface.ErrQuorum, acks, w)
+	}
+	return merged, nil
+}
+
+// nextTag returns a unique OR-Set tag. Caller holds crdtMu.
+func (n *Node) nextTag() string {
+	n.crdtSeq++
+	return fmt.Sprintf("%d-%d-%d", n.id, time.Now().UnixNano(), n.crdtSeq)
+}
+
+func (n *Node) HandleCounterIncrement(key string, delta int64) (*storage.CRDT, error) {
+	return n.updateCRDT(key, storage.COUNTER, func(state *storage.CRDT) {
+		state.Counter.Increment(n.id, delta)
+	})
+}
+
+func (n *Node) HandleSetAdd(key string, members []string) (*storage.CRDT, error) {
+	return n.updateCRDT(key, storage.SET, func(state *storage.CRDT) {
+		for _, m := range members {
+			state.Set.Add(m, n.nextTag())
+		}
+	})
+}
+
+func (n *Node) HandleSetRemove(key string, members []string) (*storage.CRDT, error) {
+	return n.updateCRDT(key, storage.SET, func(state *storage.CRDT) {
+		for _, m := range members {
+			state.Set.Remove(m)
+		}
+	})
+}
+
+func (n *Node) HandleRegisterSet(key, value string) (*storage.CRDT, error) {
+	return n.updateCRDT(key, storage.REGISTER, func(state *storage.CRDT) {
+		state.Register.Set(value, time.Now().UnixNano(), n.id)
+	})
+}
+
+func (n *Node) HandleMapPut(key, field, value string) (*storage.CRDT, error) {
+	return n.updateCRDT(key, storage.MAP, func(state *storage.CRDT) {
+		state.Map.Put(field, value, time.Now().UnixNano(), n.id)
+	})
+}
+
+func (n *Node) HandleMapRemove(key, field string) (*storage.CRDT, error) {
+	return n.updateCRDT(key, storage.MAP, func(state *storage.CRDT) {
+		state.Map.Remove(field, time.Now().UnixNano(), n.id)
+	})
+}
+
+func (n *Node) HandleCRDTRead(key string) (*storage.CRDT, error) {
+	targets, r, _ := n.crdtTargets(key)
+	return n.readCRDT(key, targets, r)
+}
+
+// HandleCRDTSync merges the states a peer sent and answers with ours for the
+// same keys. A nil state only asks for ours.
+func (n *Node) HandleCRDTSync(states map[string]*storage.CRDT) map[string]*storage.CRDT {
+	reply := make(map[string]*storage.CRDT, len(states))
+	for key, state := range states {
+		if state != nil {
+			if _, err := n.crdts.Merge(key, state); err != nil {
+				log.Printf("Node %d: merging CRDT %s failed: %v", n.id, key, err)
+			}
+		}
+		reply[key] = n.crdts.Get(key)
+	}
+	return reply
+}
+
+// antiEntropyLoop periodically exchanges CRDT states with a random replica
+// so updates that missed a replica still converge.
+func (n *Node) antiEntropyLoop() {
+	ticker := time.NewTicker(antiEntropyInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		keys := n.crdts.Keys()
+		if len(keys) == 0 {
+			continue
+		}
+
+		var candidates []iface.Member
+		for _, m := range n.members.list() {
+			if m.ID != n.id && m.Status == iface.ALIVE {
+				candidates = append(candidates, m)
+			}
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+		peer := candidates[rand.Intn(len(candidates))]
+
+		batch := make(map[string]*storage.CRDT)
+		for _, key := range keys {
+			targets, _, _ := n.crdtTargets(key)
+			for _, t := range targets {
+				if t.id == peer.ID {
+					batch[key] = n.crdts.Get(key)
+					break
+				}
+			}
+		}
+		if len(batch) == 0 {
+			continue
+		}
+
+		reply, err := syncCRDTs(peer.Addr, batch)
+		if err != nil {
+			continue
+		}
+		for key, state := range reply {
+			if state != nil {
+				n.crdts.Merge(key, state)
+			}
+		}
+	}
+}
+
+func syncCRDTs(addr string, states map[string]*storage.CRDT) (map[string]*storage.CRDT, error) {
+	conn, err := dial(addr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), replicaTimeout)
+	defer cancel()
+	resp, err := server.NewCRDTClient(conn).Sync(ctx, server.CRDTStatesToProto(states))
+	if err != nil {
+		return nil, err
+	}
+	return server.CRDTStatesFromProto(resp), nil
+}
@@ -0,0 +1,153 @@
+package node
+
+import (
+	"fmt"
+	"kvstore/iface"
+	"kvstore/storage"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	sessionTTL      = 10 * time.Minute // sessions idle longer than this are forgotten
+	sessionSweepInt = time.Minute
+	dedupWindow     = 128 // sequence numbers remembered per client below its newest
+)
+
+// session remembers the writes applied for one client within the window
+// below its newest sequence number. Its lock is held while a write of the
+// client applies, so a retry racing the original waits for its result while
+// other clients go on.
+type session struct {
+	mu       sync.Mutex
+	lastSeq  uint64
+	results  map[uint64]result // answer of each applied seq, returned to retries
+	lastSeen time.Time         // guarded by the table's lock
+}
+
+// result is what applying a write answered: its error, and for transactions
+// and collection commands what the caller reads back from the operation. In
+// chain mode it also keeps the write sent down the chain.
+type result struct {
+	err   error
+	txn   *iface.Txn                // TXN: the branch that ran and the compared keys
+	coll  *storage.CollectionResult // COLLECTION
+	chain *iface.ChainWrite         // chain replication: the write a retry sends down the chain again
+}
+
+// dedupTable gives client writes exactly-once semantics. Every replica keeps
+// one: the leader fills it when applying, followers when replaying the
+// replicated write, so a new leader still recognises retries.
+type dedupTable struct {
+	mu       sync.Mutex // guards sessions
+	sessions map[string]*session
+	ttl      time.Duration
+}
+
+func newDedupTable(ttl time.Duration) *dedupTable {
+	return &dedupTable{sessions: make(map[string]*session), ttl: ttl}
+}
+
+// apply runs fn, which applies op, unless op's (clientID, seq) was already
+// applied, in which case the cached answer is filled into op, its error
+// returned and duplicate is true. Requests may arrive out of order within
+// the window; one older than the window cannot be told apart from a retry and
+// is rejected. Requests without a client id are always applied.
+func (d *dedupTable) apply(op *operation, fn func() error) (duplicate bool, err error) {
+	if op.clientID == "" {
+		return false, fn()
+	}
+	s := d.session(op.clientID)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r, ok := s.results[op.seq]; ok {
+		r.restore(op)
+		return true, r.err
+	}
+	if op.seq+dedupWindow <= s.lastSeq {
+		return false, fmt.Errorf("%w: seq %d of client %s, newest is %d", iface.ErrStaleSeq, op.seq, op.clientID, s.lastSeq)
+	}
+
+	err = fn()
+	s.results[op.seq] = newResult(op, err)
+	if op.seq > s.lastSeq {
+		s.lastSeq = op.seq
+		for old := range s.results {
+			if old+dedupWindow <= op.seq {
+				delete(s.results, old)
+			}
+		}
+	}
+	return false, err
+}
+
+// session returns the session of clientID, created on first use.
+func (d *dedupTable) session(clientID string) *session {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	s, ok := d.sessions[clientID]
+	if !ok {
+		s = &session{results: make(map[uint64]result)}
+		d.sessions[clientID] = s
+	}
+	s.lastSeen = time.Now()
+	return s
+}
+
+func newResult(op *operation, err error) result {
+	r := result{err: err}
+	if op.txn != nil {
+		txn := *op.txn
+		r.txn = &txn
+	}
+	if op.coll != nil {
+		res := op.coll.result
+		r.coll = &res
+	}
+	if op.chain != nil {
+		w := *op.chain
+		r.chain = &w
+	}
+	return r
+}
+
+// restore fills the cached answer into op, as applying it did the first time.
+func (r result) restore(op *operation) {
+	if r.txn != nil && op.txn != nil {
+		*op.txn = *r.txn
+	}
+	if r.coll != nil && op.coll != nil {
+		op.coll.result, op.coll.applied = *r.coll, true
+	}
+	if r.chain != nil && op.chain != nil {
+		*op.chain = *r.chain
+	}
+}
+
+// expire drops sessions that have been idle for longer than the ttl.
+func (d *dedupTable) expire(now time.Time) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	expired := 0
+	for id, s := range d.sessions {
+		if now.Sub(s.lastSeen) > d.ttl {
+			delete(d.sessions, id)
+			expired++
+		}
+	}
+	return expired
+}
+
+func (n *Node) expireSessions() {
+	ticker := time.NewTicker(sessionSweepInt)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		if expired := n.sessions.expire(now); expired > 0 {
+			log.Printf("Node %d expired %d client sessions", n.id, expired)
+		}
+	}
+}
@@ -0,0 +1,386 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"kvstore/iface"
+	"kvstore/server"
+	"kvstore/storage"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultReplicas     = 3
+	replicaTimeout      = time.Second
+	hintHandoffInterval = 2 * time.Second
+)
+
+// replicaTarget is a node chosen to hold a copy of a key.
+type replicaTarget struct {
+	id      int
+	addr    string
+	hintFor int // replica this node stands in for while it is unreachable, 0 if none
+}
+
+// hintStore keeps writes accepted on behalf of unreachable replicas until
+// they can be handed off.
+type hintStore struct {
+	mu     sync.Mutex
+	byNode map[int]map[string][]storage.Sibling
+}
+
+func newHintStore() *hintStore {
+	return &hintStore{byNode: make(map[int]map[string][]storage.Sibling)}
+}
+
+func (h *hintStore) add(nodeID int, key string, siblings []storage.Sibling) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.byNode[nodeID] == nil {
+		h.byNode[nodeID] = make(map[string][]storage.Sibling)
+	}
+	h.byNode[nodeID][key] = storage.Reconcile(append(h.byNode[nodeID][key], siblings...))
+}
+
+func (h *hintStore) take(nodeID int) map[string][]storage.Sibling {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	hints := h.byNode[nodeID]
+	delete(h.byNode, nodeID)
+	return hints
+}
+
+// get returns the siblings of key held for any replica.
+func (h *hintStore) get(key string) []storage.Sibling {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var siblings []storage.Sibling
+	for _, hints := range h.byNode {
+		siblings = append(siblings, hints[key]...)
+	}
+	return siblings
+}
+
+func (h *hintStore) nodes() []int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var ids []int
+	for id := range h.byNode {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Leaderless reports whether the cluster runs the leaderless replication
+// mode, in which any node coordinates reads and writes for any key.
+func (n *Node) Leaderless() bool {
+	return n.ClusterMetadata().Replication == iface.LEADERLESS
+}
+
+// quorum returns N, R and W, defaulting to a majority of 3 replicas.
+func quorum(md iface.ClusterMetadata) (int, int, int) {
+	replicas, r, w := md.Replicas, md.ReadQuorum, md.WriteQuorum
+	if replicas <= 0 {
+		replicas = defaultReplicas
+	}
+	if r <= 0 {
+		r = replicas/2 + 1
+	}
+	if w <= 0 {
+		w = replicas/2 + 1
+	}
+	return replicas, r, w
+}
+
+// nodeRing returns a hash ring over the ids of every known member. It is
+// rebuilt only when the membership changes.
+func (n *Node) nodeRing(members []iface.Member, virtualNodes int) *HashRing {
+	ids := make([]int, 0, len(members))
+	for _, m := range members {
+		ids = append(ids, m.ID)
+	}
+	sort.Ints(ids)
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+	signature := strings.Join(parts, ",")
+
+	n.versionedMu.Lock()
+	defer n.versionedMu.Unlock()
+	if n.ringSignature != signature {
+		n.replicaRing = NewHashRing(ids, virtualNodes)
+		n.ringSignature = signature
+	}
+	return n.replicaRing
+}
+
+// preferenceList returns the replicas for key: the first N members clockwise
+// on the ring. An unreachable one is replaced by the next healthy member,
+// which keeps the write as a hint for it (sloppy quorum).
+func (n *Node) preferenceList(key string, md iface.ClusterMetadata) []replicaTarget {
+	replicas, _, _ := quorum(md)
+	members := n.members.list()
+	byID := make(map[int]iface.Member, len(members))
+	for _, m := range members {
+		byID[m.ID] = m
+	}
+	healthy := func(id int) bool { return byID[id].Status == iface.ALIVE }
+
+	order := n.nodeRing(members, md.VirtualNodes).Successors(key, len(members))
+	intended := order[:min(replicas, len(order))]
+	var spares []int
+	for _, id := range order[len(intended):] {
+		if healthy(id) {
+			spares = append(spares, id)
+		}
+	}
+
+	var targets []replicaTarget
+	for _, id := range intended {
+		if healthy(id) {
+			targets = append(targets, replicaTarget{id: id, addr: byID[id].Addr})
+		} else if len(spares) > 0 {
+			targets = append(targets, replicaTarget{id: spares[0], addr: byID[spares[0]].Addr, hintFor: id})
+			spares = spares[1:]
+		}
+	}
+	return targets
+}
+
+func (n *Node) HandleVersionedPut(key, value string, context storage.VectorClock) (storage.VectorClock, error) {
+	return n.coordinateWrite(key, storage.Sibling{Value: value}, context)
+}
+
+func (n *Node) HandleVersionedDelete(key string, context storage.VectorClock) (storage.VectorClock, error) {
+	return n.coordinateWrite(key, storage.Sibling{Deleted: true}, context)
+}
+
+// coordinateWrite creates a version descending from the client's context and
+// succeeds once W replicas stored it.
+func (n *Node) coordinateWrite(key string, sibling storage.Sibling, context storage.VectorClock) (storage.VectorClock, error) {
+	if key == "" {
+		return nil, fmt.Errorf("%w: key cannot be empty", storage.ErrInvalidArgument)
+	}
+	md := n.ClusterMetadata()
+	_, _, w := quorum(md)
+
+	sibling.Clock = n.nextClock(key, context)
+	acks := n.storeOnReplicas(key, []storage.Sibling{sibling}, n.preferenceList(key, md))
+	if acks < w {
+		return sibling.Clock, fmt.Errorf("%w: %d of %d writes acknowledged", iface.ErrQuorum, acks, w)
+	}
+	return sibling.Clock, nil
+}
+
+// nextClock advances this node's entry past anything it issued before or
+// the context has seen.
+func (n *Node) nextClock(key string, context storage.VectorClock) storage.VectorClock {
+	n.versionedMu.Lock()
+	defer n.versionedMu.Unlock()
+
+	counter := max(n.clockCounter, context[n.id])
+	for _, s := range n.versioned.Get(key) {
+		counter = max(counter, s.Clock[n.id])
+	}
+	n.clockCounter = counter + 1
+
+	clock := context.Copy()
+	clock[n.id] = n.clockCounter
+	return clock
+}
+
+func (n *Node) storeOnReplicas(key string, siblings []storage.Sibling, targets []replicaTarget) int {
+	results := make(chan error, len(targets))
+	for _, t := range targets {
+		go func(t replicaTarget) {
+			if t.id == n.id {
+				results <- n.HandleStore(key, siblings, t.hintFor)
+				return
+			}
+			results <- storeRemote(t.addr, key, siblings, t.hintFor)
+		}(t)
+	}
+
+	acks := 0
+	for range targets {
+		if err := <-results; err == nil {
+			acks++
+		} else {
+			log.Printf("Node %d: storing %s on a replica failed: %v", n.id, key, err)
+		}
+	}
+	return acks
+}
+
+// HandleVersionedGet collects the versions of R replicas and returns the
+// concurrent ones with a context covering all of them. Replicas that missed
+// a version are repaired in the background.
+func (n *Node) HandleVersionedGet(key string) ([]storage.Sibling, storage.VectorClock, error) {
+	if key == "" {
+		return nil, nil, fmt.Errorf("%w: key cannot be empty", storage.ErrInvalidArgument)
+	}
+	md := n.ClusterMetadata()
+	_, r, _ := quorum(md)
+	targets := n.preferenceList(key, md)
+
+	type reply struct {
+		target   replicaTarget
+		siblings []storage.Sibling
+		err      error
+	}
+	replies := make(chan reply, len(targets))
+	for _, t := range targets {
+		go func(t replicaTarget) {
+			if t.id == n.id {
+				replies <- reply{target: t, siblings: n.HandleFetch(key)}
+				return
+			}
+			siblings, err := fetchRemote(t.addr, key)
+			replies <- reply{target: t, siblings: siblings, err: err}
+		}(t)
+	}
+
+	var answered []reply
+	var all []storage.Sibling
+	for range targets {
+		rep := <-replies
+		if rep.err != nil {
+			continue
+		}
+		answered = append(answered, rep)
+		all = append(all, rep.siblings...)
+	}
+	if len(answered) < r {
+		return nil, nil, fmt.Errorf("%w: %d of %d reads answered", iface.ErrQuorum, len(answered), r)
+	}
+
+	merged := storage.Reconcile(all)
+	context := storage.VectorClock{}
+	var live []storage.Sibling
+	for _, s := range merged {
+		context = context.Merge(s.Clock)
+		if !s.Deleted {
+			live = append(live, s)
+		}
+	}
+
+	var stale []replicaTarget
+	for _, rep := range answered {
+		if !sameSiblings(storage.Reconcile(rep.siblings), merged) {
+			stale = append(stale, replicaTarget{id: rep.target.id, addr: rep.target.addr})
+		}
+	}
+	if len(stale) > 0 {
+		go n.storeOnReplicas(key, merged, stale)
+	}
+	return live, context, nil
+}
+
+// sameSiblings reports whether both sets hold the same versions.
+func sameSiblings(a, b []storage.Sibling) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, x := range a {
+		found := false
+		for _, y := range b {
+			if x.Clock.Compare(y.Clock) == storage.EQUAL {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *Node) HandleStore(key string, siblings []storage.Sibling, hintFor int) error {
+	if hintFor != 0 && hintFor != n.id {
+		n.hints.add(hintFor, key, siblings)
+		return nil
+	}
+	n.versioned.Merge(key, siblings)
+	return nil
+}
+
+// HandleFetch returns the versions of key this node holds, including those it
+// keeps as hints, so a write accepted by a stand-in stays readable before it
+// is handed off.
+func (n *Node) HandleFetch(key string) []storage.Sibling {
+	siblings := n.versioned.Get(key)
+	if hinted := n.hints.get(key); len(hinted) > 0 {
+		siblings = storage.Reconcile(append(siblings, hinted...))
+	}
+	return siblings
+}
+
+// handoffLoop delivers hinted writes to their replica once it is back.
+func (n *Node) handoffLoop() {
+	ticker := time.NewTicker(hintHandoffInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		alive := make(map[int]string)
+		for _, m := range n.members.list() {
+			if m.Status == iface.ALIVE {
+				alive[m.ID] = m.Addr
+			}
+		}
+		for _, id := range n.hints.nodes() {
+			addr, ok := alive[id]
+			if !ok {
+				continue
+			}
+			delivered := 0
+			for key, siblings := range n.hints.take(id) {
+				if err := storeRemote(addr, key, siblings, 0); err != nil {
+					n.hints.add(id, key, siblings)
+					continue
+				}
+				delivered++
+			}
+			log.Printf("Node %d handed off %d hinted keys to node %d", n.id, delivered, id)
+		}
+	}
+}
+
+func storeRemote(addr, key string, siblings []storage.Sibling, hintFor int) error {
+	conn, err := dial(addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), replicaTimeout)
+	defer cancel()
+	_, err = server.NewReplicaClient(conn).Store(ctx, &server.StoreRequest{Key: key, Siblings: server.SiblingsToProto(siblings), HintFor: int32(hintFor)})
+	return err
+}
+
+func fetchRemote(addr, key string) ([]storage.Sibling, error) {
+	conn, err := dial(addr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), replicaTimeout)
+	defer cancel()
+	resp, err := server.NewReplicaClient(conn).Fetch(ctx, &server.FetchRequest{Key: key})
+	if err != nil {
+		return nil, err
+	}
+	return server.SiblingsFromProto(resp.Siblings), nil
+}
@@ -0,0 +1,114 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"kvstore/iface"
+	"kvstore/storage"
+)
+
+// HandleCampaign blocks until lease leads the election name, and returns the
+// leadership. Candidates queue like lock waiters, each with a key under name
+// attached to its lease and holding the value it proclaims; the oldest key
+// leads. A candidate campaigning again keeps its place and updates its value,
+// and giving up on that campaign does not withdraw the candidacy. Who leads
+// is decided on the leader of the group owning name, where the lease must
+// belong.
+func (n *Node) HandleCampaign(ctx context.Context, name string, lease int64, value string) (iface.LeaderKey, error) {
+	if err := n.leaseSupported(); err != nil {
+		return iface.LeaderKey{}, err
+	}
+	if name == "" || lease == 0 {
+		return iface.LeaderKey{}, fmt.Errorf("%w: a campaign needs a name and a lease", storage.ErrInvalidArgument)
+	}
+	prefix, key := queueKey(name, lease)
+
+	put := storage.BatchOp{Key: key, Value: value, Lease: lease}
+	txn := iface.Txn{
+		Compares: []storage.Compare{{Key: key, Target: storage.CMP_EXISTS, Op: storage.CMP_EQ, Exists: false}},
+		Success:  []storage.BatchOp{put},
+		Failure:  []storage.BatchOp{put},
+	}
+	mine, err := n.waitTurn(ctx, prefix, key, func() (bool, error) {
+		created, _, err := n.HandleTxn(iface.RequestMeta{}, txn)
+		return created, err
+	})
+	if err != nil {
+		return iface.LeaderKey{}, err
+	}
+	return iface.LeaderKey{Name: name, Key: key, Revision: mine.Meta.CreateRevision, Lease: lease}, nil
+}
+
+// HandleProclaim sets the value of the leadership, failing with
+// ErrNotElected once it is lost.
+func (n *Node) HandleProclaim(meta iface.RequestMeta, leader iface.LeaderKey, value string) error {
+	return n.leaderTxn(meta, leader, storage.BatchOp{Key: leader.Key, Value: value, Lease: leader.Lease})
+}
+
+// HandleResign gives the leadership up, handing it to the next candidate.
+func (n *Node) HandleResign(meta iface.RequestMeta, leader iface.LeaderKey) error {
+	return n.leaderTxn(meta, leader, storage.BatchOp{Key: leader.Key, Delete: true})
+}
+
+// leaderTxn applies op if the key of leader is still the one it campaigned
+// with. A key that lapsed and was written again has another create revision.
+func (n *Node) leaderTxn(meta iface.RequestMeta, leader iface.LeaderKey, op storage.BatchOp) error {
+	if err := n.leaseSupported(); err != nil {
+		return err
+	}
+	txn := iface.Txn{
+		Compares: []storage.Compare{{Key: leader.Key, Target: storage.CMP_CREATE, Op: storage.CMP_EQ, Revision: leader.Revision}},
+		Success:  []storage.BatchOp{op},
+	}
+	ok, _, err := n.HandleTxn(meta, txn)
+	if err != nil {
+		return err
+	} else if !ok {
+		return fmt.Errorf("%w: %s", iface.ErrNotElected, leader.Key)
+	}
+	return nil
+}
+
+// HandleLeader returns the leader of the election name and its value.
+func (n *Node) HandleLeader(name string) (iface.LeaderKey, string, error) {
+	entries, err := n.queue(queuePrefix(name))
+	if err != nil {
+		return iface.LeaderKey{}, "", err
+	}
+	if len(entries) == 0 {
+		return iface.LeaderKey{}, "", fmt.Errorf("%w: %s", iface.ErrNoLeader, name)
+	}
+	return leaderKey(name, entries[0]), entries[0].Value, nil
+}
+
+// HandleObserve sends the leader of the election name and its value, then
+// again each time either changes, until ctx is done or send fails.
+func (n *Node) HandleObserve(ctx context.Context, name string, send func(iface.LeaderKey, string) error) error {
+	prefix := queuePrefix(name)
+	w := n.watches.add(prefix, storage.PrefixEnd(prefix))
+	defer func() { n.watches.remove(w) }()
+
+	var last storage.Entry
+	for {
+		entries, err := n.queue(prefix)
+		if err != nil {
+			return err
+		}
+		if len(entries) > 0 {
+			head := entries[0]
+			if head.Key != last.Key || head.Meta.CreateRevision != last.Meta.CreateRevision || head.Value != last.Value {
+				if err := send(leaderKey(name, head), head.Value); err != nil {
+					return err
+				}
+				last = head
+			}
+		}
+		if w, err = n.waitQueue(ctx, w, prefix); err != nil {
+			return err
+		}
+	}
+}
+
+func leaderKey(name string, e storage.Entry) iface.LeaderKey {
+	return iface.LeaderKey{Name: name, Key: e.Key, Revision: e.Meta.CreateRevision, Lease: e.Meta.Lease}
+}
@@ -0,0 +1,113 @@
+package node
+
+import (
+	"fmt"
+	"kvstore/iface"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const epochFile = "epoch"
+
+// loadEpoch reads the persisted leader epoch, 0 if none was saved yet.
+func loadEpoch(dir string) uint64 {
+	data, err := os.ReadFile(filepath.Join(dir, epochFile))
+	if err != nil {
+		return 0
+	}
+	epoch, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		log.Printf("Ignoring corrupt epoch file in %s: %v", dir, err)
+		return 0
+	}
+	return epoch
+}
+
+// saveEpoch writes the epoch through a temp file so a crash never leaves a partial value.
+func saveEpoch(dir string, epoch uint64) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	tmp := filepath.Join(dir, epochFile+".tmp")
+	if err := os.WriteFile(tmp, []byte(strconv.FormatUint(epoch, 10)), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(dir, epochFile))
+}
+
+func (n *Node) GetEpoch() uint64 {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.epoch
+}
+
+// setEpochLocked persists epoch if it is newer than the current one. Caller holds n.mu.
+func (n *Node) setEpochLocked(epoch uint64) error {
+	if epoch <= n.epoch {
+		return nil
+	}
+	if err := saveEpoch(n.dataDir, epoch); err != nil {
+		return fmt.Errorf("persist epoch: %w", err)
+	}
+	n.epoch = epoch
+	return nil
+}
+
+// checkEpoch fences requests coming from peers. Operations from an older epoch
+// are rejected, and so are operations at the current epoch that follow another
+// leader than the one this node knows for it, so two nodes claiming the same
+// epoch cannot accept each other's writes. A newer epoch is persisted: a
+// follower takes the sender's leader as its own, a leader learns it has been
+// replaced and steps down.
+func (n *Node) checkEpoch(meta iface.RequestMeta) error {
+	if meta.RequesterID == 0 {
+		return nil // client request
+	}
+	leader := meta.Leader
+	if leader <= 0 {
+		leader = meta.RequesterID
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if meta.Epoch < n.epoch {
+		log.Printf("Node %d rejecting request from node %d with stale epoch %d (current %d)", n.id, meta.RequesterID, meta.Epoch, n.epoch)
+		return fmt.Errorf("%w: got %d, current is %d", iface.ErrStaleEpoch, meta.Epoch, n.epoch)
+	}
+	if meta.Epoch == n.epoch {
+		if n.leader > 0 && leader != n.leader {
+			log.Printf("Node %d rejecting request from node %d: epoch %d belongs to node %d, not %d", n.id, meta.RequesterID, meta.Epoch, n.leader, leader)
+			return fmt.Errorf("%w: epoch %d is led by node %d, not %d", iface.ErrStaleEpoch, meta.Epoch, n.leader, leader)
+		}
+		if n.leader <= 0 && n.state != LEADER {
+			n.followLocked(leader)
+		}
+		return nil
+	}
+
+	if err := n.setEpochLocked(meta.Epoch); err != nil {
+		return err
+	}
+	if n.state == LEADER {
+		log.Printf("Node %d saw epoch %d from node %d, stepping down", n.id, meta.Epoch, meta.RequesterID)
+		n.state = FOLLOWER
+		n.followLocked(leader)
+		return fmt.Errorf("%w: node %d is no longer leader", iface.ErrStaleEpoch, n.id)
+	}
+	log.Printf("Node %d following node %d at epoch %d", n.id, leader, meta.Epoch)
+	n.followLocked(leader)
+	return nil
+}
+
+// followLocked records leader as the leader of the current epoch. Caller holds n.mu.
+func (n *Node) followLocked(leader int) {
+	n.leader = leader
+	n.leaderAddr = ""
+	if addr, ok := n.nodes[leader]; ok {
+		n.leaderAddr = addr
+	}
+}
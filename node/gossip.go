@@ -0,0 +1,426 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"kvstore/iface"
+	"kvstore/server"
+	"log"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// SWIM parameters, see "SWIM: Scalable Weakly-consistent Infection-style
+// Process Group Membership Protocol" (Das et al.).
+const (
+	gossipInterval   = time.Second            // protocol period
+	ackTimeout       = 300 * time.Millisecond // direct probe timeout
+	indirectProbes   = 3                      // members asked to probe on our behalf
+	suspectTimeout   = 5 * time.Second        // suspect -> dead
+	retransmitMult   = 3                      // updates are piggybacked retransmitMult*log(n) times
+	maxPiggyback     = 8                      // updates attached to a single message
+	joinAttempts     = 5
+	joinRetryBackoff = 200 * time.Millisecond
+)
+
+type memberState struct {
+	iface.Member
+	suspectedAt time.Time
+}
+
+type pendingUpdate struct {
+	member    iface.Member
+	remaining int // transmissions left
+}
+
+// membership is a node's view of the cluster, maintained by SWIM gossip.
+type membership struct {
+	mu         sync.Mutex
+	self       iface.Member
+	members    map[int]*memberState // every known member except self
+	queue      map[int]*pendingUpdate
+	probeOrder []int
+}
+
+func newMembership(self iface.Member) *membership {
+	return &membership{
+		self:    self,
+		members: make(map[int]*memberState),
+		queue:   make(map[int]*pendingUpdate),
+	}
+}
+
+// enqueueLocked schedules m to be piggybacked on outgoing messages.
+func (g *membership) enqueueLocked(m iface.Member) {
+	transmissions := retransmitMult * int(math.Ceil(math.Log2(float64(len(g.members)+2))))
+	g.queue[m.ID] = &pendingUpdate{member: m, remaining: transmissions}
+}
+
+// applyLocked merges one update using SWIM's incarnation rules and reports
+// whether the local view changed.
+func (g *membership) applyLocked(m iface.Member) bool {
+	if m.ID == g.self.ID {
+		if m.Status != iface.ALIVE && m.Incarnation >= g.self.Incarnation {
+			// Refute the suspicion with a newer incarnation
+			g.self.Incarnation = m.Incarnation + 1
+			g.enqueueLocked(g.self)
+		} else if m.Status == iface.ALIVE && m.Incarnation > g.self.Incarnation {
+			g.self.Incarnation = m.Incarnation
+		}
+		return false
+	}
+
+	cur, ok := g.members[m.ID]
+	if !ok {
+		if m.Status == iface.DEAD {
+			return false
+		}
+		g.members[m.ID] = &memberState{Member: m, suspectedAt: time.Now()}
+		g.probeOrder = append(g.probeOrder, m.ID)
+		g.enqueueLocked(m)
+		return true
+	}
+
+	switch m.Status {
+	case iface.ALIVE:
+		if m.Incarnation <= cur.Incarnation {
+			return false
+		}
+	case iface.SUSPECT:
+		if cur.Status == iface.DEAD || m.Incarnation < cur.Incarnation ||
+			(m.Incarnation == cur.Incarnation && cur.Status == iface.SUSPECT) {
+			return false
+		}
+		cur.suspectedAt = time.Now()
+	case iface.DEAD:
+		if cur.Status == iface.DEAD {
+			return false
+		}
+	}
+	cur.Member = m
+	g.enqueueLocked(m)
+	return true
+}
+
+func (g *membership) merge(updates []iface.Member) []iface.Member {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var changed []iface.Member
+	for _, m := range updates {
+		if g.applyLocked(m) {
+			changed = append(changed, m)
+		}
+	}
+	return changed
+}
+
+// join admits a member contacting us directly. A rejoining member gets an
+// incarnation above the one we remember so its old death is overridden.
+func (g *membership) join(m iface.Member) (iface.Member, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	m.Status = iface.ALIVE
+	if cur, ok := g.members[m.ID]; ok && cur.Incarnation >= m.Incarnation {
+		m.Incarnation = cur.Incarnation + 1
+	}
+	return m, g.applyLocked(m)
+}
+
+// piggyback returns the updates to attach to the next message.
+func (g *membership) piggyback() []iface.Member {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var updates []iface.Member
+	for id, u := range g.queue {
+		if len(updates) == maxPiggyback {
+			break
+		}
+		updates = append(updates, u.member)
+		u.remaining--
+		if u.remaining <= 0 {
+			delete(g.queue, id)
+		}
+	}
+	return updates
+}
+
+// nextTarget walks the members in a shuffled round-robin order, as SWIM
+// prescribes, skipping dead ones.
+func (g *membership) nextTarget() (iface.Member, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for len(g.probeOrder) > 0 {
+		id := g.probeOrder[0]
+		g.probeOrder = g.probeOrder[1:]
+		if m, ok := g.members[id]; ok && m.Status != iface.DEAD {
+			return m.Member, true
+		}
+	}
+
+	for id, m := range g.members {
+		if m.Status != iface.DEAD {
+			g.probeOrder = append(g.probeOrder, id)
+		}
+	}
+	rand.Shuffle(len(g.probeOrder), func(i, j int) {
+		g.probeOrder[i], g.probeOrder[j] = g.probeOrder[j], g.probeOrder[i]
+	})
+	if len(g.probeOrder) == 0 {
+		return iface.Member{}, false
+	}
+	id := g.probeOrder[0]
+	g.probeOrder = g.probeOrder[1:]
+	return g.members[id].Member, true
+}
+
+// randomMembers picks up to k live members other than exclude.
+func (g *membership) randomMembers(k int, exclude int) []iface.Member {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var candidates []iface.Member
+	for id, m := range g.members {
+		if id != exclude && m.Status == iface.ALIVE {
+			candidates = append(candidates, m.Member)
+		}
+	}
+	rand.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+	if len(candidates) > k {
+		candidates = candidates[:k]
+	}
+	return candidates
+}
+
+func (g *membership) suspect(id int) (iface.Member, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	cur, ok := g.members[id]
+	if !ok || cur.Status != iface.ALIVE {
+		return iface.Member{}, false
+	}
+	m := cur.Member
+	m.Status = iface.SUSPECT
+	return m, g.applyLocked(m)
+}
+
+// expireSuspects declares dead the members suspected for longer than timeout.
+func (g *membership) expireSuspects(now time.Time, timeout time.Duration) []iface.Member {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var dead []iface.Member
+	for _, cur := range g.members {
+		if cur.Status == iface.SUSPECT && now.Sub(cur.suspectedAt) > timeout {
+			m := cur.Member
+			m.Status = iface.DEAD
+			if g.applyLocked(m) {
+				dead = append(dead, m)
+			}
+		}
+	}
+	return dead
+}
+
+func (g *membership) list() []iface.Member {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	members := []iface.Member{g.self}
+	for _, m := range g.members {
+		members = append(members, m.Member)
+	}
+	return members
+}
+
+func (g *membership) setShard(shard int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.self.Shard = shard
+	g.self.Incarnation++ // so peers replace what they know about us
+	g.enqueueLocked(g.self)
+}
+
+func (g *membership) whoami() iface.Member {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.self
+}
+
+// Members returns this node's view of the cluster, itself included.
+func (n *Node) Members() []iface.Member {
+	return n.members.list()
+}
+
+// Join contacts any member of the cluster and learns the membership from it.
+func (n *Node) Join(seedAddr string) error {
+	var err error
+	for attempt := 0; attempt < joinAttempts; attempt++ {
+		var members []iface.Member
+		members, err = n.callJoin(seedAddr)
+		if err == nil {
+			n.mergeGossip(members)
+			log.Printf("Node %d joined the cluster via %s, %d members known", n.id, seedAddr, len(members))
+			return nil
+		}
+		time.Sleep(joinRetryBackoff)
+	}
+	return fmt.Errorf("join via %s: %w", seedAddr, err)
+}
+
+// mergeGossip applies updates and keeps Node.nodes in line with the membership.
+func (n *Node) mergeGossip(updates []iface.Member) {
+	for _, m := range n.members.merge(updates) {
+		n.memberChanged(m)
+	}
+}
+
+func (n *Node) memberChanged(m iface.Member) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if m.Shard != n.shard {
+		delete(n.nodes, m.ID) // not part of our replica group
+		return
+	}
+	if m.Status == iface.DEAD {
+		log.Printf("Node %d: member %d at %s is dead", n.id, m.ID, m.Addr)
+		delete(n.nodes, m.ID)
+		return
+	}
+	if m.Status == iface.SUSPECT {
+		log.Printf("Node %d: member %d at %s is suspected", n.id, m.ID, m.Addr)
+	}
+	// A member joining the group, or back from the dead, has missed writes
+	if _, known := n.nodes[m.ID]; !known && n.state == LEADER && n.leader == n.id && n.metadata.Replication != iface.LEADERLESS && n.metadata.Replication != iface.CHAIN {
+		go n.seedReplica(m.ID, m.Addr)
+	}
+	n.nodes[m.ID] = m.Addr
+}
+
+func (n *Node) HandlePing(from iface.Member, updates []iface.Member) []iface.Member {
+	n.mergeGossip(append(updates, from))
+	return n.members.piggyback()
+}
+
+func (n *Node) HandlePingReq(from, target iface.Member, updates []iface.Member) (bool, []iface.Member) {
+	n.mergeGossip(append(updates, from))
+	return n.ping(target, ackTimeout), n.members.piggyback()
+}
+
+func (n *Node) HandleJoin(member iface.Member) []iface.Member {
+	if m, changed := n.members.join(member); changed {
+		log.Printf("Node %d: member %d joined from %s", n.id, m.ID, m.Addr)
+		n.memberChanged(m)
+	}
+	return n.members.list()
+}
+
+// gossipLoop runs one SWIM protocol period per tick: probe a member directly,
+// fall back to indirect probes through others, and suspect it if nobody
+// gets an ack.
+func (n *Node) gossipLoop() {
+	ticker := time.NewTicker(gossipInterval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		for _, m := range n.members.expireSuspects(now, suspectTimeout) {
+			n.memberChanged(m)
+		}
+
+		target, ok := n.members.nextTarget()
+		if !ok || n.ping(target, ackTimeout) {
+			continue
+		}
+
+		acks := make(chan bool)
+		helpers := n.members.randomMembers(indirectProbes, target.ID)
+		for _, via := range helpers {
+			go func(via iface.Member) {
+				acks <- n.pingReq(via, target, gossipInterval-ackTimeout)
+			}(via)
+		}
+		acked := false
+		for range helpers {
+			acked = <-acks || acked
+		}
+
+		if !acked {
+			if m, changed := n.members.suspect(target.ID); changed {
+				n.memberChanged(m)
+			}
+		}
+	}
+}
+
+func (n *Node) ping(target iface.Member, timeout time.Duration) bool {
+	conn, err := dial(target.Addr)
+	if err != nil {
+		return false
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := server.NewGossipClient(conn).Ping(ctx, &server.PingRequest{
+		From:     server.MemberToProto(n.members.whoami()),
+		Updates:  server.MembersToProto(n.members.piggyback()),
+		Metadata: server.MetadataToProto(n.ClusterMetadata()),
+	})
+	if err != nil {
+		return false
+	}
+	n.MergeClusterMetadata(server.MetadataFromProto(resp.Metadata))
+	n.mergeGossip(server.MembersFromProto(resp.Updates))
+	return resp.Ack
+}
+
+func (n *Node) pingReq(via, target iface.Member, timeout time.Duration) bool {
+	conn, err := dial(via.Addr)
+	if err != nil {
+		return false
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := server.NewGossipClient(conn).PingReq(ctx, &server.PingReqRequest{
+		From:     server.MemberToProto(n.members.whoami()),
+		Target:   server.MemberToProto(target),
+		Updates:  server.MembersToProto(n.members.piggyback()),
+		Metadata: server.MetadataToProto(n.ClusterMetadata()),
+	})
+	if err != nil {
+		return false
+	}
+	n.MergeClusterMetadata(server.MetadataFromProto(resp.Metadata))
+	n.mergeGossip(server.MembersFromProto(resp.Updates))
+	return resp.Ack
+}
+
+func (n *Node) callJoin(seedAddr string) ([]iface.Member, error) {
+	conn, err := dial(seedAddr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	resp, err := server.NewGossipClient(conn).Join(ctx, &server.JoinRequest{Member: server.MemberToProto(n.members.whoami())})
+	if err != nil {
+		return nil, err
+	}
+	n.MergeClusterMetadata(server.MetadataFromProto(resp.Metadata))
+	return server.MembersFromProto(resp.Members), nil
+}
@@ -0,0 +1,211 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"kvstore/iface"
+	"kvstore/server"
+	"kvstore/storage"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const leaseTick = 100 * time.Millisecond // how often the leader looks for expired leases
+
+// leaseTimers holds when each lease expires. Only the leader's timers count:
+// it revokes the leases that expire, and resets every timer to a full TTL
+// when it takes over, so a leader change never shortens a lease.
+type leaseTimers struct {
+	mu        sync.Mutex
+	deadlines map[int64]time.Time
+}
+
+func newLeaseTimers() *leaseTimers {
+	return &leaseTimers{deadlines: make(map[int64]time.Time)}
+}
+
+func (t *leaseTimers) touch(id int64, ttl time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.deadlines[id] = time.Now().Add(ttl)
+}
+
+func (t *leaseTimers) forget(id int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.deadlines, id)
+}
+
+func (t *leaseTimers) remaining(id int64) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return max(time.Until(t.deadlines[id]), 0)
+}
+
+// expired returns the leases past their deadline and forgets them, so each is
+// revoked once.
+func (t *leaseTimers) expired(now time.Time) []int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var ids []int64
+	for id, deadline := range t.deadlines {
+		if now.After(deadline) {
+			ids = append(ids, id)
+			delete(t.deadlines, id)
+		}
+	}
+	return ids
+}
+
+// renewLeases gives every lease a full TTL. Called when this node becomes the
+// leader, which does not know when the old leader last heard of each lease.
+func (n *Node) renewLeases() {
+	for id, ttl := range n.storage.Leases() {
+		n.leases.touch(id, ttl)
+	}
+}
+
+// leaseSupported refuses leases where writes do not go through the leader's
+// operations. Leases belong to the replica group of the node granting them.
+func (n *Node) leaseSupported() error {
+	if n.Leaderless() || n.chainMode() {
+		return fmt.Errorf("%w: leases need single-leader replication", iface.ErrUnsupported)
+	}
+	return nil
+}
+
+// HandleLeaseGrant creates a lease with ttl and returns its id, picked at
+// random when id is 0.
+func (n *Node) HandleLeaseGrant(meta iface.RequestMeta, id int64, ttl time.Duration) (int64, error) {
+	if err := n.leaseSupported(); err != nil {
+		return 0, err
+	}
+	for id == 0 {
+		id = rand.Int63()
+	}
+	op := operation{kind: LEASE_GRANT, lease: id, ttl: ttl, clientID: meta.ClientID, seq: meta.Seq, replicated: meta.Replicated}
+	return id, n.handleWrite(meta, op)
+}
+
+// HandleLeaseRevoke drops the lease and deletes its keys as one write. The
+// leader picks the keys; replicas delete the ones it sends along.
+func (n *Node) HandleLeaseRevoke(meta iface.RequestMeta, id int64) error {
+	if err := n.leaseSupported(); err != nil {
+		return err
+	}
+	op := operation{kind: LEASE_REVOKE, lease: id, clientID: meta.ClientID, seq: meta.Seq, replicated: meta.Replicated, stamp: meta.Stamp}
+	for _, key := range meta.Revoked {
+		op.revoked = append(op.revoked, storage.BatchOp{Key: key, Delete: true})
+	}
+	return n.handleWrite(meta, op)
+}
+
+// HandlePutWithLease sets key to value and attaches it to lease.
+func (n *Node) HandlePutWithLease(meta iface.RequestMeta, key, value string, lease int64) error {
+	if err := n.leaseSupported(); err != nil {
+		return err
+	}
+	op := operation{kind: PUT, key: key, value: value, lease: lease, clientID: meta.ClientID, seq: meta.Seq, replicated: meta.Replicated, stamp: meta.Stamp}
+	return n.handleWrite(meta, op)
+}
+
+// HandleLeaseKeepAlive restarts the lease's TTL on the leader and returns it.
+func (n *Node) HandleLeaseKeepAlive(id int64) (time.Duration, error) {
+	if err := n.leaseSupported(); err != nil {
+		return 0, err
+	}
+	if !n.IsLeader() {
+		_, leaderAddr, _ := n.leaderInfo()
+		if leaderAddr == "" {
+			return 0, iface.ErrNotLeader
+		}
+		return renewRemote(leaderAddr, id)
+	}
+	l, ok := n.storage.Lease(id)
+	if !ok {
+		return 0, fmt.Errorf("%w: %d", storage.ErrLeaseNotFound, id)
+	}
+	n.leases.touch(id, l.TTL)
+	return l.TTL, nil
+}
+
+// HandleLeaseTimeToLive describes the lease as the leader sees it.
+func (n *Node) HandleLeaseTimeToLive(id int64) (iface.LeaseInfo, error) {
+	if err := n.leaseSupported(); err != nil {
+		return iface.LeaseInfo{}, err
+	}
+	if !n.IsLeader() {
+		_, leaderAddr, _ := n.leaderInfo()
+		if leaderAddr == "" {
+			return iface.LeaseInfo{}, iface.ErrNotLeader
+		}
+		return timeToLiveRemote(leaderAddr, id)
+	}
+	l, ok := n.storage.Lease(id)
+	if !ok {
+		return iface.LeaseInfo{}, fmt.Errorf("%w: %d", storage.ErrLeaseNotFound, id)
+	}
+	return iface.LeaseInfo{ID: id, TTL: l.TTL, Remaining: n.leases.remaining(id), Keys: l.Keys}, nil
+}
+
+// leaseLoop revokes, on the leader, the leases that expired without a
+// keep-alive.
+func (n *Node) leaseLoop() {
+	ticker := time.NewTicker(leaseTick)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		if !n.IsLeader() {
+			continue
+		}
+		for _, id := range n.leases.expired(now) {
+			log.Printf("Node %d revoking expired lease %d", n.id, id)
+			if err := n.handleWrite(iface.RequestMeta{}, operation{kind: LEASE_REVOKE, lease: id}); err != nil {
+				log.Printf("Node %d could not revoke lease %d: %v", n.id, id, err)
+			}
+		}
+	}
+}
+
+func renewRemote(addr string, id int64) (time.Duration, error) {
+	conn, err := dial(addr)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	resp, err := server.NewLeaseClient(conn).Renew(ctx, &server.LeaseKeepAliveRequest{Id: id})
+	if err != nil {
+		return 0, err
+	}
+	if resp.TtlMs == 0 {
+		return 0, fmt.Errorf("%w: %d", storage.ErrLeaseNotFound, id)
+	}
+	return time.Duration(resp.TtlMs) * time.Millisecond, nil
+}
+
+func timeToLiveRemote(addr string, id int64) (iface.LeaseInfo, error) {
+	conn, err := dial(addr)
+	if err != nil {
+		return iface.LeaseInfo{}, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	resp, err := server.NewLeaseClient(conn).TimeToLive(ctx, &server.LeaseTimeToLiveRequest{Id: id})
+	if status.Code(err) == codes.NotFound {
+		return iface.LeaseInfo{}, fmt.Errorf("%w: %d", storage.ErrLeaseNotFound, id)
+	} else if err != nil {
+		return iface.LeaseInfo{}, err
+	}
+	return server.LeaseInfoFromProto(resp), nil
+}
@@ -0,0 +1,166 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"kvstore/iface"
+	"kvstore/storage"
+	"log"
+	"sort"
+	"time"
+)
+
+// lockRecheck bounds how long a waiter sleeps between looks at its queue.
+// Keys owned by another group do not reach this node's watch hub, so the
+// queue cannot rely on watch events alone.
+const lockRecheck = time.Second
+
+// HandleLock blocks until lease holds the lock name, and returns the key it
+// holds it by and the fencing token. Each waiter writes a key under name
+// attached to its lease; the waiter whose key was created first holds the
+// lock, so waiters are served in order. The revision that created the
+// holder's key is the fencing token, which grows with every acquisition.
+// The lock is released by deleting the key, by HandleUnlock or by the lease
+// lapsing with the holder's session. The keys share name as their hash tag,
+// so the lease must belong to the group owning name.
+func (n *Node) HandleLock(ctx context.Context, name string, lease int64) (string, int64, error) {
+	if err := n.leaseSupported(); err != nil {
+		return "", 0, err
+	}
+	if name == "" || lease == 0 {
+		return "", 0, fmt.Errorf("%w: a lock needs a name and a lease", storage.ErrInvalidArgument)
+	}
+	prefix, key := queueKey(name, lease)
+
+	// A session asking again for a lock it holds or waits for keeps its place
+	txn := iface.Txn{
+		Compares: []storage.Compare{{Key: key, Target: storage.CMP_EXISTS, Op: storage.CMP_EQ, Exists: false}},
+		Success:  []storage.BatchOp{{Key: key, Lease: lease}},
+	}
+	mine, err := n.waitTurn(ctx, prefix, key, func() (bool, error) {
+		created, _, err := n.HandleTxn(iface.RequestMeta{}, txn)
+		return created, err
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	return key, mine.Meta.CreateRevision, nil
+}
+
+// HandleUnlock releases the lock held by key, handing it to the next waiter.
+func (n *Node) HandleUnlock(meta iface.RequestMeta, key string) error {
+	return n.HandleDelete(meta, key)
+}
+
+// queueKey returns the prefix of the queue of name and the key lease waits
+// in it by. The prefix is name as a hash tag, which keeps the whole queue in
+// one group.
+func queueKey(name string, lease int64) (string, string) {
+	prefix := queuePrefix(name)
+	return prefix, fmt.Sprintf("%s%x", prefix, lease)
+}
+
+func queuePrefix(name string) string {
+	return "{" + name + "}/"
+}
+
+// waitTurn runs enqueue, which writes key under prefix and reports whether it
+// created it, and blocks until key is the oldest key of the queue. If ctx is
+// done first, a key enqueue created leaves the queue; one that was already
+// there belongs to an earlier call of the same session and stays.
+func (n *Node) waitTurn(ctx context.Context, prefix, key string, enqueue func() (bool, error)) (storage.Entry, error) {
+	w := n.watches.add(prefix, storage.PrefixEnd(prefix))
+	defer func() { n.watches.remove(w) }()
+
+	created, err := enqueue()
+	if err != nil {
+		return storage.Entry{}, err
+	}
+	var mine storage.Entry
+	for {
+		entries, err := n.queue(prefix)
+		if err != nil {
+			return storage.Entry{}, err
+		}
+		queued := false
+		for i, e := range entries {
+			if e.Key != key {
+				continue
+			} else if i == 0 {
+				return e, nil
+			}
+			queued, mine = true, e
+		}
+		if !queued && mine.Key != "" {
+			return storage.Entry{}, fmt.Errorf("key %s was deleted while waiting, its lease expired", key)
+		}
+
+		if w, err = n.waitQueue(ctx, w, prefix); err != nil {
+			if created {
+				n.leaveQueue(prefix, mine)
+			}
+			return storage.Entry{}, err
+		}
+	}
+}
+
+// leaveQueue deletes the key of mine, which its waiter gave up on. The key
+// may have reached the head of the queue since it was last looked at; the
+// lock it holds then is released, handing it to the next waiter. A key
+// written again since, with another create revision, is left alone.
+func (n *Node) leaveQueue(prefix string, mine storage.Entry) {
+	if mine.Key == "" {
+		return // never seen queued: its lease expired
+	}
+	entries, err := n.queue(prefix)
+	if err == nil && len(entries) > 0 && entries[0].Key == mine.Key {
+		log.Printf("Node %d: releasing %s, granted after its waiter gave up", n.id, mine.Key)
+	}
+	txn := iface.Txn{
+		Compares: []storage.Compare{{Key: mine.Key, Target: storage.CMP_CREATE, Op: storage.CMP_EQ, Revision: mine.Meta.CreateRevision}},
+		Success:  []storage.BatchOp{{Key: mine.Key, Delete: true}},
+	}
+	if _, _, err := n.HandleTxn(iface.RequestMeta{}, txn); err != nil {
+		log.Printf("Node %d could not take %s out of its queue: %v", n.id, mine.Key, err)
+	}
+}
+
+// queue returns the keys under prefix, oldest first, as the leader of the
+// group owning them holds them. Who is at the head is decided there: a
+// follower may apply the writes of two waiters in either order.
+func (n *Node) queue(prefix string) ([]storage.Entry, error) {
+	end := storage.PrefixEnd(prefix)
+	addr, remote, err := n.scanOwner(prefix, end)
+	if err != nil {
+		return nil, err
+	}
+	if !remote && !n.IsLeader() {
+		_, addr, _ = n.leaderInfo()
+		remote = true
+	}
+	var entries []storage.Entry
+	if remote {
+		entries, _, _, err = scanRemote(addr, prefix, end, 0, 0)
+	} else {
+		entries, _, _, err = n.storage.ScanAt(prefix, end, 0, 0)
+	}
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Meta.CreateRevision < entries[j].Meta.CreateRevision })
+	return entries, nil
+}
+
+// waitQueue waits for a change under prefix, which w watches, or for
+// lockRecheck to pass. It returns the watcher to keep waiting with.
+func (n *Node) waitQueue(ctx context.Context, w *watcher, prefix string) (*watcher, error) {
+	select {
+	case <-ctx.Done():
+		return w, ctx.Err()
+	case <-w.events:
+	case <-w.lagged:
+		w = n.watches.add(prefix, storage.PrefixEnd(prefix))
+	case <-time.After(lockRecheck):
+	}
+	return w, nil
+}
@@ -0,0 +1,297 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"kvstore/iface"
+	"kvstore/server"
+	"kvstore/storage"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// HandleGetAt returns key with its metadata as it was at revision, 0 for the
+// latest. Revisions are numbered by each replica group on its own.
+func (n *Node) HandleGetAt(key string, revision int64) (string, storage.KeyMeta, error) {
+	if n.Leaderless() {
+		return "", storage.KeyMeta{}, fmt.Errorf("%w: revisions are not kept in leaderless mode", iface.ErrUnsupported)
+	}
+	if addr, remote := n.route(key); remote {
+		return routeGet(addr, key, revision)
+	}
+	if n.chainMode() {
+		return n.chainRead(key, revision)
+	}
+	return n.storage.GetAt(key, revision)
+}
+
+// HandleScan returns up to limit keys in [start, end) as of revision, 0 for
+// the latest, along with the revision read and whether the limit left keys
+// out. The range must be owned by a single replica group. In chain mode the
+// scan is served by the tail.
+func (n *Node) HandleScan(start, end string, limit int, revision int64) ([]storage.Entry, int64, bool, error) {
+	if n.Leaderless() {
+		return nil, 0, false, fmt.Errorf("%w: scans are not supported in leaderless mode", iface.ErrUnsupported)
+	}
+	addr, remote, err := n.scanOwner(start, end)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	if remote {
+		return scanRemote(addr, start, end, limit, revision)
+	}
+	for n.chainMode() {
+		chain := n.chain()
+		tail := chain[len(chain)-1]
+		if tail.id == n.id {
+			break
+		}
+		entries, read, more, err := scanRemote(tail.addr, start, end, limit, revision)
+		if status.Code(err) != codes.Unavailable {
+			return entries, read, more, err
+		}
+		log.Printf("Node %d: chain tail %d at %s is unreachable, reconfiguring around it: %v", n.id, tail.id, tail.addr, err)
+		n.skipChainMember(tail.id)
+	}
+	return n.storage.ScanAt(start, end, limit, revision)
+}
+
+// HandleScanKeys lists up to limit keys in [start, end) like HandleScan,
+// along with the keys holding a collection, which are listed as they are now.
+func (n *Node) HandleScanKeys(start, end string, limit int, revision int64) ([]string, int64, bool, error) {
+	if n.Leaderless() {
+		return nil, 0, false, fmt.Errorf("%w: scans are not supported in leaderless mode", iface.ErrUnsupported)
+	}
+	addr, remote, err := n.scanOwner(start, end)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	if remote {
+		entries, read, more, err := scan(addr, &server.ScanRequest{Start: start, End: end, Limit: int32(limit), Revision: revision, Collections: true})
+		return entryKeys(entries), read, more, err
+	}
+	if n.chainMode() {
+		// Chains keep no collections
+		entries, read, more, err := n.HandleScan(start, end, limit, revision)
+		return entryKeys(entries), read, more, err
+	}
+	return n.storage.ScanKeysAt(start, end, limit, revision)
+}
+
+func entryKeys(entries []storage.Entry) []string {
+	keys := make([]string, len(entries))
+	for i, e := range entries {
+		keys[i] = e.Key
+	}
+	return keys
+}
+
+// HandleCount returns the number of keys holding a value or a collection in
+// the cluster, asking the leader of every other group for its own count, or
+// in this node's group only when groupOnly is set.
+func (n *Node) HandleCount(groupOnly bool) (int64, error) {
+	if n.Leaderless() {
+		return 0, fmt.Errorf("%w: counts are not supported in leaderless mode", iface.ErrUnsupported)
+	}
+	count := int64(n.storage.Size())
+	if groupOnly {
+		return count, nil
+	}
+	for _, g := range n.ClusterMetadata().Shards {
+		if g.ID == n.GetShard() {
+			continue
+		}
+		c, err := countRemote(g.LeaderAddr)
+		if err != nil {
+			return 0, fmt.Errorf("counting the keys of group %d: %w", g.ID, err)
+		}
+		count += c
+	}
+	return count, nil
+}
+
+// HandleDeleteRange deletes the keys in [start, end) holding a value or a
+// collection in the cluster, asking the leader of every other group to delete
+// its own, or in this node's group only when groupOnly is set. Each key is
+// deleted as its own write. It returns the number of keys deleted.
+func (n *Node) HandleDeleteRange(meta iface.RequestMeta, start, end string, groupOnly bool) (int64, error) {
+	if n.Leaderless() {
+		return 0, fmt.Errorf("%w: range deletes are not supported in leaderless mode", iface.ErrUnsupported)
+	}
+	keys := n.storage.KeysInRange(start, end)
+	for _, r := range n.storage.CollectionsInRange(start, end) {
+		keys = append(keys, r.Key)
+	}
+	var deleted int64
+	for _, key := range keys {
+		if err := n.handleWrite(iface.RequestMeta{}, operation{kind: DELETE, key: key, replicated: meta.Replicated}); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	if groupOnly {
+		return deleted, nil
+	}
+	for _, g := range n.ClusterMetadata().Shards {
+		if g.ID == n.GetShard() {
+			continue
+		}
+		d, err := deleteRangeRemote(g.LeaderAddr, start, end, meta.Replicated)
+		deleted += d
+		if err != nil {
+			return deleted, fmt.Errorf("deleting the keys of group %d: %w", g.ID, err)
+		}
+	}
+	return deleted, nil
+}
+
+// scanOwner returns the leader address of the group owning all of [start,
+// end) when that group is not ours. Scans spanning groups are refused: each
+// group numbers its revisions on its own, so they share no snapshot. With
+// hash partitioning only the keys of one hash tag can be scanned.
+func (n *Node) scanOwner(start, end string) (string, bool, error) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	owner := -1
+	switch {
+	case n.metadata.Partitioning == iface.RANGE:
+		for _, e := range n.directory {
+			if (end != "" && e.Start >= end) || (e.End != "" && e.End <= start) {
+				continue
+			}
+			if owner != -1 && owner != e.shard {
+				return "", false, fmt.Errorf("%w: scan of [%q, %q) spans several groups", iface.ErrUnsupported, start, end)
+			}
+			owner = e.shard
+		}
+	case len(n.metadata.Shards) > 1:
+		tag, ok := rangeTag(start, end)
+		if !ok || n.ring == nil {
+			return "", false, fmt.Errorf("%w: scans need range partitioning or keys sharing a hash tag when there are several groups", iface.ErrUnsupported)
+		}
+		owner = n.ring.Owner(tag)
+	case len(n.metadata.Shards) == 1:
+		owner = n.metadata.Shards[0].ID
+	}
+	if owner == -1 || owner == n.shard {
+		return "", false, nil
+	}
+	for _, g := range n.metadata.Shards {
+		if g.ID == owner {
+			return g.LeaderAddr, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// HandleCompact discards the history of the group before revision. The
+// leader compacts and passes the request on to its followers; a follower
+// forwards a client's request to the leader.
+func (n *Node) HandleCompact(meta iface.RequestMeta, revision int64) error {
+	if n.Leaderless() {
+		return fmt.Errorf("%w: revisions are not kept in leaderless mode", iface.ErrUnsupported)
+	}
+	if err := n.checkEpoch(meta); err != nil {
+		return err
+	}
+	leader, leaderAddr, epoch := n.leaderInfo()
+
+	if n.IsLeader() {
+		if err := n.storage.Compact(revision); err != nil {
+			return err
+		}
+		for id, addr := range n.peers() {
+			go func(id int, addr string) {
+				ctx, cancel := peerContext(n.id, n.id, epoch)
+				defer cancel()
+				if err := compactRemote(ctx, addr, revision); err != nil {
+					log.Printf("Compaction of follower %d failed: %v", id, err)
+				}
+			}(id, addr)
+		}
+		return nil
+	} else if meta.RequesterID != leader {
+		if leaderAddr == "" {
+			return iface.ErrNotLeader
+		}
+		ctx, cancel := peerContext(n.id, leader, epoch)
+		defer cancel()
+		return compactRemote(ctx, leaderAddr, revision)
+	}
+	// A follower may not have caught up with revision yet
+	return n.storage.Compact(min(revision, n.storage.Revision()))
+}
+
+func scanRemote(addr, start, end string, limit int, revision int64) ([]storage.Entry, int64, bool, error) {
+	return scan(addr, &server.ScanRequest{Start: start, End: end, Limit: int32(limit), Revision: revision})
+}
+
+func scan(addr string, req *server.ScanRequest) ([]storage.Entry, int64, bool, error) {
+	conn, err := dial(addr)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	resp, err := server.NewKVStoreClient(conn).Scan(ctx, req)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	return server.EntriesFromProto(resp.Kvs), resp.Revision, resp.More, nil
+}
+
+func countRemote(addr string) (int64, error) {
+	conn, err := dial(addr)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	resp, err := server.NewKVStoreClient(conn).Count(ctx, &server.CountRequest{GroupOnly: true})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Count, nil
+}
+
+func deleteRangeRemote(addr, start, end string, replicated bool) (int64, error) {
+	conn, err := dial(addr)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if replicated {
+		ctx = metadata.AppendToOutgoingContext(ctx, "replicated", "true")
+	}
+
+	resp, err := server.NewKVStoreClient(conn).DeleteRange(ctx, &server.DeleteRangeRequest{Start: start, End: end, GroupOnly: true})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Deleted, nil
+}
+
+func compactRemote(ctx context.Context, addr string, revision int64) error {
+	conn, err := dial(addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = server.NewKVStoreClient(conn).Compact(ctx, &server.CompactRequest{Revision: revision})
+	return err
+}
@@ -1,36 +1,165 @@
 package node
 
 import (
+	"errors"
+	"fmt"
+	"kvstore/iface"
 	"kvstore/server"
 	"kvstore/storage"
 	"log"
+	"path/filepath"
+	"sync"
+	"time"
 )
 
 const (
-	LEADER   = 1
-	FOLLOWER = 2
-	DELETE   = "delete"
-	PUT      = "put"
+	LEADER       = 1
+	FOLLOWER     = 2
+	DELETE       = "delete"
+	PUT          = "put"
+	TXN          = "txn"
+	LEASE_GRANT  = "lease-grant"
+	LEASE_REVOKE = "lease-revoke"
+	COLLECTION   = "collection"
+	INSTALL      = "install"
 )
 
+// operation is a client write as it travels between nodes.
+type operation struct {
+	kind       string // PUT, DELETE, TXN, LEASE_GRANT, LEASE_REVOKE, COLLECTION or INSTALL
+	key        string
+	value      string
+	clientID   string // optional idempotency token, see dedupTable
+	seq        uint64
+	replicated bool                // applied by a cross-cluster replication link
+	txn        *iface.Txn          // TXN: the transaction, resolved in place once applied
+	stamp      storage.Stamp       // revision, time and writer, assigned by the leader
+	lease      int64               // PUT: lease to attach the key to; LEASE_*: the lease
+	ttl        time.Duration       // LEASE_GRANT
+	revoked    []storage.BatchOp   // LEASE_REVOKE: deletions of the lease's keys, set once applied
+	coll       *collectionCall     // COLLECTION: the command, and its result once applied
+	records    []storage.KeyRecord // INSTALL: whole state of keys of a range changing groups; COLLECTION: the state left once applied
+	chain      *iface.ChainWrite   // chain replication: the write sent down the chain once applied
+}
+
+// replica returns the operation followers apply for op. A collection write
+// travels as the state it left, which they install under its revision
+// whatever order the writes reach them in.
+func (op operation) replica() operation {
+	if op.kind == COLLECTION {
+		return operation{kind: INSTALL, records: op.records, stamp: op.stamp}
+	}
+	return op
+}
+
+func (op operation) known() bool {
+	switch op.kind {
+	case PUT, DELETE, TXN, LEASE_GRANT, LEASE_REVOKE, COLLECTION, INSTALL:
+		return true
+	}
+	return false
+}
+
+// writes returns the key writes op consists of. A transaction must have been
+// resolved and a revocation applied.
+func (op operation) writes() []storage.BatchOp {
+	switch op.kind {
+	case TXN:
+		return op.txn.Success
+	case LEASE_GRANT, COLLECTION:
+		return nil
+	case LEASE_REVOKE:
+		return op.revoked
+	case INSTALL:
+		var ops []storage.BatchOp
+		for _, r := range op.records {
+			if value, ok := r.Current(); ok {
+				ops = append(ops, storage.BatchOp{Key: r.Key, Value: value, Lease: r.Lease()})
+			} else if r.Collection == nil {
+				ops = append(ops, storage.BatchOp{Key: r.Key, Delete: true})
+			}
+		}
+		return ops
+	}
+	return []storage.BatchOp{{Key: op.key, Value: op.value, Delete: op.kind == DELETE, Lease: op.lease}}
+}
+
 type Node struct {
-	id         int            // unique identifier for the node
-	state      int            // current state of the node: LEADER or FOLLOWER LEADER = 1, FOLLOWER = 2
-	leader     int            // id of the current leader if node is follower
-	leaderAddr string         // address of the leader if node is follower
-	nodes      map[int]string // ids of the followers if node is leader
-	storage    *storage.MemoryStorage
-	grpcServer *server.GRPCServer
+	id            int            // unique identifier for the node
+	state         int            // current state of the node: LEADER or FOLLOWER LEADER = 1, FOLLOWER = 2
+	leader        int            // id of the current leader if node is follower
+	leaderAddr    string         // address of the leader if node is follower
+	epoch         uint64         // highest leader epoch seen, persisted in dataDir
+	dataDir       string         // directory holding the node's persistent state
+	nodes         map[int]string // addresses of the live peers in our replica group, maintained by gossip
+	members       *membership    // SWIM view of the cluster that feeds nodes
+	shard         int            // replica group this node belongs to
+	metadata      iface.ClusterMetadata
+	ring          *HashRing      // built from metadata, nil until metadata is installed
+	directory     rangeDirectory // built from metadata under RANGE partitioning
+	rangeStats    *rangeStats    // writes per range since the last split check
+	rangePolicy   RangePolicy
+	rangeMu       sync.RWMutex  // held shared by writes, exclusively while ranges change hands or shape
+	migration     *migration    // range being moved to another group, if any
+	rebalance     *rebalanceJob // rebalance started through this node
+	storage       *storage.MemoryStorage
+	versioned     *storage.VersionedStorage // values of the leaderless mode, with their vector clocks
+	hints         *hintStore                // leaderless writes held for unreachable replicas
+	replicaRing   *HashRing                 // ring of member ids choosing leaderless replicas
+	ringSignature string                    // member ids replicaRing was built from
+	clockCounter  uint64                    // last vector clock entry this node issued
+	versionedMu   sync.Mutex                // guards replicaRing, ringSignature and clockCounter
+	crdts         *storage.CRDTStorage      // convergent values, merged on every replica
+	crdtSeq       uint64                    // makes OR-Set tags unique
+	crdtMu        sync.Mutex                // serializes this node's CRDT updates
+	chainVersion  uint64                    // highest chain write version seen
+	chainApplied  map[string]uint64         // version of the last chain write applied per key
+	chainSkipped  map[int]time.Time         // unreachable chain members and when they were skipped
+	chainSyncing  map[int]bool              // skipped members being brought up to date
+	chainMu       sync.Mutex                // guards the chain fields
+	sessions      *dedupTable               // last applied write per client, for retries
+	leases        *leaseTimers              // expiry of the leases, kept by the leader
+	oplog         *opLog                    // writes committed as leader, tailed by standby clusters
+	replication   *replicationLink          // link of a standby cluster to its primary
+	watches       *watchHub                 // watches served by this node, fed by its storage
+	grpcServer    *server.GRPCServer
+	mu            sync.RWMutex // guards state, leader, leaderAddr, epoch, nodes and the sharding fields
 }
 
 func NewNode(id int, addr string, state int) *Node {
 	n := &Node{
-		id:      id,
-		state:   state,
-		leader:  -1, // no leader initially
-		nodes:   make(map[int]string),
-		storage: storage.NewMemoryStorage(),
+		id:           id,
+		state:        state,
+		leader:       -1, // no leader initially
+		dataDir:      filepath.Join("data", fmt.Sprintf("node-%d", id)),
+		nodes:        make(map[int]string),
+		storage:      storage.NewMemoryStorage(),
+		versioned:    storage.NewVersionedStorage(),
+		hints:        newHintStore(),
+		crdts:        storage.NewCRDTStorage(),
+		chainApplied: make(map[string]uint64),
+		chainSkipped: make(map[int]time.Time),
+		chainSyncing: make(map[int]bool),
+		sessions:     newDedupTable(sessionTTL),
+		leases:       newLeaseTimers(),
+		oplog:        newOpLog(),
+		replication:  &replicationLink{},
+		watches:      newWatchHub(),
+		members:      newMembership(iface.Member{ID: id, Addr: addr, Status: iface.ALIVE}),
+		rangeStats:   newRangeStats(),
+		rangePolicy:  DefaultRangePolicy,
+		rebalance:    &rebalanceJob{},
 	}
+	n.epoch = loadEpoch(n.dataDir)
+	n.storage.Observe(n.watches.publish)
+	go n.expireSessions()
+	go n.gossipLoop()
+	go n.rangeLoop()
+	go n.handoffLoop()
+	go n.antiEntropyLoop()
+	go n.leaseLoop()
+
+	n.resumeReplication()
 
 	n.grpcServer = server.NewServer(n)
 
@@ -45,72 +174,207 @@ func NewNode(id int, addr string, state int) *Node {
 	return n
 }
 
+// ServeRESP starts a Redis protocol listener on addr next to the node's gRPC
+// server. Publishing addr as the group's RESPAddr in the cluster metadata
+// lets the other groups redirect Redis clients to it.
+func (n *Node) ServeRESP(addr string) {
+	go func() {
+		log.Printf("Node %d starting RESP server on %s", n.id, addr)
+		if err := server.NewRESPServer(n).StartRESPServer(addr); err != nil {
+			log.Printf("RESP server error for node %d: %v", n.id, err)
+		}
+	}()
+}
+
+// ServeMemcache starts a memcached text protocol listener on addr next to the
+// node's gRPC server.
+func (n *Node) ServeMemcache(addr string) {
+	go func() {
+		log.Printf("Node %d starting memcached server on %s", n.id, addr)
+		if err := server.NewMemcacheServer(n).StartMemcacheServer(addr); err != nil {
+			log.Printf("memcached server error for node %d: %v", n.id, err)
+		}
+	}()
+}
+
+// ServeGateway starts the HTTP/JSON gateway on addr next to the node's gRPC
+// server.
+func (n *Node) ServeGateway(addr string) {
+	go func() {
+		log.Printf("Node %d starting HTTP gateway on %s", n.id, addr)
+		if err := server.NewHTTPServer(n).StartHTTPServer(addr); err != nil {
+			log.Printf("HTTP gateway error for node %d: %v", n.id, err)
+		}
+	}()
+}
+
 func (n *Node) GetID() int {
 	return n.id
 }
 
 func (n *Node) GetState() int {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
 	return n.state
 }
 
 func (n *Node) IsLeader() bool {
-	return n.state == LEADER
+	return n.GetState() == LEADER
 }
 
-func (n *Node) SetLeader(leaderID int, addr string) {
+// SetLeader installs leaderID as the leader for the given epoch. Epochs older
+// than the highest one this node has seen are refused.
+func (n *Node) SetLeader(leaderID int, addr string, epoch uint64) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if epoch < n.epoch {
+		return fmt.Errorf("%w: epoch %d is older than %d", iface.ErrStaleEpoch, epoch, n.epoch)
+	}
+	if err := n.setEpochLocked(epoch); err != nil {
+		return err
+	}
+
 	n.leader = leaderID
 	n.leaderAddr = addr
 	if leaderID == n.id {
+		if n.state != LEADER {
+			n.renewLeases()
+		}
 		n.state = LEADER
 	} else {
 		n.state = FOLLOWER
 	}
+	return nil
+}
+
+// peers returns a copy of the addresses of the other live members.
+func (n *Node) peers() map[int]string {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	peers := make(map[int]string, len(n.nodes))
+	for id, addr := range n.nodes {
+		peers[id] = addr
+	}
+	return peers
+}
+
+// leaderInfo returns the current leader id, its address and the epoch.
+func (n *Node) leaderInfo() (int, string, uint64) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.leader, n.leaderAddr, n.epoch
+}
+
+func (n *Node) HandlePut(meta iface.RequestMeta, key, value string) error {
+	return n.handleWrite(meta, operation{kind: PUT, key: key, value: value, clientID: meta.ClientID, seq: meta.Seq, replicated: meta.Replicated, stamp: meta.Stamp})
+}
+
+func (n *Node) HandleGet(key string) (string, error) {
+	value, _, err := n.HandleGetMeta(key)
+	return value, err
+}
+
+// HandleGetMeta returns the value of key with its metadata.
+func (n *Node) HandleGetMeta(key string) (string, storage.KeyMeta, error) {
+	return n.HandleGetAt(key, 0)
 }
 
-func (n *Node) AddFollower(followerID int, followerAddr string) {
-	n.nodes[followerID] = followerAddr
+func (n *Node) HandleDelete(meta iface.RequestMeta, key string) error {
+	return n.handleWrite(meta, operation{kind: DELETE, key: key, clientID: meta.ClientID, seq: meta.Seq, replicated: meta.Replicated, stamp: meta.Stamp})
 }
 
-func (n *Node) HandlePut(requesterID int, key, value string) error {
+// handleWrite routes client writes for other shards to their leader. Within
+// the owning group it applies op on the leader and replicates it, forwards it
+// to the leader when received by a follower from a client, and applies it
+// directly when it is the leader's replication. Client writes are refused on a
+// read-only standby, except those of its replication link.
+func (n *Node) handleWrite(meta iface.RequestMeta, op operation) error {
+	n.rangeMu.RLock()
+	defer n.rangeMu.RUnlock()
+
+	if meta.RequesterID == 0 {
+		if !op.replicated && n.ClusterMetadata().ReadOnly {
+			return iface.ErrReadOnly
+		}
+		// Lease operations have no key and stay in this group
+		if addr, remote := n.route(op.key); remote && op.key != "" {
+			return routeOperation(addr, op)
+		}
+	}
+	if err := n.checkEpoch(meta); err != nil {
+		return err
+	}
+	leader, leaderAddr, epoch := n.leaderInfo()
+
 	if n.IsLeader() {
-		err := n.storage.Put(key, value)
-		if err != nil {
+		// The leader stamps the write; a follower that forwarded it is
+		// recorded as its writer.
+		op.stamp = storage.Stamp{Writer: meta.RequesterID}
+	}
+	if n.IsLeader() && n.chainMode() {
+		return n.headWrite(op)
+	} else if n.IsLeader() {
+		duplicate, err := n.sessions.apply(&op, func() error {
+			return outcome(op, n.oplog.commit(&op, func() error { return n.applyLocal(&op) }))
+		})
+		if duplicate || (err != nil && !errors.Is(err, errCompareFailed)) {
 			return err
 		}
-		broadcastRequest(n.id, n.nodes, PUT, key, value)
-	} else if requesterID != n.leader {
-		forwardRequestToLeader(n.id, n.leaderAddr, PUT, key, value)
+		n.trackWrites(op)
+		broadcastRequest(n.id, epoch, n.peers(), op.replica())
+		return err
+	} else if meta.RequesterID != leader {
+		return forwardRequestToLeader(n.id, leader, epoch, leaderAddr, op)
 	} else {
-		err := n.storage.Put(key, value)
-		if err != nil {
+		_, err := n.sessions.apply(&op, func() error { return outcome(op, n.applyLocal(&op)) })
+		if err != nil && !errors.Is(err, errCompareFailed) {
 			return err
 		}
 	}
 	return nil
 }
 
-func (n *Node) HandleGet(key string) (string, error) {
-	value, err := n.storage.Get(key)
-	if err != nil {
-		return "", err
+// trackWrites feeds the writes of op to the range statistics and to a
+// running migration.
+func (n *Node) trackWrites(op operation) {
+	for _, w := range op.writes() {
+		n.recordWrite(w.Key)
 	}
-	return value, nil
+	n.captureMigration(op)
 }
 
-func (n *Node) HandleDelete(requesterID int, key string) error {
-	if n.IsLeader() {
-		_, err := n.storage.Delete(key)
-		if err != nil {
-			return err
+// applyLocal writes op to this node's storage under op's stamp, completing
+// the stamp with what the storage filled in. A write this node stamps itself
+// is attributed to it unless a writer was given.
+func (n *Node) applyLocal(op *operation) error {
+	if op.stamp.Revision == 0 && op.stamp.Writer == 0 {
+		op.stamp.Writer = n.id
+	}
+	var err error
+	switch op.kind {
+	case PUT, DELETE:
+		op.stamp, err = n.storage.ApplyBatch(op.writes(), op.stamp)
+	case TXN:
+		op.stamp, err = n.applyTxn(op.txn, op.stamp)
+	case LEASE_GRANT:
+		if err = n.storage.GrantLease(op.lease, op.ttl); err == nil {
+			n.leases.touch(op.lease, op.ttl)
 		}
-		broadcastRequest(n.id, n.nodes, DELETE, key, "")
-	} else if requesterID != n.leader {
-		forwardRequestToLeader(n.id, n.leaderAddr, DELETE, key, "")
-	} else {
-		_, err := n.storage.Delete(key)
-		if err != nil {
-			return err
+	case LEASE_REVOKE:
+		if op.revoked, op.stamp, err = n.storage.RevokeLease(op.lease, op.revoked, op.stamp); err == nil {
+			n.leases.forget(op.lease)
 		}
+	case COLLECTION:
+		var rec storage.KeyRecord
+		op.coll.result, rec, op.stamp, err = n.storage.ApplyCollection(op.coll.op, op.stamp)
+		op.coll.applied = err == nil
+		op.records = []storage.KeyRecord{rec}
+	case INSTALL:
+		op.stamp, err = n.storage.ImportKeys(op.records, op.stamp)
+	default:
+		err = fmt.Errorf("unknown operation %q", op.kind)
 	}
-	return nil
+	return err
 }
@@ -0,0 +1,210 @@
+package node
+
+import (
+	"context"
+	"kvstore/iface"
+	"kvstore/storage"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	opLogCapacity = 100000      // entries kept for standbys that fall behind
+	tailBatch     = 256         // entries read from the log at a time
+	tailHeartbeat = time.Second // idle streams send a heartbeat this often
+)
+
+// opLog is the log of the writes a node committed as leader or chain head,
+// in the order they were applied. It lives in memory under a random id, so a
+// standby following an older log or an index no longer kept starts over from
+// a snapshot.
+type opLog struct {
+	mu       sync.Mutex
+	id       uint64
+	entries  []iface.LogEntry
+	last     uint64        // index of the last entry, 0 while empty
+	appended chan struct{} // closed by the next append
+}
+
+func newOpLog() *opLog {
+	return &opLog{id: rand.Uint64() | 1, appended: make(chan struct{})}
+}
+
+// commit applies op and appends it to the log under the log's lock, so the
+// log order is the order the writes were applied in. Operations other than
+// puts and deletes are logged as the writes they made, along with the
+// collections they left. Leases stay in their cluster: writes are
+// logged without them, and a lease that is revoked or expires reaches a
+// standby as the deletes of its keys.
+func (l *opLog) commit(op *operation, apply func() error) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := apply(); err != nil {
+		return err
+	}
+	l.last++
+	e := iface.LogEntry{LogID: l.id, Index: l.last, Op: op.kind, Key: op.key, Value: op.value, Committed: time.Now()}
+	switch op.kind {
+	case PUT, DELETE:
+	case COLLECTION:
+		e.Op, e.Key, e.Records = INSTALL, "", op.records
+	default:
+		e.Op, e.Key = TXN, ""
+		for _, w := range op.writes() {
+			w.Lease = 0
+			e.Ops = append(e.Ops, w)
+		}
+		for _, r := range op.records {
+			if r.Collection != nil {
+				e.Op = INSTALL
+				e.Records = append(e.Records, r)
+			}
+		}
+	}
+	l.entries = append(l.entries, e)
+	if len(l.entries) > opLogCapacity {
+		l.entries = append([]iface.LogEntry(nil), l.entries[len(l.entries)-opLogCapacity*9/10:]...)
+	}
+	close(l.appended)
+	l.appended = make(chan struct{})
+	return nil
+}
+
+// read returns up to max entries starting at index from, the last index and a
+// channel closed by the next append. ok is false when from is not retained.
+func (l *opLog) read(from uint64, max int) (entries []iface.LogEntry, last uint64, ok bool, appended <-chan struct{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	first := l.last + 1 - uint64(len(l.entries))
+	if from < first || from > l.last+1 {
+		return nil, l.last, false, l.appended
+	}
+	start := int(from - first)
+	end := min(len(l.entries), start+max)
+	return append([]iface.LogEntry(nil), l.entries[start:end]...), l.last, true, l.appended
+}
+
+func (l *opLog) lastIndex() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.last
+}
+
+// HandleTail streams the log from index from to a standby, keeping only keys
+// under prefix. A standby on another log or too far behind first gets a reset
+// followed by a snapshot of the keys; the heartbeat after the snapshot tells
+// it the index the snapshot stands for. Writes committed while the snapshot is
+// read may show up in both, which is harmless as the log replays in order.
+func (n *Node) HandleTail(ctx context.Context, logID, from uint64, prefix string, send func(iface.LogEntry) error) error {
+	snapshot := logID != n.oplog.id
+	idle := time.NewTimer(tailHeartbeat)
+	defer idle.Stop()
+
+	for {
+		if snapshot {
+			index, err := n.sendSnapshot(prefix, send)
+			if err != nil {
+				return err
+			}
+			from, snapshot = index+1, false
+		}
+
+		entries, last, ok, appended := n.oplog.read(from, tailBatch)
+		if !ok {
+			snapshot = true
+			continue
+		}
+		skipped := false
+		for _, e := range entries {
+			from = e.Index + 1
+			keep := strings.HasPrefix(e.Key, prefix)
+			if e.Op == TXN || e.Op == INSTALL {
+				e.Ops, e.Records = filterPrefix(e.Ops, prefix), filterRecords(e.Records, prefix)
+				keep = len(e.Ops) > 0 || len(e.Records) > 0
+			}
+			if !keep {
+				skipped = true
+				continue
+			}
+			e.HeadIndex = last
+			if err := send(e); err != nil {
+				return err
+			}
+		}
+		if skipped {
+			if err := send(n.heartbeat(from-1, last)); err != nil {
+				return err
+			}
+		}
+		if len(entries) > 0 {
+			continue
+		}
+
+		idle.Reset(tailHeartbeat)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-appended:
+		case <-idle.C:
+			if err := send(n.heartbeat(from-1, last)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// sendSnapshot sends a reset and the keys and collections under prefix, and
+// returns the log index the snapshot continues from.
+func (n *Node) sendSnapshot(prefix string, send func(iface.LogEntry) error) (uint64, error) {
+	index := n.oplog.lastIndex()
+	if err := send(iface.LogEntry{LogID: n.oplog.id, Index: index, Op: iface.LOG_RESET, HeadIndex: index}); err != nil {
+		return 0, err
+	}
+	for _, key := range n.storage.KeysInRange(prefix, storage.PrefixEnd(prefix)) {
+		value, err := n.storage.Get(key)
+		if err != nil {
+			continue
+		}
+		e := iface.LogEntry{LogID: n.oplog.id, Index: index, Op: PUT, Key: key, Value: value, HeadIndex: index, Snapshot: true}
+		if err := send(e); err != nil {
+			return 0, err
+		}
+	}
+	for _, r := range n.storage.CollectionsInRange(prefix, storage.PrefixEnd(prefix)) {
+		e := iface.LogEntry{LogID: n.oplog.id, Index: index, Op: INSTALL, Records: []storage.KeyRecord{r}, HeadIndex: index, Snapshot: true}
+		if err := send(e); err != nil {
+			return 0, err
+		}
+	}
+	return index, send(n.heartbeat(index, index))
+}
+
+func (n *Node) heartbeat(index, last uint64) iface.LogEntry {
+	return iface.LogEntry{LogID: n.oplog.id, Index: index, Op: iface.LOG_HEARTBEAT, HeadIndex: last}
+}
+
+// filterRecords returns the records of keys under prefix.
+func filterRecords(records []storage.KeyRecord, prefix string) []storage.KeyRecord {
+	var out []storage.KeyRecord
+	for _, r := range records {
+		if strings.HasPrefix(r.Key, prefix) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// filterPrefix returns the writes of ops to keys under prefix.
+func filterPrefix(ops []storage.BatchOp, prefix string) []storage.BatchOp {
+	var out []storage.BatchOp
+	for _, op := range ops {
+		if strings.HasPrefix(op.Key, prefix) {
+			out = append(out, op)
+		}
+	}
+	return out
+}
@@ -0,0 +1,202 @@
+package node
+
+import (
+	"kvstore/iface"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+// RangePolicy decides when the leader of a group splits or merges its ranges
+// under RANGE partitioning.
+type RangePolicy struct {
+	MaxKeys        int           // split a range holding more keys
+	MaxQPS         float64       // split a range receiving more writes per second
+	MergeBelowKeys int           // merge adjacent ranges holding fewer keys together
+	CheckInterval  time.Duration // how often ranges are evaluated
+}
+
+const rangeLoopTick = 50 * time.Millisecond
+
+var DefaultRangePolicy = RangePolicy{
+	MaxKeys:        100000,
+	MaxQPS:         1000,
+	MergeBelowKeys: 10000,
+	CheckInterval:  10 * time.Second,
+}
+
+type rangeEntry struct {
+	iface.KeyRange
+	shard int
+}
+
+// rangeDirectory lists every range of the cluster sorted by start key.
+type rangeDirectory []rangeEntry
+
+func newRangeDirectory(groups []iface.ShardGroup) rangeDirectory {
+	var d rangeDirectory
+	for _, g := range groups {
+		for _, r := range g.Ranges {
+			d = append(d, rangeEntry{KeyRange: r, shard: g.ID})
+		}
+	}
+	sort.Slice(d, func(i, j int) bool { return d[i].Start < d[j].Start })
+	return d
+}
+
+func (d rangeDirectory) lookup(key string) (rangeEntry, bool) {
+	i := sort.Search(len(d), func(i int) bool { return d[i].Start > key })
+	if i == 0 || !d[i-1].Contains(key) {
+		return rangeEntry{}, false
+	}
+	return d[i-1], true
+}
+
+// rangeStats counts writes per range, keyed by range start, between checks.
+type rangeStats struct {
+	mu     sync.Mutex
+	writes map[string]int
+	since  time.Time
+}
+
+func newRangeStats() *rangeStats {
+	return &rangeStats{writes: make(map[string]int), since: time.Now()}
+}
+
+func (s *rangeStats) record(start string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.writes[start]++
+}
+
+// reset returns the counts gathered so far and the seconds they cover.
+func (s *rangeStats) reset() (map[string]int, float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writes, elapsed := s.writes, time.Since(s.since).Seconds()
+	s.writes, s.since = make(map[string]int), time.Now()
+	return writes, elapsed
+}
+
+func (n *Node) SetRangePolicy(p RangePolicy) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.rangePolicy = p
+}
+
+func (n *Node) recordWrite(key string) {
+	n.mu.RLock()
+	entry, ok := n.directory.lookup(key)
+	n.mu.RUnlock()
+	if ok {
+		n.rangeStats.record(entry.Start)
+	}
+}
+
+// rangeLoop evaluates the ranges every CheckInterval. It wakes up on a short
+// tick so a new policy takes effect right away.
+func (n *Node) rangeLoop() {
+	ticker := time.NewTicker(rangeLoopTick)
+	defer ticker.Stop()
+
+	last := time.Now()
+	for now := range ticker.C {
+		n.mu.RLock()
+		interval := n.rangePolicy.CheckInterval
+		partitioning := n.metadata.Partitioning
+		n.mu.RUnlock()
+
+		if interval <= 0 {
+			interval = DefaultRangePolicy.CheckInterval
+		}
+		if now.Sub(last) < interval {
+			continue
+		}
+		last = now
+		if partitioning == iface.RANGE && n.IsLeader() {
+			n.checkRanges()
+		}
+	}
+}
+
+// checkRanges splits the group's hot or large ranges at their median key and
+// otherwise merges small adjacent ones, then publishes the new directory. It
+// holds rangeMu exclusively so no write is applied against a half-updated
+// directory.
+func (n *Node) checkRanges() {
+	n.rangeMu.Lock()
+	defer n.rangeMu.Unlock()
+
+	n.mu.RLock()
+	md, shard, policy, moving := n.metadata, n.shard, n.rangePolicy, n.migration != nil
+	n.mu.RUnlock()
+	if moving {
+		return // ranges keep their shape while one is handed over
+	}
+
+	idx := -1
+	for i, g := range md.Shards {
+		if g.ID == shard {
+			idx = i
+		}
+	}
+	writes, elapsed := n.rangeStats.reset()
+	if idx == -1 || elapsed <= 0 {
+		return
+	}
+	group := md.Shards[idx]
+
+	current := append([]iface.KeyRange(nil), group.Ranges...)
+	sort.Slice(current, func(i, j int) bool { return current[i].Start < current[j].Start })
+
+	keys := make(map[string][]string, len(current))
+	for _, r := range current {
+		keys[r.Start] = n.storage.KeysInRange(r.Start, r.End)
+	}
+	qps := func(r iface.KeyRange) float64 { return float64(writes[r.Start]) / elapsed }
+
+	var next []iface.KeyRange
+	split := false
+	for _, r := range current {
+		rangeKeys := keys[r.Start]
+		if (len(rangeKeys) > policy.MaxKeys || qps(r) > policy.MaxQPS) && len(rangeKeys) >= 2 {
+			mid := rangeKeys[len(rangeKeys)/2]
+			if mid > r.Start {
+				log.Printf("Node %d splitting range [%q, %q) at %q", n.id, r.Start, r.End, mid)
+				next = append(next, iface.KeyRange{Start: r.Start, End: mid}, iface.KeyRange{Start: mid, End: r.End})
+				split = true
+				continue
+			}
+		}
+		next = append(next, r)
+	}
+
+	if !split {
+		next = next[:0]
+		for _, r := range current {
+			if len(next) > 0 {
+				last := &next[len(next)-1]
+				size := len(n.storage.KeysInRange(last.Start, last.End)) + len(keys[r.Start])
+				cool := qps(*last) < policy.MaxQPS/2 && qps(r) < policy.MaxQPS/2
+				if last.End == r.Start && size < policy.MergeBelowKeys && cool {
+					log.Printf("Node %d merging ranges [%q, %q) and [%q, %q)", n.id, last.Start, last.End, r.Start, r.End)
+					last.End = r.End
+					continue
+				}
+			}
+			next = append(next, r)
+		}
+		if len(next) == len(current) {
+			return
+		}
+	}
+
+	group.Ranges = next
+	group.Version++
+	updated := md
+	updated.Shards = append([]iface.ShardGroup(nil), md.Shards...)
+	updated.Shards[idx] = group
+	n.MergeClusterMetadata(updated)
+}
@@ -0,0 +1,558 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"kvstore/iface"
+	"kvstore/server"
+	"kvstore/storage"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	transferChunkKeys = 100 // keys per InstallRange chunk
+	catchUpRounds     = 5   // catch-up passes before writes are frozen
+	catchUpThreshold  = 10  // writes left to ship below which the switch happens
+)
+
+// migration captures the keys written in a range while it is streamed to
+// another group, so their state can be sent again after the snapshot.
+type migration struct {
+	r    iface.KeyRange
+	mu   sync.Mutex
+	keys map[string]bool
+}
+
+func (m *migration) capture(op operation) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	keys := []string{}
+	for _, w := range op.writes() {
+		keys = append(keys, w.Key)
+	}
+	if op.kind == COLLECTION {
+		keys = append(keys, op.coll.op.Key)
+	}
+	for _, key := range keys {
+		if m.r.Contains(key) {
+			m.keys[key] = true
+		}
+	}
+}
+
+func (n *Node) captureMigration(op operation) {
+	n.mu.RLock()
+	mig := n.migration
+	n.mu.RUnlock()
+	if mig != nil {
+		mig.capture(op)
+	}
+}
+
+// drain returns the keys captured since the last drain, in key order.
+func (m *migration) drain() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	keys := make([]string, 0, len(m.keys))
+	for key := range m.keys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	m.keys = make(map[string]bool)
+	return keys
+}
+
+// rebalanceJob is the plan this node is executing and its progress.
+type rebalanceJob struct {
+	mu      sync.Mutex
+	moves   []iface.RangeMove
+	running bool
+}
+
+func (j *rebalanceJob) update(i int, fn func(m *iface.RangeMove)) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	fn(&j.moves[i])
+}
+
+// throttle caps a transfer at rate bytes per second, 0 meaning unlimited.
+type throttle struct {
+	rate  int64
+	start time.Time
+	sent  int64
+}
+
+func (t *throttle) wait(bytes int) {
+	t.sent += int64(bytes)
+	if t.rate <= 0 {
+		return
+	}
+	expected := time.Duration(float64(t.sent) / float64(t.rate) * float64(time.Second))
+	if ahead := expected - time.Since(t.start); ahead > 0 {
+		time.Sleep(ahead)
+	}
+}
+
+// PlanRangeMoves computes the moves that spread the ranges of the live groups
+// over them in proportion to their live replicas, given by live, so that each
+// replica serves about the same share of the ranges. Ranges are the unit of
+// load since they are split when they grow large or hot.
+func PlanRangeMoves(md iface.ClusterMetadata, live map[int]int) []iface.RangeMove {
+	owned := make(map[int][]iface.KeyRange)
+	var groups []int
+	total := 0
+	for _, g := range md.Shards {
+		if live[g.ID] <= 0 {
+			continue
+		}
+		groups = append(groups, g.ID)
+		ranges := append([]iface.KeyRange(nil), g.Ranges...)
+		sort.Slice(ranges, func(i, j int) bool { return ranges[i].Start < ranges[j].Start })
+		owned[g.ID] = ranges
+		total += len(ranges)
+	}
+	if len(groups) < 2 {
+		return nil
+	}
+	sort.Ints(groups)
+	target := rangeTargets(groups, live, total)
+
+	var moves []iface.RangeMove
+	for {
+		donor, recipient := groups[0], groups[0]
+		for _, id := range groups {
+			if len(owned[id])-target[id] > len(owned[donor])-target[donor] {
+				donor = id
+			}
+			if len(owned[id])-target[id] < len(owned[recipient])-target[recipient] {
+				recipient = id
+			}
+		}
+		if len(owned[donor]) <= target[donor] || len(owned[recipient]) >= target[recipient] {
+			return moves
+		}
+		last := len(owned[donor]) - 1
+		r := owned[donor][last]
+		owned[donor] = owned[donor][:last]
+		owned[recipient] = append(owned[recipient], r)
+		moves = append(moves, iface.RangeMove{Range: r, From: donor, To: recipient, State: iface.MOVE_PENDING})
+	}
+}
+
+// rangeTargets splits total ranges between groups in proportion to their
+// live replicas, handing the remainders out by largest fraction.
+func rangeTargets(groups []int, live map[int]int, total int) map[int]int {
+	replicas := 0
+	for _, id := range groups {
+		replicas += live[id]
+	}
+	target := make(map[int]int, len(groups))
+	byRemainder := append([]int(nil), groups...)
+	left := total
+	for _, id := range groups {
+		target[id] = total * live[id] / replicas
+		left -= target[id]
+	}
+	sort.SliceStable(byRemainder, func(i, j int) bool {
+		return total*live[byRemainder[i]]%replicas > total*live[byRemainder[j]]%replicas
+	})
+	for _, id := range byRemainder[:left] {
+		target[id]++
+	}
+	return target
+}
+
+// liveGroups returns the number of live replicas of the groups whose leader
+// is not known to be dead.
+func (n *Node) liveGroups(md iface.ClusterMetadata) map[int]int {
+	alive := make(map[int]bool)
+	replicas := make(map[int]int)
+	for _, m := range n.members.list() {
+		if m.Status != iface.DEAD {
+			alive[m.ID] = true
+			replicas[m.Shard]++
+		}
+	}
+	live := make(map[int]int)
+	for _, g := range md.Shards {
+		if alive[g.LeaderID] {
+			live[g.ID] = replicas[g.ID]
+		}
+	}
+	return live
+}
+
+// moveSupported refuses range moves where keys are not placed by range or
+// not written through the leader's operations. A hash cluster spreads load
+// over a new node by the node joining a group, whose leader seeds it.
+func (n *Node) moveSupported() error {
+	if n.ClusterMetadata().Partitioning != iface.RANGE {
+		return fmt.Errorf("%w: rebalancing moves ranges and needs range partitioning", iface.ErrUnsupported)
+	}
+	if n.Leaderless() || n.chainMode() {
+		return fmt.Errorf("%w: range moves need single-leader replication", iface.ErrUnsupported)
+	}
+	return nil
+}
+
+func groupByID(md iface.ClusterMetadata, id int) (iface.ShardGroup, bool) {
+	for _, g := range md.Shards {
+		if g.ID == id {
+			return g, true
+		}
+	}
+	return iface.ShardGroup{}, false
+}
+
+func (n *Node) PlanRebalance() ([]iface.RangeMove, error) {
+	if err := n.moveSupported(); err != nil {
+		return nil, err
+	}
+	md := n.ClusterMetadata()
+	return PlanRangeMoves(md, n.liveGroups(md)), nil
+}
+
+// StartRebalance computes a plan and executes it in the background, one move
+// at a time. Progress is reported by RebalanceStatus.
+func (n *Node) StartRebalance(bytesPerSecond int64) ([]iface.RangeMove, error) {
+	moves, err := n.PlanRebalance()
+	if err != nil {
+		return nil, err
+	}
+
+	n.rebalance.mu.Lock()
+	defer n.rebalance.mu.Unlock()
+	if n.rebalance.running {
+		return nil, fmt.Errorf("%w: a rebalance is running", iface.ErrBusy)
+	}
+	n.rebalance.moves = moves
+	n.rebalance.running = len(moves) > 0
+	if n.rebalance.running {
+		go n.runRebalance(bytesPerSecond)
+	}
+	return append([]iface.RangeMove(nil), moves...), nil
+}
+
+func (n *Node) RebalanceStatus() ([]iface.RangeMove, bool) {
+	n.rebalance.mu.Lock()
+	defer n.rebalance.mu.Unlock()
+	return append([]iface.RangeMove(nil), n.rebalance.moves...), n.rebalance.running
+}
+
+func (n *Node) runRebalance(bytesPerSecond int64) {
+	defer func() {
+		n.rebalance.mu.Lock()
+		n.rebalance.running = false
+		n.rebalance.mu.Unlock()
+	}()
+
+	moves, _ := n.RebalanceStatus()
+	for i, move := range moves {
+		n.rebalance.update(i, func(m *iface.RangeMove) { m.State = iface.MOVE_RUNNING })
+		keys, bytes, err := n.executeMove(move, bytesPerSecond)
+		n.rebalance.update(i, func(m *iface.RangeMove) {
+			m.Keys, m.Bytes = keys, bytes
+			if err != nil {
+				m.State, m.Error = iface.MOVE_FAILED, err.Error()
+				log.Printf("Node %d: moving [%q, %q) from group %d to %d failed: %v", n.id, move.Range.Start, move.Range.End, move.From, move.To, err)
+			} else {
+				m.State = iface.MOVE_DONE
+			}
+		})
+	}
+}
+
+// executeMove asks the source group leader to hand the range over.
+func (n *Node) executeMove(move iface.RangeMove, bytesPerSecond int64) (int64, int64, error) {
+	md := n.ClusterMetadata()
+	from, ok := groupByID(md, move.From)
+	to, ok2 := groupByID(md, move.To)
+	if !ok || !ok2 {
+		return 0, 0, fmt.Errorf("unknown group in move %d -> %d", move.From, move.To)
+	}
+	if from.LeaderID == n.id {
+		return n.HandleMoveRange(move.Range, to.ID, to.LeaderAddr, bytesPerSecond)
+	}
+
+	conn, err := dial(from.LeaderAddr)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer conn.Close()
+
+	resp, err := server.NewAdminClient(conn).MoveRange(context.Background(), &server.MoveRangeRequest{
+		Range:          server.RangeToProto(move.Range),
+		ToShard:        int32(to.ID),
+		ToAddr:         to.LeaderAddr,
+		BytesPerSecond: bytesPerSecond,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return resp.Keys, resp.Bytes, nil
+}
+
+// HandleMoveRange streams a snapshot of r to the target leader, sends again
+// the keys written meanwhile, then freezes writes to ship the tail and switch
+// ownership in one step. Keys travel with their whole state: history,
+// metadata, collections and leases. The old copy is removed afterwards
+// through this group's own writes, so it is logged and replicated.
+func (n *Node) HandleMoveRange(r iface.KeyRange, toShard int, toAddr string, bytesPerSecond int64) (int64, int64, error) {
+	if err := n.moveSupported(); err != nil {
+		return 0, 0, err
+	}
+	if !n.IsLeader() {
+		return 0, 0, iface.ErrNotLeader
+	}
+
+	n.mu.Lock()
+	group, ok := groupByID(n.metadata, n.shard)
+	if !ok || !containsRange(group.Ranges, r) {
+		n.mu.Unlock()
+		return 0, 0, fmt.Errorf("range [%q, %q) is not owned by group %d", r.Start, r.End, n.shard)
+	}
+	if n.migration != nil {
+		n.mu.Unlock()
+		return 0, 0, fmt.Errorf("%w: a range is already moving", iface.ErrBusy)
+	}
+	mig := &migration{r: r, keys: make(map[string]bool)}
+	n.migration = mig
+	n.mu.Unlock()
+
+	defer func() {
+		n.mu.Lock()
+		n.migration = nil
+		n.mu.Unlock()
+	}()
+
+	conn, err := dial(toAddr)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := server.NewAdminClient(conn).InstallRange(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	limit := &throttle{rate: bytesPerSecond, start: time.Now()}
+	var keys int64
+	send := func(records []storage.KeyRecord, commit bool) error {
+		for i := 0; i == 0 || i < len(records); i += transferChunkKeys {
+			chunk := iface.RangeChunk{Records: records[i:min(i+transferChunkKeys, len(records))], Leases: make(map[int64]time.Duration)}
+			size := 0
+			for _, rec := range chunk.Records {
+				size += recordSize(rec)
+				if lease := rec.Lease(); lease != 0 {
+					chunk.Leases[lease] = n.leases.remaining(lease)
+				}
+			}
+			limit.wait(size)
+			keys += int64(len(chunk.Records))
+			last := commit && i+transferChunkKeys >= len(records)
+			if err := stream.Send(server.RangeChunkToProto(r, chunk, last)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// Snapshot
+	if err := send(n.storage.ExportRange(r.Start, r.End), false); err != nil {
+		return keys, limit.sent, err
+	}
+
+	// Catch up while writes keep flowing
+	for round := 0; round < catchUpRounds; round++ {
+		written := mig.drain()
+		if err := send(n.storage.ExportKeys(written), false); err != nil {
+			return keys, limit.sent, err
+		}
+		if len(written) < catchUpThreshold {
+			break
+		}
+	}
+
+	// Freeze writes, ship the tail and switch ownership
+	n.rangeMu.Lock()
+	if err := send(n.storage.ExportKeys(mig.drain()), true); err != nil {
+		n.rangeMu.Unlock()
+		return keys, limit.sent, err
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		n.rangeMu.Unlock()
+		return keys, limit.sent, err
+	}
+	md := n.ClusterMetadata()
+	updated := md
+	updated.Shards = append([]iface.ShardGroup(nil), md.Shards...)
+	for i, g := range updated.Shards {
+		switch g.ID {
+		case n.GetShard():
+			g.Ranges = removeRange(g.Ranges, r)
+			g.Version++
+			updated.Shards[i] = g
+		case toShard:
+			if target := server.GroupFromProto(resp.Group); target.Version > g.Version {
+				updated.Shards[i] = target
+			}
+		}
+	}
+	n.MergeClusterMetadata(updated)
+	n.rangeMu.Unlock()
+	log.Printf("Node %d moved range [%q, %q) to group %d: %d keys, %d bytes", n.id, r.Start, r.End, toShard, keys, limit.sent)
+
+	// Drop the old copy
+	old := n.storage.ExportRange(r.Start, r.End)
+	for i := 0; i < len(old); i += transferChunkKeys {
+		var removals []storage.KeyRecord
+		for _, rec := range old[i:min(i+transferChunkKeys, len(old))] {
+			removals = append(removals, storage.KeyRecord{Key: rec.Key})
+		}
+		if err := n.handleWrite(iface.RequestMeta{}, operation{kind: INSTALL, records: removals}); err != nil {
+			log.Printf("Node %d could not drop its copy of [%q, %q): %v", n.id, r.Start, r.End, err)
+			break
+		}
+	}
+	return keys, limit.sent, nil
+}
+
+// seedReplica brings a member that joined this leader's group up to date, so
+// that the new replica takes its share of the reads and can take over as
+// leader. It is sent the group's leases, then a snapshot of the keys stamped
+// with the revision reached before it was taken. The member already receives
+// the writes replicated since it joined, and the install keeps whichever of
+// them is newer than the snapshot.
+func (n *Node) seedReplica(id int, addr string) {
+	_, _, epoch := n.leaderInfo()
+	send := func(op operation) error {
+		ctx, cancel := peerContext(n.id, n.id, epoch)
+		defer cancel()
+		return sendOperation(ctx, addr, op)
+	}
+
+	revision := n.storage.Revision()
+	for lease := range n.storage.Leases() {
+		if err := send(operation{kind: LEASE_GRANT, lease: lease, ttl: max(n.leases.remaining(lease), time.Millisecond)}); err != nil {
+			log.Printf("Node %d could not seed member %d at %s: %v", n.id, id, addr, err)
+			return
+		}
+	}
+	records := n.storage.ExportRange("", "")
+	for i := 0; i < len(records); i += transferChunkKeys {
+		op := operation{kind: INSTALL, records: records[i:min(i+transferChunkKeys, len(records))], stamp: storage.Stamp{Revision: revision}}
+		if err := send(op); err != nil {
+			log.Printf("Node %d could not seed member %d at %s: %v", n.id, id, addr, err)
+			return
+		}
+	}
+	log.Printf("Node %d seeded member %d at %s with %d keys as of revision %d", n.id, id, addr, len(records), revision)
+}
+
+// recordSize returns the key and value bytes of rec.
+func recordSize(rec storage.KeyRecord) int {
+	size := len(rec.Key)
+	for _, v := range rec.History {
+		size += len(v.Value)
+	}
+	if c := rec.Collection; c != nil {
+		for _, e := range c.List {
+			size += len(e)
+		}
+		for m := range c.Set {
+			size += len(m)
+		}
+		for f, v := range c.Hash {
+			size += len(f) + len(v)
+		}
+		for m := range c.ZSet {
+			size += len(m) + 8
+		}
+	}
+	return size
+}
+
+// HandleInstallRange writes a chunk of a range moved to this group. The
+// leader first grants the leases of its keys, with the time they have left,
+// then writes the chunk like any write, so followers install it too.
+// Keep-alives of a moved key's lease must then be sent to this group. The
+// leader's replication of collection writes, and a standby's replication
+// link, also install records this way, outside of any range move.
+func (n *Node) HandleInstallRange(meta iface.RequestMeta, r iface.KeyRange, chunk iface.RangeChunk) error {
+	if meta.RequesterID == 0 && !meta.Replicated {
+		if err := n.moveSupported(); err != nil {
+			return err
+		}
+		if !n.IsLeader() {
+			return iface.ErrNotLeader
+		}
+	}
+	if meta.RequesterID == 0 {
+		for id, ttl := range chunk.Leases {
+			if _, ok := n.storage.Lease(id); ok {
+				continue
+			}
+			if err := n.handleWrite(iface.RequestMeta{}, operation{kind: LEASE_GRANT, lease: id, ttl: max(ttl, time.Millisecond)}); err != nil {
+				return err
+			}
+		}
+	}
+	if len(chunk.Records) == 0 {
+		return nil
+	}
+	// A moving range is not owned here yet, so its chunks are not routed
+	op := operation{kind: INSTALL, records: chunk.Records, stamp: meta.Stamp, replicated: meta.Replicated}
+	if meta.Replicated {
+		op.key = chunk.Records[0].Key
+	}
+	return n.handleWrite(meta, op)
+}
+
+// HandleCommitRange adds r to this group's ranges and publishes the change.
+func (n *Node) HandleCommitRange(r iface.KeyRange) (iface.ShardGroup, error) {
+	n.rangeMu.Lock()
+	defer n.rangeMu.Unlock()
+
+	md := n.ClusterMetadata()
+	updated := md
+	updated.Shards = append([]iface.ShardGroup(nil), md.Shards...)
+	for i, g := range updated.Shards {
+		if g.ID != n.GetShard() {
+			continue
+		}
+		if !containsRange(g.Ranges, r) {
+			g.Ranges = append(append([]iface.KeyRange(nil), g.Ranges...), r)
+			g.Version++
+			updated.Shards[i] = g
+			n.MergeClusterMetadata(updated)
+		}
+		return g, nil
+	}
+	return iface.ShardGroup{}, fmt.Errorf("group %d is missing from the cluster metadata", n.GetShard())
+}
+
+func containsRange(ranges []iface.KeyRange, r iface.KeyRange) bool {
+	for _, cur := range ranges {
+		if cur == r {
+			return true
+		}
+	}
+	return false
+}
+
+func removeRange(ranges []iface.KeyRange, r iface.KeyRange) []iface.KeyRange {
+	var out []iface.KeyRange
+	for _, cur := range ranges {
+		if cur != r {
+			out = append(out, cur)
+		}
+	}
+	return out
+}
@@ -0,0 +1,294 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"kvstore/iface"
+	"kvstore/server"
+	"kvstore/storage"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	replicationFile      = "replication"
+	replicationRetry     = time.Second // pause before reconnecting to the primary
+	replicationSaveEvery = 100         // applied entries between position saves
+)
+
+// replicationPosition is what a standby persists about its link: where it
+// tails from and how far it got. Entries applied after the last save are
+// applied again after a restart, which is harmless as they replay in order
+// and each carries the state it leaves rather than a command: resolved
+// writes, and whole collections.
+type replicationPosition struct {
+	Source  string
+	Prefix  string
+	LogID   uint64
+	Applied uint64
+}
+
+// replicationLink is the standby side of cross-cluster replication.
+type replicationLink struct {
+	mu          sync.Mutex
+	pos         replicationPosition
+	running     bool
+	sourceIndex uint64    // last index of the primary's log, from the latest entry
+	committed   time.Time // commit time of the last write applied
+	unsaved     int
+	err         string
+	cancel      context.CancelFunc
+}
+
+func loadReplication(dir string) (replicationPosition, bool) {
+	var pos replicationPosition
+	data, err := os.ReadFile(filepath.Join(dir, replicationFile))
+	if err != nil {
+		return pos, false
+	}
+	if err := json.Unmarshal(data, &pos); err != nil {
+		log.Printf("Ignoring corrupt replication file in %s: %v", dir, err)
+		return pos, false
+	}
+	return pos, true
+}
+
+// saveReplication writes the position through a temp file like saveEpoch.
+func saveReplication(dir string, pos replicationPosition) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(pos)
+	if err != nil {
+		return err
+	}
+	tmp := filepath.Join(dir, replicationFile+".tmp")
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(dir, replicationFile))
+}
+
+// StartReplication makes this node's cluster a read-only standby of the
+// cluster source belongs to and starts tailing source's log, which should be
+// the log of the primary group's leader.
+func (n *Node) StartReplication(source, prefix string) (iface.ReplicationStatus, error) {
+	r := n.replication
+	r.mu.Lock()
+	if r.running {
+		r.mu.Unlock()
+		return n.ReplicationStatus(), fmt.Errorf("%w: replicating from %s", iface.ErrBusy, r.pos.Source)
+	}
+	pos := replicationPosition{Source: source, Prefix: prefix}
+	if err := saveReplication(n.dataDir, pos); err != nil {
+		r.mu.Unlock()
+		return n.ReplicationStatus(), err
+	}
+	// Marked running before the lock is released, so a concurrent call is
+	// refused instead of starting a second link
+	ctx := n.beginReplicationLocked(pos)
+	r.mu.Unlock()
+
+	n.setReadOnly(true)
+	go n.replicationLoop(ctx)
+	return n.ReplicationStatus(), nil
+}
+
+// resumeReplication restarts a link saved before the node stopped.
+func (n *Node) resumeReplication() {
+	if pos, ok := loadReplication(n.dataDir); ok {
+		log.Printf("Node %d resuming replication from %s at index %d", n.id, pos.Source, pos.Applied)
+		n.replication.mu.Lock()
+		ctx := n.beginReplicationLocked(pos)
+		n.replication.mu.Unlock()
+		go n.replicationLoop(ctx)
+	}
+}
+
+// beginReplicationLocked sets up the link state for tailing from pos and
+// returns the context that stops the link. Caller holds the link's lock.
+func (n *Node) beginReplicationLocked(pos replicationPosition) context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := n.replication
+	r.pos, r.running, r.cancel, r.err = pos, true, cancel, ""
+	r.sourceIndex, r.committed, r.unsaved = 0, time.Time{}, 0
+	return ctx
+}
+
+// Promote is the manual failover: it stops the link and makes the standby
+// cluster writable. The link is forgotten so a restart does not resume it.
+func (n *Node) Promote() (iface.ReplicationStatus, error) {
+	r := n.replication
+	r.mu.Lock()
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.running, r.cancel = false, nil
+	r.mu.Unlock()
+
+	if err := os.Remove(filepath.Join(n.dataDir, replicationFile)); err != nil && !os.IsNotExist(err) {
+		return n.ReplicationStatus(), err
+	}
+	n.setReadOnly(false)
+	log.Printf("Node %d promoted its cluster to primary", n.id)
+	return n.ReplicationStatus(), nil
+}
+
+func (n *Node) ReplicationStatus() iface.ReplicationStatus {
+	r := n.replication
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	st := iface.ReplicationStatus{
+		Source:      r.pos.Source,
+		Prefix:      r.pos.Prefix,
+		Running:     r.running,
+		LogID:       r.pos.LogID,
+		Applied:     r.pos.Applied,
+		SourceIndex: r.sourceIndex,
+		Error:       r.err,
+		ReadOnly:    n.ClusterMetadata().ReadOnly,
+	}
+	if r.sourceIndex > r.pos.Applied {
+		st.LagEntries = r.sourceIndex - r.pos.Applied
+		if !r.committed.IsZero() {
+			st.Lag = time.Since(r.committed)
+		}
+	}
+	return st
+}
+
+// setReadOnly publishes the read-only flag in a new metadata version, which
+// gossip spreads to the rest of the cluster.
+func (n *Node) setReadOnly(readOnly bool) {
+	md := n.ClusterMetadata()
+	if md.ReadOnly == readOnly {
+		return
+	}
+	md.Version++
+	md.ReadOnly = readOnly
+	n.MergeClusterMetadata(md)
+}
+
+func (n *Node) replicationLoop(ctx context.Context) {
+	for ctx.Err() == nil {
+		err := n.tailSource(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("Node %d replication link failed, retrying: %v", n.id, err)
+		n.replication.mu.Lock()
+		n.replication.err = err.Error()
+		n.replication.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(replicationRetry):
+		}
+	}
+}
+
+// tailSource streams the source's log from the saved position and applies it
+// until the stream breaks.
+func (n *Node) tailSource(ctx context.Context) error {
+	n.replication.mu.Lock()
+	pos := n.replication.pos
+	n.replication.mu.Unlock()
+
+	conn, err := dial(pos.Source)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	stream, err := server.NewOpLogClient(conn).Tail(ctx, &server.TailRequest{LogId: pos.LogID, FromIndex: pos.Applied + 1, Prefix: pos.Prefix})
+	if err != nil {
+		return err
+	}
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if err := n.applyLogEntry(server.LogEntryFromProto(msg)); err != nil {
+			return err
+		}
+	}
+}
+
+// applyLogEntry applies an entry of the primary's log through the normal write
+// path, so it is routed and replicated inside the standby like a client write.
+// Writes carry the log id and index as client session, which drops duplicates
+// delivered again after a reconnect.
+func (n *Node) applyLogEntry(e iface.LogEntry) error {
+	r := n.replication
+	r.mu.Lock()
+	r.sourceIndex = e.HeadIndex
+	prefix := r.pos.Prefix
+	r.mu.Unlock()
+
+	switch {
+	case e.Op == iface.LOG_RESET:
+		log.Printf("Node %d replacing keys under %q with a snapshot of log %d at index %d", n.id, prefix, e.LogID, e.Index)
+		_, err := n.HandleDeleteRange(iface.RequestMeta{Replicated: true}, prefix, storage.PrefixEnd(prefix), false)
+		return err
+	case e.Op == iface.LOG_HEARTBEAT:
+		n.advanceReplication(e, true)
+		return nil
+	case e.Snapshot && e.Op == INSTALL && len(e.Records) > 0:
+		return n.handleWrite(iface.RequestMeta{}, operation{kind: INSTALL, key: e.Records[0].Key, records: e.Records, replicated: true})
+	case e.Snapshot:
+		return n.handleWrite(iface.RequestMeta{}, operation{kind: e.Op, key: e.Key, value: e.Value, replicated: true})
+	}
+
+	op := operation{kind: e.Op, key: e.Key, value: e.Value, clientID: fmt.Sprintf("replication-%d", e.LogID), seq: e.Index, replicated: true}
+	switch e.Op {
+	case TXN, INSTALL:
+		// The collections an install replaced are written after its other
+		// writes, outside the session, since writing them twice is harmless.
+		if len(e.Ops) > 0 {
+			op.kind, op.key, op.txn = TXN, e.Ops[0].Key, &iface.Txn{Success: e.Ops, Resolved: true, Succeeded: true}
+			if err := n.handleWrite(iface.RequestMeta{}, op); err != nil {
+				return err
+			}
+		}
+		if len(e.Records) > 0 {
+			if err := n.handleWrite(iface.RequestMeta{}, operation{kind: INSTALL, key: e.Records[0].Key, records: e.Records, replicated: true}); err != nil {
+				return err
+			}
+		}
+		n.advanceReplication(e, false)
+		return nil
+	}
+	if err := n.handleWrite(iface.RequestMeta{}, op); err != nil {
+		return err
+	}
+	n.advanceReplication(e, false)
+	return nil
+}
+
+// advanceReplication records e as applied, saving the position on heartbeats
+// and every replicationSaveEvery entries.
+func (n *Node) advanceReplication(e iface.LogEntry, save bool) {
+	r := n.replication
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pos.LogID, r.pos.Applied = e.LogID, e.Index
+	if !e.Committed.IsZero() {
+		r.committed = e.Committed
+	}
+	r.err = ""
+	r.unsaved++
+	if save || r.unsaved >= replicationSaveEvery {
+		if err := saveReplication(n.dataDir, r.pos); err != nil {
+			log.Printf("Node %d could not save its replication position: %v", n.id, err)
+			return
+		}
+		r.unsaved = 0
+	}
+}
@@ -0,0 +1,80 @@
+package node
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sort"
+	"strconv"
+)
+
+const defaultVirtualNodes = 64
+
+type ringPoint struct {
+	hash  uint64
+	shard int
+}
+
+// HashRing maps keys to shards with consistent hashing. Every shard owns
+// several virtual points so load stays even and adding or removing a shard
+// only moves the keys adjacent to its points.
+type HashRing struct {
+	points []ringPoint
+}
+
+func NewHashRing(shards []int, virtualNodes int) *HashRing {
+	if virtualNodes <= 0 {
+		virtualNodes = defaultVirtualNodes
+	}
+	r := &HashRing{}
+	for _, shard := range shards {
+		for v := 0; v < virtualNodes; v++ {
+			r.points = append(r.points, ringPoint{hash: hashKey(strconv.Itoa(shard) + "#" + strconv.Itoa(v)), shard: shard})
+		}
+	}
+	sort.Slice(r.points, func(i, j int) bool {
+		if r.points[i].hash == r.points[j].hash {
+			return r.points[i].shard < r.points[j].shard
+		}
+		return r.points[i].hash < r.points[j].hash
+	})
+	return r
+}
+
+func hashKey(key string) uint64 {
+	sum := sha256.Sum256([]byte(key))
+	return binary.BigEndian.Uint64(sum[:8])
+}
+
+// Owner returns the shard owning key, -1 if the ring is empty.
+func (r *HashRing) Owner(key string) int {
+	if len(r.points) == 0 {
+		return -1
+	}
+	h := hashKey(key)
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i].hash >= h })
+	if i == len(r.points) {
+		i = 0 // wrap around
+	}
+	return r.points[i].shard
+}
+
+// Successors walks the ring clockwise from key and returns up to n distinct
+// shards, the first being the owner.
+func (r *HashRing) Successors(key string, n int) []int {
+	if len(r.points) == 0 {
+		return nil
+	}
+	h := hashKey(key)
+	start := sort.Search(len(r.points), func(i int) bool { return r.points[i].hash >= h })
+
+	var out []int
+	seen := make(map[int]bool)
+	for i := 0; i < len(r.points) && len(out) < n; i++ {
+		shard := r.points[(start+i)%len(r.points)].shard
+		if !seen[shard] {
+			seen[shard] = true
+			out = append(out, shard)
+		}
+	}
+	return out
+}
@@ -0,0 +1,197 @@
+package node
+
+import (
+	"context"
+	"kvstore/iface"
+	"kvstore/server"
+	"kvstore/storage"
+	"log"
+	"strings"
+	"time"
+)
+
+func (n *Node) GetShard() int {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.shard
+}
+
+// SetShard places the node in a replica group. Only members of the same
+// group are kept in Node.nodes and receive this node's replication.
+func (n *Node) SetShard(shard int) {
+	n.members.setShard(shard)
+	members := n.members.list()
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.shard = shard
+	n.nodes = make(map[int]string)
+	for _, m := range members {
+		if m.ID != n.id && m.Shard == shard && m.Status != iface.DEAD {
+			n.nodes[m.ID] = m.Addr
+		}
+	}
+}
+
+func (n *Node) ClusterMetadata() iface.ClusterMetadata {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.metadata
+}
+
+// SetClusterMetadata installs a new routing table; gossip spreads it to the
+// other nodes. Its version must be above the current one.
+func (n *Node) SetClusterMetadata(md iface.ClusterMetadata) bool {
+	return n.MergeClusterMetadata(md)
+}
+
+// MergeClusterMetadata combines md with the local copy. The higher version
+// decides which groups exist; each group's entry is then taken from whichever
+// side has the higher group version, so leaders can publish their own range
+// changes without coordinating.
+func (n *Node) MergeClusterMetadata(md iface.ClusterMetadata) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	merged, changed := mergeMetadata(n.metadata, md)
+	if !changed {
+		return false
+	}
+	n.metadata = merged
+	shards := make([]int, 0, len(merged.Shards))
+	for _, g := range merged.Shards {
+		shards = append(shards, g.ID)
+	}
+	n.ring = NewHashRing(shards, merged.VirtualNodes)
+	n.directory = newRangeDirectory(merged.Shards)
+	log.Printf("Node %d installed cluster metadata version %d with %d shards", n.id, merged.Version, len(shards))
+	return true
+}
+
+func mergeMetadata(cur, in iface.ClusterMetadata) (iface.ClusterMetadata, bool) {
+	base, other := cur, in
+	if in.Version > cur.Version {
+		base, other = in, cur
+	}
+	others := make(map[int]iface.ShardGroup, len(other.Shards))
+	for _, g := range other.Shards {
+		others[g.ID] = g
+	}
+
+	merged := base
+	merged.Shards = make([]iface.ShardGroup, len(base.Shards))
+	for i, g := range base.Shards {
+		if o, ok := others[g.ID]; ok && o.Version > g.Version {
+			g = o
+		}
+		merged.Shards[i] = g
+	}
+	return merged, !sameVersions(cur, merged)
+}
+
+func sameVersions(a, b iface.ClusterMetadata) bool {
+	if a.Version != b.Version || len(a.Shards) != len(b.Shards) {
+		return false
+	}
+	for i := range a.Shards {
+		if a.Shards[i].ID != b.Shards[i].ID || a.Shards[i].Version != b.Shards[i].Version {
+			return false
+		}
+	}
+	return true
+}
+
+// ownerLocked returns the shard owning key, -1 when unknown. Caller holds n.mu.
+func (n *Node) ownerLocked(key string) int {
+	if n.metadata.Partitioning == iface.RANGE {
+		entry, ok := n.directory.lookup(key)
+		if !ok {
+			return -1
+		}
+		return entry.shard
+	}
+	if n.ring == nil {
+		return -1
+	}
+	return n.ring.Owner(hashTag(key))
+}
+
+// hashTag returns the part of key that places it on the hash ring: the text
+// between its first '{' and the next '}' when there is some, as in Redis
+// Cluster, or else the whole key. Keys sharing a tag live in the same group.
+func hashTag(key string) string {
+	if i := strings.IndexByte(key, '{'); i >= 0 {
+		if j := strings.IndexByte(key[i+1:], '}'); j > 0 {
+			return key[i+1 : i+1+j]
+		}
+	}
+	return key
+}
+
+// rangeTag returns the hash tag every key of [start, end) shares, which they
+// do when start opens with a tag and end is no further than the keys
+// beginning with it.
+func rangeTag(start, end string) (string, bool) {
+	if !strings.HasPrefix(start, "{") {
+		return "", false
+	}
+	tag := hashTag(start)
+	if tag == start {
+		return "", false
+	}
+	prefix := start[:len(tag)+2]
+	if end == "" || end > storage.PrefixEnd(prefix) {
+		return "", false
+	}
+	return tag, true
+}
+
+// KeyOwner returns the group owning key when that group is not ours. Without
+// metadata the node owns the whole keyspace.
+func (n *Node) KeyOwner(key string) (iface.ShardGroup, bool) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	owner := n.ownerLocked(key)
+	if owner == -1 || owner == n.shard {
+		return iface.ShardGroup{}, false
+	}
+	for _, g := range n.metadata.Shards {
+		if g.ID == owner {
+			return g, true
+		}
+	}
+	return iface.ShardGroup{}, false
+}
+
+// route returns the leader address of the shard owning key when that shard
+// is not ours.
+func (n *Node) route(key string) (string, bool) {
+	g, remote := n.KeyOwner(key)
+	return g.LeaderAddr, remote
+}
+
+// routeOperation hands a client write to the owning shard as a client would,
+// so the receiving group applies its own epoch and forwarding rules.
+func routeOperation(addr string, op operation) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	return sendOperation(ctx, addr, op)
+}
+
+func routeGet(addr string, key string, revision int64) (string, storage.KeyMeta, error) {
+	conn, err := dial(addr)
+	if err != nil {
+		return "", storage.KeyMeta{}, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	resp, err := server.NewKVStoreClient(conn).Get(ctx, &server.GetRequest{Key: key, Revision: revision})
+	if err != nil {
+		return "", storage.KeyMeta{}, err
+	}
+	return resp.Value, server.KeyMetaFromProto(resp.Meta), nil
+}
@@ -0,0 +1,124 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"kvstore/iface"
+	"kvstore/storage"
+	"strconv"
+)
+
+// errCompareFailed is what applying a transaction whose compares failed
+// returns to the dedup table, which caches it like any result so a retry
+// learns which branch ran. It never reaches clients.
+var errCompareFailed = errors.New("transaction compares failed")
+
+// outcome turns the successful application of a transaction whose failure
+// branch ran into errCompareFailed.
+func outcome(op operation, err error) error {
+	if err == nil && op.kind == TXN && !op.txn.Succeeded {
+		return errCompareFailed
+	}
+	return err
+}
+
+// HandleTxn runs txn atomically on the leader of the group owning its keys and
+// replicates the writes it applied as a single operation. It reports whether
+// the compares held and the compared keys as the leader saw them, which the
+// dedup table answers a retry with as well. A leader that took over only
+// knows the branch that ran, so a retry it answers reports no keys.
+func (n *Node) HandleTxn(meta iface.RequestMeta, txn iface.Txn) (bool, map[string]storage.KeyState, error) {
+	if n.Leaderless() {
+		return false, nil, fmt.Errorf("%w: transactions need a leader", iface.ErrUnsupported)
+	}
+	keys := txn.Keys()
+	if len(keys) == 0 {
+		return true, nil, nil
+	}
+	addr, remote := n.route(keys[0])
+	for _, key := range keys[1:] {
+		if a, r := n.route(key); a != addr || r != remote {
+			return false, nil, fmt.Errorf("%w: transaction keys %q and %q belong to different shards", iface.ErrUnsupported, keys[0], key)
+		}
+	}
+
+	op := operation{kind: TXN, key: keys[0], clientID: meta.ClientID, seq: meta.Seq, replicated: meta.Replicated, txn: &txn}
+	err := n.handleWrite(meta, op)
+	if errors.Is(err, errCompareFailed) {
+		return false, txn.Current, nil
+	}
+	return err == nil, txn.Current, err
+}
+
+// HandleCompareAndSwap sets key to value if it currently holds expected.
+func (n *Node) HandleCompareAndSwap(meta iface.RequestMeta, key, expected, value string) (bool, storage.KeyState, error) {
+	cmp := storage.Compare{Key: key, Target: storage.CMP_VALUE, Op: storage.CMP_EQ, Value: expected}
+	return n.conditionalWrite(meta, []storage.Compare{cmp}, storage.BatchOp{Key: key, Value: value})
+}
+
+func (n *Node) HandleConditionalPut(meta iface.RequestMeta, key, value string, cond iface.Condition) (bool, storage.KeyState, error) {
+	return n.conditionalWrite(meta, conditionCompares(key, cond), storage.BatchOp{Key: key, Value: value})
+}
+
+func (n *Node) HandleConditionalDelete(meta iface.RequestMeta, key string, cond iface.Condition) (bool, storage.KeyState, error) {
+	return n.conditionalWrite(meta, conditionCompares(key, cond), storage.BatchOp{Key: key, Delete: true})
+}
+
+// conditionalWrite runs a single write as a transaction, so the condition is
+// evaluated inside MemoryStorage on the leader and only the resulting write
+// is replicated.
+func (n *Node) conditionalWrite(meta iface.RequestMeta, cmps []storage.Compare, write storage.BatchOp) (bool, storage.KeyState, error) {
+	ok, current, err := n.HandleTxn(meta, iface.Txn{Compares: cmps, Success: []storage.BatchOp{write}})
+	return ok, current[write.Key], err
+}
+
+// HandleIncrement adds delta to the integer held by key and returns the
+// result. The leader computes it inside MemoryStorage, resolving the
+// increment into a put of the result that is replicated like any write. A
+// retry answered by a leader that took over, which does not know the value
+// the key held, reads the key instead.
+func (n *Node) HandleIncrement(meta iface.RequestMeta, key string, delta int64) (int64, error) {
+	txn := iface.Txn{Success: []storage.BatchOp{{Key: key, Increment: true, Delta: delta}}}
+	_, current, err := n.HandleTxn(meta, txn)
+	if err != nil {
+		return 0, err
+	}
+	before, ok := current[key]
+	if !ok {
+		value, err := n.HandleGet(key)
+		if err != nil {
+			return 0, err
+		}
+		return strconv.ParseInt(value, 10, 64)
+	}
+	old := int64(0)
+	if before.Exists {
+		old, _ = strconv.ParseInt(before.Value, 10, 64)
+	}
+	return old + delta, nil
+}
+
+func conditionCompares(key string, cond iface.Condition) []storage.Compare {
+	var cmps []storage.Compare
+	if cond.IfAbsent {
+		cmps = append(cmps, storage.Compare{Key: key, Target: storage.CMP_EXISTS, Op: storage.CMP_EQ, Exists: false})
+	}
+	if cond.IfVersion > 0 {
+		cmps = append(cmps, storage.Compare{Key: key, Target: storage.CMP_VERSION, Op: storage.CMP_EQ, Version: cond.IfVersion})
+	}
+	return cmps
+}
+
+// applyTxn evaluates and applies txn in one storage batch and resolves it to
+// the branch that ran. A resolved transaction is applied as it is.
+func (n *Node) applyTxn(txn *iface.Txn, st storage.Stamp) (storage.Stamp, error) {
+	if txn.Resolved {
+		return n.storage.ApplyBatch(txn.Success, st)
+	}
+	res, err := n.storage.Txn(txn.Compares, txn.Success, txn.Failure, st)
+	if err != nil {
+		return storage.Stamp{}, err
+	}
+	*txn = iface.Txn{Success: res.Applied, Resolved: true, Succeeded: res.Succeeded, Current: res.Current}
+	return res.Stamp, nil
+}
@@ -2,125 +2,166 @@ package node
 
 import (
 	"context"
+	"kvstore/iface"
 	"kvstore/server"
+	"kvstore/storage"
 	"log"
 	"strconv"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
-func broadcastRequest(myid int, nodes map[int]string, requestType string, key string, value string) {
-	switch requestType {
-	case "put":
-		for followerID, followerAddr := range nodes {
-			if followerID == myid {
-				log.Printf("Node %d is leader, skipping replication to itself", myid)
-				continue
-			}
-			go func(id int, addr string) {
-				log.Printf("Node %d replicating Put to follower %d at %s", myid, id, addr)
-				conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
-				if err != nil {
-					log.Printf("Failed to connect to follower %d: %v", id, err)
-					return
-				}
-				defer conn.Close()
+// peerContext tags an outgoing request with the sender id, its leader epoch
+// and the leader it follows in that epoch.
+func peerContext(myid, leader int, epoch uint64) (context.Context, context.CancelFunc) {
+	return peerContextWithTimeout(myid, leader, epoch, time.Second)
+}
 
-				client := server.NewKVStoreClient(conn)
-				md := metadata.Pairs("requesterID", strconv.Itoa(myid))
-				ctx := metadata.NewOutgoingContext(context.TODO(), md)
-				ctx, cancel := context.WithTimeout(ctx, time.Second)
-				defer cancel()
+func peerContextWithTimeout(myid, leader int, epoch uint64, timeout time.Duration) (context.Context, context.CancelFunc) {
+	md := metadata.Pairs("requesterID", strconv.Itoa(myid), "epoch", strconv.FormatUint(epoch, 10), "leader", strconv.Itoa(leader))
+	ctx := metadata.NewOutgoingContext(context.TODO(), md)
+	return context.WithTimeout(ctx, timeout)
+}
 
-				_, err = client.Put(ctx, &server.PutRequest{Key: key, Value: value})
-				if err != nil {
-					log.Printf("Replication to follower %d failed: %v", id, err)
-				} else {
-					log.Printf("Successfully replicated to follower %d", id)
-				}
-			}(followerID, followerAddr)
-		}
-	case "delete":
-		for followerID, followerAddr := range nodes {
-			if followerID == myid {
-				log.Printf("Node %d is leader, skipping replication to itself", myid)
-				continue
-			}
-			go func(id int, addr string) {
-				log.Printf("Node %d replicating delete to follower %d at %s", myid, id, addr)
-				conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
-				if err != nil {
-					log.Printf("Failed to connect to follower %d: %v", id, err)
-					return
-				}
-				defer conn.Close()
+// dial connects to a peer. Peer addresses are host:port pairs resolved by the
+// system resolver when connecting; grpc's own DNS resolver would also look up
+// service config TXT records, and closing the connection waits for that.
+func dial(addr string) (*grpc.ClientConn, error) {
+	return grpc.NewClient("passthrough:///"+addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+}
+
+// sendOperation delivers op to the KVStore service at addr.
+func sendOperation(ctx context.Context, addr string, op operation) error {
+	conn, err := dial(addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
 
-				client := server.NewKVStoreClient(conn)
-				md := metadata.Pairs("requesterID", strconv.Itoa(myid))
-				ctx := metadata.NewOutgoingContext(context.TODO(), md)
-				ctx, cancel := context.WithTimeout(ctx, time.Second)
-				defer cancel()
+	client := server.NewKVStoreClient(conn)
+	if op.replicated {
+		ctx = metadata.AppendToOutgoingContext(ctx, "replicated", "true")
+	}
 
-				_, err = client.Delete(ctx, &server.DeleteRequest{Key: key})
-				if err != nil {
-					log.Printf("Replication to follower %d failed: %v", id, err)
-				} else {
-					log.Printf("Successfully replicated to follower %d", id)
-				}
-			}(followerID, followerAddr)
+	switch op.kind {
+	case PUT:
+		_, err = client.Put(ctx, &server.PutRequest{Key: op.key, Value: op.value, ClientId: op.clientID, Sequence: op.seq, Stamp: server.StampToProto(op.stamp), Lease: op.lease})
+	case DELETE:
+		_, err = client.Delete(ctx, &server.DeleteRequest{Key: op.key, ClientId: op.clientID, Sequence: op.seq, Stamp: server.StampToProto(op.stamp)})
+	case TXN:
+		req := server.TxnToProto(*op.txn)
+		req.ClientId, req.Sequence, req.Stamp = op.clientID, op.seq, server.StampToProto(op.stamp)
+		var resp *server.TxnResponse
+		resp, err = client.Txn(ctx, req)
+		if err == nil && !op.txn.Resolved {
+			op.txn.Current = server.KeyStatesFromProto(resp.Current)
+			if !resp.Succeeded {
+				err = errCompareFailed
+			}
+		}
+	case LEASE_GRANT:
+		req := &server.LeaseGrantRequest{Id: op.lease, TtlMs: op.ttl.Milliseconds()}
+		_, err = server.NewLeaseClient(conn).Grant(ctx, req)
+	case LEASE_REVOKE:
+		req := &server.LeaseRevokeRequest{Id: op.lease, Stamp: server.StampToProto(op.stamp)}
+		for _, w := range op.revoked {
+			req.Keys = append(req.Keys, w.Key)
+		}
+		_, err = server.NewLeaseClient(conn).Revoke(ctx, req)
+	case INSTALL:
+		var stream server.Admin_InstallRangeClient
+		if stream, err = server.NewAdminClient(conn).InstallRange(ctx); err != nil {
+			return err
+		}
+		chunk := server.RangeChunkToProto(iface.KeyRange{}, iface.RangeChunk{Records: op.records}, false)
+		chunk.Stamp = server.StampToProto(op.stamp)
+		if err = stream.Send(chunk); err == nil {
+			_, err = stream.CloseAndRecv()
+		}
+	case COLLECTION:
+		var resp *server.CollectionReply
+		resp, err = server.NewCollectionsClient(conn).Apply(ctx, server.CollectionOpToProto(op.coll.op))
+		if err == nil && !op.coll.applied {
+			op.coll.result = server.CollectionResultFromProto(resp)
 		}
-	default:
-		log.Printf("Unknown request type: %s", requestType)
 	}
+	return peerError(err)
 }
 
-func forwardRequestToLeader(myid int, leaderAddr string, requestType string, key string, value string) {
-	switch requestType {
-	case "put":
-		conn, err := grpc.NewClient(leaderAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
-		if err != nil {
-			log.Printf("Failed to connect to leader %s: %v", leaderAddr, err)
-			return
+// peerError restores the storage error behind a status a peer returned, so a
+// forwarded request fails with the error it would have failed with locally.
+func peerError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok || err == nil {
+		return err
+	}
+	for _, target := range []error{storage.ErrWrongType, storage.ErrNotNumber, storage.ErrOverflow, storage.ErrInvalidArgument} {
+		if strings.Contains(st.Message(), target.Error()) {
+			return remoteError{err, target}
 		}
-		defer conn.Close()
+	}
+	return err
+}
 
-		client := server.NewKVStoreClient(conn)
-		md := metadata.Pairs("requesterID", strconv.Itoa(myid))
-		ctx := metadata.NewOutgoingContext(context.TODO(), md)
-		ctx, cancel := context.WithTimeout(ctx, time.Second)
-		defer cancel()
+// remoteError is an error a peer returned, which is also its target.
+type remoteError struct {
+	error
+	target error
+}
 
-		_, err = client.Put(ctx, &server.PutRequest{Key: key, Value: value})
-		if err != nil {
-			log.Printf("Forwarding Put to leader %s failed: %v", leaderAddr, err)
-		} else {
-			log.Printf("Successfully forwarded Put to leader %s", leaderAddr)
-		}
+func (e remoteError) Unwrap() []error { return []error{e.error, e.target} }
 
-	case "delete":
-		conn, err := grpc.NewClient(leaderAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
-		if err != nil {
-			log.Printf("Failed to connect to leader %s: %v", leaderAddr, err)
-			return
-		}
-		defer conn.Close()
+// sendChainWrite passes w to the Chain service of the next node at addr.
+func sendChainWrite(ctx context.Context, addr string, w iface.ChainWrite) error {
+	conn, err := dial(addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
 
-		client := server.NewKVStoreClient(conn)
-		md := metadata.Pairs("requesterID", strconv.Itoa(myid))
-		ctx := metadata.NewOutgoingContext(context.TODO(), md)
-		ctx, cancel := context.WithTimeout(ctx, time.Second)
-		defer cancel()
+	_, err = server.NewChainClient(conn).Propagate(ctx, server.ChainWriteToProto(w))
+	return err
+}
 
-		_, err = client.Delete(ctx, &server.DeleteRequest{Key: key})
-		if err != nil {
-			log.Printf("Forwarding delete to leader %s failed: %v", leaderAddr, err)
-		} else {
-			log.Printf("Successfully forwarded delete to leader %s", leaderAddr)
+func broadcastRequest(myid int, epoch uint64, nodes map[int]string, op operation) {
+	if !op.known() {
+		log.Printf("Unknown request type: %s", op.kind)
+		return
+	}
+	for followerID, followerAddr := range nodes {
+		if followerID == myid {
+			log.Printf("Node %d is leader, skipping replication to itself", myid)
+			continue
 		}
-	default:
-		log.Printf("Unknown request type: %s", requestType)
+		go func(id int, addr string) {
+			log.Printf("Node %d replicating %s to follower %d at %s", myid, op.kind, id, addr)
+			ctx, cancel := peerContext(myid, myid, epoch)
+			defer cancel()
+			if err := sendOperation(ctx, addr, op); err != nil {
+				log.Printf("Replication to follower %d failed: %v", id, err)
+			} else {
+				log.Printf("Successfully replicated to follower %d", id)
+			}
+		}(followerID, followerAddr)
+	}
+}
+
+func forwardRequestToLeader(myid, leader int, epoch uint64, leaderAddr string, op operation) error {
+	if !op.known() {
+		log.Printf("Unknown request type: %s", op.kind)
+		return nil
+	}
+	ctx, cancel := peerContext(myid, leader, epoch)
+	defer cancel()
+	if err := sendOperation(ctx, leaderAddr, op); err != nil {
+		log.Printf("Forwarding %s to leader %s failed: %v", op.kind, leaderAddr, err)
+		return err
 	}
+	log.Printf("Successfully forwarded %s to leader %s", op.kind, leaderAddr)
+	return nil
 }
@@ -0,0 +1,161 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"kvstore/iface"
+	"kvstore/storage"
+	"sync"
+	"time"
+)
+
+const watchBuffer = 256 // writes queued per watch before it falls back to the history
+
+// watcher is a watch registered with the hub.
+type watcher struct {
+	start, end string
+	events     chan []storage.Event // changes of one write each, within the range
+	lagged     chan struct{}        // closed when events overflowed and the watcher was dropped
+}
+
+// watchHub fans the writes applied to this node's storage out to the watches
+// it serves. It is the storage observer, so every replica feeds it with what
+// it applies, whichever path the write took.
+type watchHub struct {
+	mu       sync.Mutex
+	watchers map[*watcher]struct{}
+	last     int64 // revision of the last write published
+}
+
+func newWatchHub() *watchHub {
+	return &watchHub{watchers: make(map[*watcher]struct{})}
+}
+
+func (h *watchHub) add(start, end string) *watcher {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	w := &watcher{start: start, end: end, events: make(chan []storage.Event, watchBuffer), lagged: make(chan struct{})}
+	h.watchers[w] = struct{}{}
+	return w
+}
+
+func (h *watchHub) remove(w *watcher) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.watchers, w)
+}
+
+// publish hands the changes of a write to the watches of their keys. A watch
+// too slow to take them is dropped and told so; it catches up from the
+// storage history instead.
+func (h *watchHub) publish(events []storage.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, ev := range events {
+		h.last = max(h.last, ev.Meta.ModRevision)
+	}
+	for w := range h.watchers {
+		var matched []storage.Event
+		for _, ev := range events {
+			if ev.Key >= w.start && (w.end == "" || ev.Key < w.end) {
+				matched = append(matched, ev)
+			}
+		}
+		if len(matched) == 0 {
+			continue
+		}
+		select {
+		case w.events <- matched:
+		default:
+			delete(h.watchers, w)
+			close(w.lagged)
+		}
+	}
+}
+
+// idle returns the revision of the last write published when nothing is
+// queued for w, which is then up to date with it.
+func (h *watchHub) idle(w *watcher) (int64, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.watchers[w]; !ok || len(w.events) > 0 {
+		return 0, false
+	}
+	return h.last, true
+}
+
+// HandleWatch streams the changes to the keys of req as this node applies
+// them, one response per write, until ctx is done. Changes from
+// req.StartRevision on are replayed from the history first. Any replica can
+// serve a watch of the keys its group owns.
+func (n *Node) HandleWatch(ctx context.Context, req iface.WatchRequest, send func(iface.WatchResponse) error) error {
+	if n.Leaderless() {
+		return fmt.Errorf("%w: revisions are not kept in leaderless mode", iface.ErrUnsupported)
+	}
+	if addr, remote, err := n.scanOwner(req.Start, req.End); err != nil {
+		return err
+	} else if remote {
+		return fmt.Errorf("%w: the keys are owned by the group led by %s", iface.ErrUnsupported, addr)
+	}
+
+	w := n.watches.add(req.Start, req.End)
+	defer func() { n.watches.remove(w) }()
+
+	// Writes up to sent have been delivered or were made before the watch
+	sent, catchUp := req.StartRevision-1, req.StartRevision > 0
+	if !catchUp {
+		sent = n.storage.Revision()
+	}
+	var progress <-chan time.Time
+	if req.ProgressInterval > 0 {
+		ticker := time.NewTicker(req.ProgressInterval)
+		defer ticker.Stop()
+		progress = ticker.C
+	}
+
+	for {
+		if catchUp {
+			events, last, err := n.storage.ChangesSince(req.Start, req.End, sent+1)
+			if err != nil {
+				return err
+			}
+			for len(events) > 0 {
+				rev := events[0].Meta.ModRevision
+				i := 1
+				for i < len(events) && events[i].Meta.ModRevision == rev {
+					i++
+				}
+				if err := send(iface.WatchResponse{Events: events[:i], Revision: rev}); err != nil {
+					return err
+				}
+				events = events[i:]
+			}
+			sent, catchUp = max(sent, last), false
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case events := <-w.events:
+			rev := events[0].Meta.ModRevision
+			if rev <= sent {
+				continue
+			}
+			if err := send(iface.WatchResponse{Events: events, Revision: rev}); err != nil {
+				return err
+			}
+			sent = rev
+		case <-w.lagged:
+			w, catchUp = n.watches.add(req.Start, req.End), true
+		case <-progress:
+			if last, ok := n.watches.idle(w); ok {
+				if err := send(iface.WatchResponse{Revision: max(last, sent), Progress: true}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package server
+
+import (
+	"context"
+	"io"
+	"kvstore/iface"
+	"kvstore/storage"
+	"maps"
+	"slices"
+	"time"
+)
+
+type adminServer struct {
+	node iface.NodeAPI
+	UnimplementedAdminServer
+}
+
+func (s *adminServer) PlanRebalance(ctx context.Context, req *RebalanceRequest) (*RebalanceStatus, error) {
+	moves, err := s.node.PlanRebalance()
+	return &RebalanceStatus{Moves: movesToProto(moves)}, toStatus(err)
+}
+
+func (s *adminServer) StartRebalance(ctx context.Context, req *RebalanceRequest) (*RebalanceStatus, error) {
+	moves, err := s.node.StartRebalance(req.BytesPerSecond)
+	return &RebalanceStatus{Moves: movesToProto(moves), Running: err == nil}, toStatus(err)
+}
+
+func (s *adminServer) GetRebalanceStatus(ctx context.Context, req *RebalanceStatusRequest) (*RebalanceStatus, error) {
+	moves, running := s.node.RebalanceStatus()
+	return &RebalanceStatus{Moves: movesToProto(moves), Running: running}, nil
+}
+
+func (s *adminServer) MoveRange(ctx context.Context, req *MoveRangeRequest) (*MoveRangeResponse, error) {
+	keys, bytes, err := s.node.HandleMoveRange(rangeFromProto(req.Range), int(req.ToShard), req.ToAddr, req.BytesPerSecond)
+	return &MoveRangeResponse{Keys: keys, Bytes: bytes}, toStatus(err)
+}
+
+func (s *adminServer) InstallRange(stream Admin_InstallRangeServer) error {
+	meta := requestMeta(stream.Context())
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&InstallRangeResponse{})
+		}
+		if err != nil {
+			return err
+		}
+
+		r := rangeFromProto(chunk.Range)
+		meta.Stamp = StampFromProto(chunk.Stamp)
+		if err := s.node.HandleInstallRange(meta, r, RangeChunkFromProto(chunk)); err != nil {
+			return toStatus(err)
+		}
+		if chunk.Commit {
+			group, err := s.node.HandleCommitRange(r)
+			if err != nil {
+				return toStatus(err)
+			}
+			return stream.SendAndClose(&InstallRangeResponse{Group: groupToProto(group)})
+		}
+	}
+}
+
+func (s *adminServer) StartReplication(ctx context.Context, req *StartReplicationRequest) (*ReplicationStatus, error) {
+	st, err := s.node.StartReplication(req.Source, req.Prefix)
+	return replicationStatusToProto(st), toStatus(err)
+}
+
+func (s *adminServer) GetReplicationStatus(ctx context.Context, req *ReplicationStatusRequest) (*ReplicationStatus, error) {
+	return replicationStatusToProto(s.node.ReplicationStatus()), nil
+}
+
+func (s *adminServer) Promote(ctx context.Context, req *PromoteRequest) (*ReplicationStatus, error) {
+	st, err := s.node.Promote()
+	return replicationStatusToProto(st), toStatus(err)
+}
+
+func replicationStatusToProto(st iface.ReplicationStatus) *ReplicationStatus {
+	return &ReplicationStatus{
+		Source:       st.Source,
+		Prefix:       st.Prefix,
+		Running:      st.Running,
+		LogId:        st.LogID,
+		AppliedIndex: st.Applied,
+		SourceIndex:  st.SourceIndex,
+		LagEntries:   st.LagEntries,
+		LagSeconds:   st.Lag.Seconds(),
+		Error:        st.Error,
+		ReadOnly:     st.ReadOnly,
+	}
+}
+
+func movesToProto(moves []iface.RangeMove) []*RangeMove {
+	out := make([]*RangeMove, 0, len(moves))
+	for _, m := range moves {
+		out = append(out, &RangeMove{
+			Range:     RangeToProto(m.Range),
+			FromShard: int32(m.From),
+			ToShard:   int32(m.To),
+			State:     m.State,
+			Keys:      m.Keys,
+			Bytes:     m.Bytes,
+			Error:     m.Error,
+		})
+	}
+	return out
+}
+
+func RangeToProto(r iface.KeyRange) *KeyRange {
+	return &KeyRange{Start: r.Start, End: r.End}
+}
+
+func rangeFromProto(r *KeyRange) iface.KeyRange {
+	return iface.KeyRange{Start: r.GetStart(), End: r.GetEnd()}
+}
+
+// RangeChunkToProto converts chunk for streaming r, the last chunk when commit
+// is set.
+func RangeChunkToProto(r iface.KeyRange, chunk iface.RangeChunk, commit bool) *RangeChunk {
+	out := &RangeChunk{Range: RangeToProto(r), Commit: commit, LeaseTtlMs: make(map[int64]int64, len(chunk.Leases))}
+	for id, ttl := range chunk.Leases {
+		out.LeaseTtlMs[id] = ttl.Milliseconds()
+	}
+	out.Records = KeyRecordsToProto(chunk.Records)
+	return out
+}
+
+func RangeChunkFromProto(chunk *RangeChunk) iface.RangeChunk {
+	out := iface.RangeChunk{Leases: make(map[int64]time.Duration, len(chunk.GetLeaseTtlMs()))}
+	for id, ms := range chunk.GetLeaseTtlMs() {
+		out.Leases[id] = time.Duration(ms) * time.Millisecond
+	}
+	out.Records = KeyRecordsFromProto(chunk.GetRecords())
+	return out
+}
+
+func KeyRecordsToProto(records []storage.KeyRecord) []*KeyRecord {
+	var out []*KeyRecord
+	for _, rec := range records {
+		pb := &KeyRecord{Key: rec.Key, Collection: CollectionToProto(rec.Collection)}
+		for _, v := range rec.History {
+			pb.History = append(pb.History, &KeyVersion{Value: v.Value, Meta: KeyMetaToProto(v.Meta), Deleted: v.Deleted})
+		}
+		out = append(out, pb)
+	}
+	return out
+}
+
+func KeyRecordsFromProto(records []*KeyRecord) []storage.KeyRecord {
+	var out []storage.KeyRecord
+	for _, pb := range records {
+		rec := storage.KeyRecord{Key: pb.GetKey(), Collection: CollectionFromProto(pb.GetCollection())}
+		for _, v := range pb.GetHistory() {
+			rec.History = append(rec.History, storage.KeyVersion{Value: v.GetValue(), Meta: KeyMetaFromProto(v.GetMeta()), Deleted: v.GetDeleted()})
+		}
+		out = append(out, rec)
+	}
+	return out
+}
+
+// CollectionToProto lists the elements of c, sets, hashes and sorted sets in
+// member order.
+func CollectionToProto(c *storage.Collection) *CollectionValue {
+	if c == nil {
+		return nil
+	}
+	out := &CollectionValue{Type: c.Type, Elements: append([]string(nil), c.List...)}
+	for _, m := range slices.Sorted(maps.Keys(c.Set)) {
+		out.Elements = append(out.Elements, m)
+	}
+	for _, f := range slices.Sorted(maps.Keys(c.Hash)) {
+		out.Elements, out.Values = append(out.Elements, f), append(out.Values, c.Hash[f])
+	}
+	for _, m := range slices.Sorted(maps.Keys(c.ZSet)) {
+		out.Elements, out.Scores = append(out.Elements, m), append(out.Scores, c.ZSet[m])
+	}
+	return out
+}
+
+func CollectionFromProto(pb *CollectionValue) *storage.Collection {
+	if pb == nil {
+		return nil
+	}
+	c := &storage.Collection{Type: pb.GetType()}
+	switch c.Type {
+	case storage.COLL_LIST:
+		c.List = pb.GetElements()
+	case storage.COLL_SET:
+		c.Set = make(map[string]bool)
+		for _, m := range pb.GetElements() {
+			c.Set[m] = true
+		}
+	case storage.COLL_HASH:
+		c.Hash = make(map[string]string)
+		for i, f := range pb.GetElements()[:min(len(pb.GetElements()), len(pb.GetValues()))] {
+			c.Hash[f] = pb.GetValues()[i]
+		}
+	case storage.COLL_ZSET:
+		c.ZSet = make(map[string]float64)
+		for i, m := range pb.GetElements()[:min(len(pb.GetElements()), len(pb.GetScores()))] {
+			c.ZSet[m] = pb.GetScores()[i]
+		}
+	}
+	return c
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"kvstore/iface"
+	"kvstore/storage"
+)
+
+const (
+	bulkBatch     = 1000 // records of a bulk load applied as one write
+	bulkMaxErrors = 100  // errors a bulk load reports back
+)
+
+func (s *GRPCServer) BatchGet(ctx context.Context, req *BatchGetRequest) (*BatchGetResponse, error) {
+	results := s.node.HandleBatchGet(req.Keys)
+	resp := &BatchGetResponse{Results: make([]*BatchGetResult, len(results)), Epoch: s.node.GetEpoch()}
+	for i, res := range results {
+		resp.Results[i] = GetResultToProto(res)
+	}
+	return resp, nil
+}
+
+func (s *GRPCServer) BatchWrite(ctx context.Context, req *BatchWriteRequest) (*BatchWriteResponse, error) {
+	meta := requestMeta(ctx)
+	meta.ClientID, meta.Seq = req.ClientId, req.Sequence
+	errs := s.node.HandleBatchWrite(meta, BatchFromProto(req.Ops))
+	resp := &BatchWriteResponse{Errors: make([]string, len(errs)), Epoch: s.node.GetEpoch()}
+	for i, err := range errs {
+		resp.Errors[i] = errorString(err)
+	}
+	return resp, nil
+}
+
+func GetResultToProto(res iface.GetResult) *BatchGetResult {
+	return &BatchGetResult{Key: res.Key, Value: res.Value, Found: res.Found, Meta: KeyMetaToProto(res.Meta), Error: errorString(res.Err)}
+}
+
+func GetResultFromProto(res *BatchGetResult) iface.GetResult {
+	out := iface.GetResult{Key: res.GetKey(), Value: res.GetValue(), Found: res.GetFound(), Meta: KeyMetaFromProto(res.GetMeta())}
+	if res.GetError() != "" {
+		out.Err = errors.New(res.GetError())
+	}
+	return out
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func (s *GRPCServer) BulkLoad(stream KVStore_BulkLoadServer) error {
+	meta := requestMeta(stream.Context())
+	resp := &BulkLoadResponse{}
+	overwrite, first := true, true
+	var pending []storage.BatchOp
+	flush := func() {
+		loaded, skipped, errs := s.node.HandleBulkLoad(meta, pending, overwrite)
+		resp.Loaded += int64(loaded)
+		resp.Skipped += int64(skipped)
+		for i, err := range errs {
+			if err == nil {
+				continue
+			}
+			resp.Failed++
+			if len(resp.Errors) < bulkMaxErrors {
+				resp.Errors = append(resp.Errors, fmt.Sprintf("%s: %v", pending[i].Key, err))
+			}
+		}
+		pending = pending[:0]
+	}
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		if first {
+			overwrite, first = !req.SkipExisting, false
+		}
+		for _, kv := range req.Records {
+			pending = append(pending, storage.BatchOp{Key: kv.Key, Value: kv.Value})
+			if len(pending) == bulkBatch {
+				flush()
+			}
+		}
+	}
+	if len(pending) > 0 {
+		flush()
+	}
+	resp.Epoch = s.node.GetEpoch()
+	return stream.SendAndClose(resp)
+}
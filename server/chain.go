@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+	"kvstore/iface"
+)
+
+type chainServer struct {
+	node iface.NodeAPI
+	UnimplementedChainServer
+}
+
+func (s *chainServer) Propagate(ctx context.Context, req *ChainWrite) (*ChainAck, error) {
+	err := s.node.HandleChainWrite(requestMeta(ctx), ChainWriteFromProto(req))
+	return &ChainAck{}, toStatus(err)
+}
+
+func (s *chainServer) Read(ctx context.Context, req *GetRequest) (*GetResponse, error) {
+	value, meta, err := s.node.HandleChainRead(req.Key, req.Revision)
+	return &GetResponse{Value: value, Epoch: s.node.GetEpoch(), Meta: KeyMetaToProto(meta)}, toStatus(err)
+}
+
+func ChainWriteToProto(w iface.ChainWrite) *ChainWrite {
+	return &ChainWrite{
+		Op:        w.Op,
+		Key:       w.Key,
+		Value:     w.Value,
+		ClientId:  w.ClientID,
+		Sequence:  w.Seq,
+		Version:   w.Version,
+		Ops:       BatchToProto(w.Ops),
+		Succeeded: w.Succeeded,
+		Stamp:     StampToProto(w.Stamp),
+	}
+}
+
+func ChainWriteFromProto(w *ChainWrite) iface.ChainWrite {
+	return iface.ChainWrite{
+		Op:        w.GetOp(),
+		Key:       w.GetKey(),
+		Value:     w.GetValue(),
+		ClientID:  w.GetClientId(),
+		Seq:       w.GetSequence(),
+		Version:   w.GetVersion(),
+		Ops:       BatchFromProto(w.GetOps()),
+		Succeeded: w.GetSucceeded(),
+		Stamp:     StampFromProto(w.GetStamp()),
+	}
+}
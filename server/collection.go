@@ -0,0 +1,128 @@
+package server
+
+import (
+	"context"
+	"kvstore/iface"
+	"kvstore/storage"
+	"sort"
+)
+
+type collectionsServer struct {
+	node iface.NodeAPI
+	UnimplementedCollectionsServer
+}
+
+// run runs op for a client and renders the reply.
+func (s *collectionsServer) run(ctx context.Context, op storage.CollectionOp) (*CollectionReply, error) {
+	res, err := s.node.HandleCollection(requestMeta(ctx), op)
+	reply := CollectionResultToProto(res)
+	reply.Epoch = s.node.GetEpoch()
+	return reply, toStatus(err)
+}
+
+func (s *collectionsServer) ListPush(ctx context.Context, req *ListPushRequest) (*CollectionReply, error) {
+	cmd := storage.RPUSH
+	if req.Left {
+		cmd = storage.LPUSH
+	}
+	return s.run(ctx, storage.CollectionOp{Cmd: cmd, Key: req.Key, Members: req.Values})
+}
+
+func (s *collectionsServer) ListPop(ctx context.Context, req *ListPopRequest) (*CollectionReply, error) {
+	cmd := storage.RPOP
+	if req.Left {
+		cmd = storage.LPOP
+	}
+	return s.run(ctx, storage.CollectionOp{Cmd: cmd, Key: req.Key, Count: int(req.Count)})
+}
+
+func (s *collectionsServer) ListRange(ctx context.Context, req *ListRangeRequest) (*CollectionReply, error) {
+	return s.run(ctx, storage.CollectionOp{Cmd: storage.LRANGE, Key: req.Key, Start: req.Start, Stop: req.Stop})
+}
+
+func (s *collectionsServer) SetAdd(ctx context.Context, req *MembersRequest) (*CollectionReply, error) {
+	return s.run(ctx, storage.CollectionOp{Cmd: storage.SADD, Key: req.Key, Members: req.Members})
+}
+
+func (s *collectionsServer) SetRemove(ctx context.Context, req *MembersRequest) (*CollectionReply, error) {
+	return s.run(ctx, storage.CollectionOp{Cmd: storage.SREM, Key: req.Key, Members: req.Members})
+}
+
+func (s *collectionsServer) SetMembers(ctx context.Context, req *CollectionKeyRequest) (*CollectionReply, error) {
+	return s.run(ctx, storage.CollectionOp{Cmd: storage.SMEMBERS, Key: req.Key})
+}
+
+func (s *collectionsServer) SetIntersect(ctx context.Context, req *SetIntersectRequest) (*CollectionReply, error) {
+	op := storage.CollectionOp{Cmd: storage.SINTER}
+	if len(req.Keys) > 0 {
+		op.Key, op.Keys = req.Keys[0], req.Keys[1:]
+	}
+	return s.run(ctx, op)
+}
+
+func (s *collectionsServer) HashSet(ctx context.Context, req *HashSetRequest) (*CollectionReply, error) {
+	op := storage.CollectionOp{Cmd: storage.HSET, Key: req.Key}
+	// Fields in order, so every replica applies the same command
+	for field := range req.Fields {
+		op.Members = append(op.Members, field)
+	}
+	sort.Strings(op.Members)
+	for _, field := range op.Members {
+		op.Values = append(op.Values, req.Fields[field])
+	}
+	return s.run(ctx, op)
+}
+
+func (s *collectionsServer) HashGet(ctx context.Context, req *HashFieldsRequest) (*CollectionReply, error) {
+	return s.run(ctx, storage.CollectionOp{Cmd: storage.HGET, Key: req.Key, Members: req.Fields})
+}
+
+func (s *collectionsServer) HashDelete(ctx context.Context, req *HashFieldsRequest) (*CollectionReply, error) {
+	return s.run(ctx, storage.CollectionOp{Cmd: storage.HDEL, Key: req.Key, Members: req.Fields})
+}
+
+func (s *collectionsServer) HashGetAll(ctx context.Context, req *CollectionKeyRequest) (*CollectionReply, error) {
+	return s.run(ctx, storage.CollectionOp{Cmd: storage.HGETALL, Key: req.Key})
+}
+
+func (s *collectionsServer) ZSetAdd(ctx context.Context, req *ZSetAddRequest) (*CollectionReply, error) {
+	op := storage.CollectionOp{Cmd: storage.ZADD, Key: req.Key}
+	for _, m := range req.Members {
+		op.Members, op.Scores = append(op.Members, m.Member), append(op.Scores, m.Score)
+	}
+	return s.run(ctx, op)
+}
+
+func (s *collectionsServer) ZSetRemove(ctx context.Context, req *MembersRequest) (*CollectionReply, error) {
+	return s.run(ctx, storage.CollectionOp{Cmd: storage.ZREM, Key: req.Key, Members: req.Members})
+}
+
+func (s *collectionsServer) ZSetRangeByScore(ctx context.Context, req *ZSetRangeRequest) (*CollectionReply, error) {
+	return s.run(ctx, storage.CollectionOp{Cmd: storage.ZRANGEBYSCORE, Key: req.Key, Min: req.Min, Max: req.Max})
+}
+
+func (s *collectionsServer) ZSetRank(ctx context.Context, req *ZSetRankRequest) (*CollectionReply, error) {
+	return s.run(ctx, storage.CollectionOp{Cmd: storage.ZRANK, Key: req.Key, Members: []string{req.Member}})
+}
+
+func (s *collectionsServer) Apply(ctx context.Context, req *CollectionOp) (*CollectionReply, error) {
+	return s.run(ctx, CollectionOpFromProto(req))
+}
+
+func CollectionOpToProto(op storage.CollectionOp) *CollectionOp {
+	return &CollectionOp{Cmd: op.Cmd, Key: op.Key, Keys: op.Keys, Members: op.Members, Values: op.Values, Scores: op.Scores,
+		Start: op.Start, Stop: op.Stop, Min: op.Min, Max: op.Max, Count: int32(op.Count)}
+}
+
+func CollectionOpFromProto(op *CollectionOp) storage.CollectionOp {
+	return storage.CollectionOp{Cmd: op.GetCmd(), Key: op.GetKey(), Keys: op.GetKeys(), Members: op.GetMembers(), Values: op.GetValues(),
+		Scores: op.GetScores(), Start: op.GetStart(), Stop: op.GetStop(), Min: op.GetMin(), Max: op.GetMax(), Count: int(op.GetCount())}
+}
+
+func CollectionResultToProto(res storage.CollectionResult) *CollectionReply {
+	return &CollectionReply{Elements: res.Elements, Values: res.Values, Scores: res.Scores, Count: res.Count, Found: res.Found}
+}
+
+func CollectionResultFromProto(r *CollectionReply) storage.CollectionResult {
+	return storage.CollectionResult{Elements: r.GetElements(), Values: r.GetValues(), Scores: r.GetScores(), Count: r.GetCount(), Found: r.GetFound()}
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"kvstore/iface"
+	"kvstore/storage"
+	"log"
+)
+
+type crdtServer struct {
+	node iface.NodeAPI
+	UnimplementedCRDTServer
+}
+
+func (s *crdtServer) Increment(ctx context.Context, req *CounterIncrementRequest) (*CRDTValue, error) {
+	state, err := s.node.HandleCounterIncrement(req.Key, req.Delta)
+	return crdtValue(state), toStatus(err)
+}
+
+func (s *crdtServer) AddToSet(ctx context.Context, req *SetRequest) (*CRDTValue, error) {
+	state, err := s.node.HandleSetAdd(req.Key, req.Members)
+	return crdtValue(state), toStatus(err)
+}
+
+func (s *crdtServer) RemoveFromSet(ctx context.Context, req *SetRequest) (*CRDTValue, error) {
+	state, err := s.node.HandleSetRemove(req.Key, req.Members)
+	return crdtValue(state), toStatus(err)
+}
+
+func (s *crdtServer) SetRegister(ctx context.Context, req *RegisterRequest) (*CRDTValue, error) {
+	state, err := s.node.HandleRegisterSet(req.Key, req.Value)
+	return crdtValue(state), toStatus(err)
+}
+
+func (s *crdtServer) MapPut(ctx context.Context, req *MapRequest) (*CRDTValue, error) {
+	state, err := s.node.HandleMapPut(req.Key, req.Field, req.Value)
+	return crdtValue(state), toStatus(err)
+}
+
+func (s *crdtServer) MapRemove(ctx context.Context, req *MapRequest) (*CRDTValue, error) {
+	state, err := s.node.HandleMapRemove(req.Key, req.Field)
+	return crdtValue(state), toStatus(err)
+}
+
+func (s *crdtServer) Read(ctx context.Context, req *CRDTReadRequest) (*CRDTValue, error) {
+	state, err := s.node.HandleCRDTRead(req.Key)
+	return crdtValue(state), toStatus(err)
+}
+
+func (s *crdtServer) Sync(ctx context.Context, req *CRDTStates) (*CRDTStates, error) {
+	return CRDTStatesToProto(s.node.HandleCRDTSync(CRDTStatesFromProto(req))), nil
+}
+
+// crdtValue renders the merged state a client reads.
+func crdtValue(state *storage.CRDT) *CRDTValue {
+	if state == nil {
+		return &CRDTValue{}
+	}
+	out := &CRDTValue{Type: state.Type}
+	switch state.Type {
+	case storage.COUNTER:
+		out.Counter = state.Counter.Value()
+	case storage.SET:
+		out.Members = state.Set.Members()
+	case storage.REGISTER:
+		out.Register = state.Register.Value
+	case storage.MAP:
+		out.Entries = state.Map.Entries()
+	}
+	return out
+}
+
+func CRDTStatesToProto(states map[string]*storage.CRDT) *CRDTStates {
+	out := &CRDTStates{}
+	for key, state := range states {
+		if state == nil {
+			out.States = append(out.States, &CRDTState{Key: key}) // asks the peer for its state
+			continue
+		}
+		data, err := state.Encode()
+		if err != nil {
+			log.Printf("Skipping CRDT %s: %v", key, err)
+			continue
+		}
+		out.States = append(out.States, &CRDTState{Key: key, State: data})
+	}
+	return out
+}
+
+func CRDTStatesFromProto(states *CRDTStates) map[string]*storage.CRDT {
+	out := make(map[string]*storage.CRDT, len(states.GetStates()))
+	for _, s := range states.GetStates() {
+		if len(s.State) == 0 {
+			out[s.Key] = nil
+			continue
+		}
+		state, err := storage.DecodeCRDT(s.State)
+		if err != nil {
+			log.Printf("Skipping CRDT %s: %v", s.Key, err)
+			continue
+		}
+		out[s.Key] = state
+	}
+	return out
+}
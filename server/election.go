@@ -0,0 +1,46 @@
+package server
+
+import (
+	"context"
+	"kvstore/iface"
+)
+
+type electionServer struct {
+	node iface.NodeAPI
+	UnimplementedElectionServer
+}
+
+func (s *electionServer) Campaign(ctx context.Context, req *CampaignRequest) (*CampaignResponse, error) {
+	leader, err := s.node.HandleCampaign(ctx, req.Name, req.Lease, req.Value)
+	return &CampaignResponse{Leader: LeaderKeyToProto(leader), Epoch: s.node.GetEpoch()}, toStatus(err)
+}
+
+func (s *electionServer) Proclaim(ctx context.Context, req *ProclaimRequest) (*ProclaimResponse, error) {
+	err := s.node.HandleProclaim(requestMeta(ctx), LeaderKeyFromProto(req.Leader), req.Value)
+	return &ProclaimResponse{Epoch: s.node.GetEpoch()}, toStatus(err)
+}
+
+func (s *electionServer) Resign(ctx context.Context, req *ResignRequest) (*ResignResponse, error) {
+	err := s.node.HandleResign(requestMeta(ctx), LeaderKeyFromProto(req.Leader))
+	return &ResignResponse{Epoch: s.node.GetEpoch()}, toStatus(err)
+}
+
+func (s *electionServer) Leader(ctx context.Context, req *LeaderRequest) (*LeaderResponse, error) {
+	leader, value, err := s.node.HandleLeader(req.Name)
+	return &LeaderResponse{Leader: LeaderKeyToProto(leader), Value: value, Epoch: s.node.GetEpoch()}, toStatus(err)
+}
+
+func (s *electionServer) Observe(req *LeaderRequest, stream Election_ObserveServer) error {
+	err := s.node.HandleObserve(stream.Context(), req.Name, func(leader iface.LeaderKey, value string) error {
+		return stream.Send(&LeaderResponse{Leader: LeaderKeyToProto(leader), Value: value, Epoch: s.node.GetEpoch()})
+	})
+	return toStatus(err)
+}
+
+func LeaderKeyToProto(k iface.LeaderKey) *LeaderKey {
+	return &LeaderKey{Name: k.Name, Key: k.Key, Revision: k.Revision, Lease: k.Lease}
+}
+
+func LeaderKeyFromProto(k *LeaderKey) iface.LeaderKey {
+	return iface.LeaderKey{Name: k.GetName(), Key: k.GetKey(), Revision: k.GetRevision(), Lease: k.GetLease()}
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"kvstore/iface"
+	"kvstore/storage"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatus maps node errors to gRPC status codes so clients can tell them apart.
+func toStatus(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, iface.ErrStaleEpoch), errors.Is(err, iface.ErrNotLeader), errors.Is(err, iface.ErrUnsupported),
+		errors.Is(err, iface.ErrReadOnly), errors.Is(err, iface.ErrNotElected), errors.Is(err, iface.ErrStaleSeq):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, storage.ErrWrongType), errors.Is(err, storage.ErrNotNumber):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, storage.ErrInvalidArgument):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, storage.ErrCompacted), errors.Is(err, storage.ErrFutureRevision), errors.Is(err, storage.ErrOverflow):
+		return status.Error(codes.OutOfRange, err.Error())
+	case errors.Is(err, storage.ErrLeaseNotFound), errors.Is(err, iface.ErrNoLeader):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	case errors.Is(err, iface.ErrQuorum):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, iface.ErrBusy):
+		return status.Error(codes.Aborted, err.Error())
+	default:
+		return err
+	}
+}
+
+// respError maps node errors to the error prefixes Redis clients know. The
+// returned string is the error reply without its leading '-'.
+func respError(err error) string {
+	prefix := "ERR"
+	switch {
+	case errors.Is(err, storage.ErrWrongType):
+		prefix = "WRONGTYPE"
+	case errors.Is(err, iface.ErrStaleEpoch), errors.Is(err, iface.ErrNotLeader):
+		prefix = "TRYAGAIN"
+	case errors.Is(err, iface.ErrReadOnly):
+		prefix = "READONLY"
+	case errors.Is(err, iface.ErrQuorum):
+		prefix = "CLUSTERDOWN"
+	case errors.Is(err, iface.ErrBusy):
+		prefix = "BUSY"
+	}
+	return prefix + " " + err.Error()
+}
+
+// memcacheError maps node errors to the error replies of the memcached
+// protocol: errors caused by the request are the client's, the others the
+// server's.
+func memcacheError(err error) string {
+	msg := strings.ReplaceAll(err.Error(), "\n", " ")
+	switch {
+	case errors.Is(err, storage.ErrWrongType), errors.Is(err, storage.ErrNotNumber), errors.Is(err, storage.ErrOverflow),
+		errors.Is(err, storage.ErrInvalidArgument):
+		return "CLIENT_ERROR " + msg
+	}
+	return "SERVER_ERROR " + msg
+}
+
+// httpStatus maps the gRPC codes toStatus produces to HTTP statuses.
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.OutOfRange, codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.Aborted:
+		return http.StatusConflict
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.Canceled:
+		return 499 // client closed the request
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusInternalServerError
+}
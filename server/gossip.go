@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"kvstore/iface"
+)
+
+type gossipServer struct {
+	node iface.NodeAPI
+	UnimplementedGossipServer
+}
+
+func (s *gossipServer) Ping(ctx context.Context, req *PingRequest) (*PingResponse, error) {
+	s.node.MergeClusterMetadata(MetadataFromProto(req.Metadata))
+	updates := s.node.HandlePing(MemberFromProto(req.From), MembersFromProto(req.Updates))
+	return &PingResponse{Ack: true, Updates: MembersToProto(updates), Metadata: MetadataToProto(s.node.ClusterMetadata())}, nil
+}
+
+func (s *gossipServer) PingReq(ctx context.Context, req *PingReqRequest) (*PingResponse, error) {
+	s.node.MergeClusterMetadata(MetadataFromProto(req.Metadata))
+	ack, updates := s.node.HandlePingReq(MemberFromProto(req.From), MemberFromProto(req.Target), MembersFromProto(req.Updates))
+	return &PingResponse{Ack: ack, Updates: MembersToProto(updates), Metadata: MetadataToProto(s.node.ClusterMetadata())}, nil
+}
+
+func (s *gossipServer) Join(ctx context.Context, req *JoinRequest) (*JoinResponse, error) {
+	members := s.node.HandleJoin(MemberFromProto(req.Member))
+	return &JoinResponse{Members: MembersToProto(members), Metadata: MetadataToProto(s.node.ClusterMetadata())}, nil
+}
+
+func MemberToProto(m iface.Member) *Member {
+	return &Member{Id: int32(m.ID), Addr: m.Addr, Status: MemberStatus(m.Status), Incarnation: m.Incarnation, Shard: int32(m.Shard)}
+}
+
+func MemberFromProto(m *Member) iface.Member {
+	return iface.Member{ID: int(m.GetId()), Addr: m.GetAddr(), Status: int(m.GetStatus()), Incarnation: m.GetIncarnation(), Shard: int(m.GetShard())}
+}
+
+func MembersToProto(members []iface.Member) []*Member {
+	out := make([]*Member, 0, len(members))
+	for _, m := range members {
+		out = append(out, MemberToProto(m))
+	}
+	return out
+}
+
+func MembersFromProto(members []*Member) []iface.Member {
+	out := make([]iface.Member, 0, len(members))
+	for _, m := range members {
+		out = append(out, MemberFromProto(m))
+	}
+	return out
+}
+
+func MetadataToProto(md iface.ClusterMetadata) *ClusterMetadata {
+	out := &ClusterMetadata{
+		Version:      md.Version,
+		VirtualNodes: int32(md.VirtualNodes),
+		Partitioning: md.Partitioning,
+		Replication:  md.Replication,
+		Replicas:     int32(md.Replicas),
+		ReadQuorum:   int32(md.ReadQuorum),
+		WriteQuorum:  int32(md.WriteQuorum),
+		ReadOnly:     md.ReadOnly,
+	}
+	for _, g := range md.Shards {
+		out.Shards = append(out.Shards, groupToProto(g))
+	}
+	return out
+}
+
+func MetadataFromProto(md *ClusterMetadata) iface.ClusterMetadata {
+	out := iface.ClusterMetadata{
+		Version:      md.GetVersion(),
+		VirtualNodes: int(md.GetVirtualNodes()),
+		Partitioning: md.GetPartitioning(),
+		Replication:  md.GetReplication(),
+		Replicas:     int(md.GetReplicas()),
+		ReadQuorum:   int(md.GetReadQuorum()),
+		WriteQuorum:  int(md.GetWriteQuorum()),
+		ReadOnly:     md.GetReadOnly(),
+	}
+	for _, g := range md.GetShards() {
+		out.Shards = append(out.Shards, GroupFromProto(g))
+	}
+	return out
+}
+
+func groupToProto(g iface.ShardGroup) *ShardGroup {
+	group := &ShardGroup{Id: int32(g.ID), LeaderId: int32(g.LeaderID), LeaderAddr: g.LeaderAddr, Version: g.Version, RespAddr: g.RESPAddr}
+	for _, r := range g.Ranges {
+		group.Ranges = append(group.Ranges, RangeToProto(r))
+	}
+	return group
+}
+
+func GroupFromProto(g *ShardGroup) iface.ShardGroup {
+	group := iface.ShardGroup{ID: int(g.GetId()), LeaderID: int(g.GetLeaderId()), LeaderAddr: g.GetLeaderAddr(), Version: g.GetVersion(), RESPAddr: g.GetRespAddr()}
+	for _, r := range g.GetRanges() {
+		group.Ranges = append(group.Ranges, rangeFromProto(r))
+	}
+	return group
+}
@@ -0,0 +1,199 @@
+package server
+
+import (
+	"encoding/json"
+	"kvstore/iface"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// httpMaxBody bounds the JSON body of a request.
+const httpMaxBody = 4 << 20
+
+var httpJSON = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+// HTTPServer is a JSON gateway to the KVStore service:
+//
+//	GET    /v1/kv/{key}   ?revision=
+//	PUT    /v1/kv/{key}   body: value, client_id, sequence, if_absent, if_version, lease
+//	DELETE /v1/kv/{key}   ?if_version=
+//	GET    /v1/kv         ?prefix= or ?start=&end=, with ?limit=&revision=
+//
+// Each request runs the gRPC handler of the same call, so it reaches the node
+// the same way and forwarding, routing and replication behave identically.
+// Responses are the protobuf messages in their JSON form with the proto field
+// names. A missing key is a 404, a failed condition a 412 with the key's
+// current state, and other errors carry the gRPC code and message under the
+// HTTP status of the code.
+type HTTPServer struct {
+	grpc *GRPCServer
+}
+
+func NewHTTPServer(n iface.NodeAPI) *HTTPServer {
+	return &HTTPServer{grpc: NewServer(n)}
+}
+
+func (s *HTTPServer) StartHTTPServer(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Printf("failed to listen: %v", err)
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/kv", s.scan)
+	mux.HandleFunc("GET /v1/kv/{key...}", s.get)
+	mux.HandleFunc("PUT /v1/kv/{key...}", s.put)
+	mux.HandleFunc("DELETE /v1/kv/{key...}", s.delete)
+	return http.Serve(listener, mux)
+}
+
+func (s *HTTPServer) get(w http.ResponseWriter, r *http.Request) {
+	key := r.PathValue("key")
+	if key == "" {
+		s.scan(w, r)
+		return
+	}
+	revision, err := queryInt(r, "revision")
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	resp, err := s.grpc.Get(r.Context(), &GetRequest{Key: key, Revision: revision})
+	if err == nil && resp.GetMeta().GetVersion() == 0 && len(resp.GetSiblings()) == 0 {
+		err = status.Errorf(codes.NotFound, "key %s not found", key)
+	}
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	writeHTTP(w, http.StatusOK, resp)
+}
+
+// putBody is the body of a PUT: the fields of a PutRequest a client may set.
+// The fields peers fill in, like the leader's stamp, are refused.
+type putBody struct {
+	Value     string `json:"value"`
+	ClientID  string `json:"client_id"`
+	Sequence  uint64 `json:"sequence"`
+	IfAbsent  bool   `json:"if_absent"`
+	IfVersion int64  `json:"if_version"`
+	Lease     int64  `json:"lease"`
+}
+
+func (s *HTTPServer) put(w http.ResponseWriter, r *http.Request) {
+	var body putBody
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, httpMaxBody))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&body); err != nil {
+		writeHTTPError(w, status.Errorf(codes.InvalidArgument, "invalid request body: %v", err))
+		return
+	}
+	key := r.PathValue("key")
+	if key == "" {
+		writeHTTPError(w, status.Error(codes.InvalidArgument, "key cannot be empty"))
+		return
+	}
+	req := &PutRequest{Key: key, Value: body.Value, ClientId: body.ClientID, Sequence: body.Sequence,
+		IfAbsent: body.IfAbsent, IfVersion: body.IfVersion, Lease: body.Lease}
+	resp, err := s.grpc.Put(r.Context(), req)
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	writeHTTP(w, conditionStatus(resp.Success), resp)
+}
+
+func (s *HTTPServer) delete(w http.ResponseWriter, r *http.Request) {
+	version, err := queryInt(r, "if_version")
+	if err == nil && r.PathValue("key") == "" {
+		err = status.Error(codes.InvalidArgument, "key cannot be empty")
+	}
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	resp, err := s.grpc.Delete(r.Context(), &DeleteRequest{Key: r.PathValue("key"), IfVersion: version})
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	writeHTTP(w, conditionStatus(resp.Success), resp)
+}
+
+func (s *HTTPServer) scan(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	limit, err := queryInt(r, "limit")
+	if err == nil && (limit < 0 || limit > math.MaxInt32) {
+		err = status.Errorf(codes.InvalidArgument, "invalid limit %d", limit)
+	}
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	revision, err := queryInt(r, "revision")
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	req := &ScanRequest{Prefix: q.Get("prefix"), Start: q.Get("start"), End: q.Get("end"), Limit: int32(limit), Revision: revision}
+	resp, err := s.grpc.Scan(r.Context(), req)
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	writeHTTP(w, http.StatusOK, resp)
+}
+
+// conditionStatus returns the status of a write that was applied or whose
+// condition failed.
+func conditionStatus(ok bool) int {
+	if ok {
+		return http.StatusOK
+	}
+	return http.StatusPreconditionFailed
+}
+
+// queryInt reads an integer query parameter, 0 when absent.
+func queryInt(r *http.Request, name string) (int64, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid %s %q", name, v)
+	}
+	return n, nil
+}
+
+func writeHTTP(w http.ResponseWriter, code int, m proto.Message) {
+	body, err := httpJSON.Marshal(m)
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(body)
+}
+
+// writeHTTPError writes err as a JSON body with the HTTP status of the gRPC
+// code toStatus gives it.
+func writeHTTPError(w http.ResponseWriter, err error) {
+	st := status.Convert(toStatus(err))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus(st.Code()))
+	json.NewEncoder(w).Encode(struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}{st.Code().String(), st.Message()})
+}
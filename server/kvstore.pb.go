@@ -21,11 +21,71 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Gossip
+type MemberStatus int32
+
+const (
+	MemberStatus_ALIVE   MemberStatus = 0
+	MemberStatus_SUSPECT MemberStatus = 1
+	MemberStatus_DEAD    MemberStatus = 2
+)
+
+// Enum value maps for MemberStatus.
+var (
+	MemberStatus_name = map[int32]string{
+		0: "ALIVE",
+		1: "SUSPECT",
+		2: "DEAD",
+	}
+	MemberStatus_value = map[string]int32{
+		"ALIVE":   0,
+		"SUSPECT": 1,
+		"DEAD":    2,
+	}
+)
+
+func (x MemberStatus) Enum() *MemberStatus {
+	p := new(MemberStatus)
+	*p = x
+	return p
+}
+
+func (x MemberStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MemberStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_kvstore_proto_enumTypes[0].Descriptor()
+}
+
+func (MemberStatus) Type() protoreflect.EnumType {
+	return &file_kvstore_proto_enumTypes[0]
+}
+
+func (x MemberStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MemberStatus.Descriptor instead.
+func (MemberStatus) EnumDescriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{0}
+}
+
 // Client requests
 type PutRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// Optional idempotency token: retries with the same client_id and
+	// sequence are applied once and answered from the dedup table.
+	ClientId string       `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Sequence uint64       `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Context  *VectorClock `protobuf:"bytes,5,opt,name=context,proto3" json:"context,omitempty"` // leaderless mode: clock of the versions being replaced
+	// Optional conditions, evaluated on the leader
+	IfAbsent      bool   `protobuf:"varint,6,opt,name=if_absent,json=ifAbsent,proto3" json:"if_absent,omitempty"`    // only create the key
+	IfVersion     int64  `protobuf:"varint,7,opt,name=if_version,json=ifVersion,proto3" json:"if_version,omitempty"` // only write the key at this version
+	Stamp         *Stamp `protobuf:"bytes,8,opt,name=stamp,proto3" json:"stamp,omitempty"`                           // internal: set by the leader when replicating
+	Lease         int64  `protobuf:"varint,9,opt,name=lease,proto3" json:"lease,omitempty"`                          // attach the key to this lease
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -74,9 +134,59 @@ func (x *PutRequest) GetValue() string {
 	return ""
 }
 
+func (x *PutRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *PutRequest) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *PutRequest) GetContext() *VectorClock {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *PutRequest) GetIfAbsent() bool {
+	if x != nil {
+		return x.IfAbsent
+	}
+	return false
+}
+
+func (x *PutRequest) GetIfVersion() int64 {
+	if x != nil {
+		return x.IfVersion
+	}
+	return 0
+}
+
+func (x *PutRequest) GetStamp() *Stamp {
+	if x != nil {
+		return x.Stamp
+	}
+	return nil
+}
+
+func (x *PutRequest) GetLease() int64 {
+	if x != nil {
+		return x.Lease
+	}
+	return 0
+}
+
 type GetRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Revision      int64                  `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"` // read the key as of this revision, 0 for the latest
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -118,9 +228,21 @@ func (x *GetRequest) GetKey() string {
 	return ""
 }
 
+func (x *GetRequest) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
 type DeleteRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	ClientId      string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Sequence      uint64                 `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Context       *VectorClock           `protobuf:"bytes,4,opt,name=context,proto3" json:"context,omitempty"`
+	IfVersion     int64                  `protobuf:"varint,5,opt,name=if_version,json=ifVersion,proto3" json:"if_version,omitempty"` // only delete the key at this version
+	Stamp         *Stamp                 `protobuf:"bytes,6,opt,name=stamp,proto3" json:"stamp,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -162,28 +284,66 @@ func (x *DeleteRequest) GetKey() string {
 	return ""
 }
 
-// Responses
-type PutResponse struct {
+func (x *DeleteRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *DeleteRequest) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *DeleteRequest) GetContext() *VectorClock {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *DeleteRequest) GetIfVersion() int64 {
+	if x != nil {
+		return x.IfVersion
+	}
+	return 0
+}
+
+func (x *DeleteRequest) GetStamp() *Stamp {
+	if x != nil {
+		return x.Stamp
+	}
+	return nil
+}
+
+type CompareAndSwapRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Expected      string                 `protobuf:"bytes,2,opt,name=expected,proto3" json:"expected,omitempty"` // a missing key has an empty value
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	ClientId      string                 `protobuf:"bytes,4,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Sequence      uint64                 `protobuf:"varint,5,opt,name=sequence,proto3" json:"sequence,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PutResponse) Reset() {
-	*x = PutResponse{}
+func (x *CompareAndSwapRequest) Reset() {
+	*x = CompareAndSwapRequest{}
 	mi := &file_kvstore_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PutResponse) String() string {
+func (x *CompareAndSwapRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PutResponse) ProtoMessage() {}
+func (*CompareAndSwapRequest) ProtoMessage() {}
 
-func (x *PutResponse) ProtoReflect() protoreflect.Message {
+func (x *CompareAndSwapRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_kvstore_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -195,39 +355,73 @@ func (x *PutResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PutResponse.ProtoReflect.Descriptor instead.
-func (*PutResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CompareAndSwapRequest.ProtoReflect.Descriptor instead.
+func (*CompareAndSwapRequest) Descriptor() ([]byte, []int) {
 	return file_kvstore_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *PutResponse) GetSuccess() bool {
+func (x *CompareAndSwapRequest) GetKey() string {
 	if x != nil {
-		return x.Success
+		return x.Key
 	}
-	return false
+	return ""
 }
 
-type GetResponse struct {
+func (x *CompareAndSwapRequest) GetExpected() string {
+	if x != nil {
+		return x.Expected
+	}
+	return ""
+}
+
+func (x *CompareAndSwapRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *CompareAndSwapRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *CompareAndSwapRequest) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+// Keys in [start, end), or with prefix when it is set
+type ScanRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Start         string                 `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End           string                 `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"` // empty for unbounded
+	Prefix        string                 `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`             // 0 for no limit
+	Revision      int64                  `protobuf:"varint,5,opt,name=revision,proto3" json:"revision,omitempty"`       // read as of this revision, 0 for the latest
+	Collections   bool                   `protobuf:"varint,6,opt,name=collections,proto3" json:"collections,omitempty"` // list the keys only, collections included
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetResponse) Reset() {
-	*x = GetResponse{}
+func (x *ScanRequest) Reset() {
+	*x = ScanRequest{}
 	mi := &file_kvstore_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetResponse) String() string {
+func (x *ScanRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetResponse) ProtoMessage() {}
+func (*ScanRequest) ProtoMessage() {}
 
-func (x *GetResponse) ProtoReflect() protoreflect.Message {
+func (x *ScanRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_kvstore_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -239,39 +433,74 @@ func (x *GetResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetResponse.ProtoReflect.Descriptor instead.
-func (*GetResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ScanRequest.ProtoReflect.Descriptor instead.
+func (*ScanRequest) Descriptor() ([]byte, []int) {
 	return file_kvstore_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetResponse) GetValue() string {
+func (x *ScanRequest) GetStart() string {
 	if x != nil {
-		return x.Value
+		return x.Start
 	}
 	return ""
 }
 
-type DeleteResponse struct {
+func (x *ScanRequest) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *ScanRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ScanRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ScanRequest) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *ScanRequest) GetCollections() bool {
+	if x != nil {
+		return x.Collections
+	}
+	return false
+}
+
+type CountRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	GroupOnly     bool                   `protobuf:"varint,1,opt,name=group_only,json=groupOnly,proto3" json:"group_only,omitempty"` // count the keys of the serving group only
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteResponse) Reset() {
-	*x = DeleteResponse{}
+func (x *CountRequest) Reset() {
+	*x = CountRequest{}
 	mi := &file_kvstore_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteResponse) String() string {
+func (x *CountRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteResponse) ProtoMessage() {}
+func (*CountRequest) ProtoMessage() {}
 
-func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
+func (x *CountRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_kvstore_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -283,39 +512,41 @@ func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
-func (*DeleteResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CountRequest.ProtoReflect.Descriptor instead.
+func (*CountRequest) Descriptor() ([]byte, []int) {
 	return file_kvstore_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *DeleteResponse) GetSuccess() bool {
+func (x *CountRequest) GetGroupOnly() bool {
 	if x != nil {
-		return x.Success
+		return x.GroupOnly
 	}
 	return false
 }
 
-type ReplicateResponse struct {
+type DeleteRangeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Start         string                 `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End           string                 `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`                               // empty for no end
+	GroupOnly     bool                   `protobuf:"varint,3,opt,name=group_only,json=groupOnly,proto3" json:"group_only,omitempty"` // delete the keys of the serving group only
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReplicateResponse) Reset() {
-	*x = ReplicateResponse{}
+func (x *DeleteRangeRequest) Reset() {
+	*x = DeleteRangeRequest{}
 	mi := &file_kvstore_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReplicateResponse) String() string {
+func (x *DeleteRangeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReplicateResponse) ProtoMessage() {}
+func (*DeleteRangeRequest) ProtoMessage() {}
 
-func (x *ReplicateResponse) ProtoReflect() protoreflect.Message {
+func (x *DeleteRangeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_kvstore_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -327,44 +558,7038 @@ func (x *ReplicateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReplicateResponse.ProtoReflect.Descriptor instead.
-func (*ReplicateResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteRangeRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRangeRequest) Descriptor() ([]byte, []int) {
 	return file_kvstore_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *ReplicateResponse) GetSuccess() bool {
+func (x *DeleteRangeRequest) GetStart() string {
 	if x != nil {
-		return x.Success
+		return x.Start
+	}
+	return ""
+}
+
+func (x *DeleteRangeRequest) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *DeleteRangeRequest) GetGroupOnly() bool {
+	if x != nil {
+		return x.GroupOnly
 	}
 	return false
 }
 
-var File_kvstore_proto protoreflect.FileDescriptor
+type CompactRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revision      int64                  `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_kvstore_proto_rawDesc = "" +
-	"\n" +
-	"\rkvstore.proto\"4\n" +
-	"\n" +
-	"PutRequest\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value\"\x1e\n" +
-	"\n" +
-	"GetRequest\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\"!\n" +
-	"\rDeleteRequest\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\"'\n" +
-	"\vPutResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"#\n" +
-	"\vGetResponse\x12\x14\n" +
-	"\x05value\x18\x01 \x01(\tR\x05value\"*\n" +
-	"\x0eDeleteResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"-\n" +
-	"\x11ReplicateResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2x\n" +
-	"\aKVStore\x12 \n" +
-	"\x03Put\x12\v.PutRequest\x1a\f.PutResponse\x12 \n" +
-	"\x03Get\x12\v.GetRequest\x1a\f.GetResponse\x12)\n" +
-	"\x06Delete\x12\x0e.DeleteRequest\x1a\x0f.DeleteResponseB\tZ\a./;mainb\x06proto3"
+func (x *CompactRequest) Reset() {
+	*x = CompactRequest{}
+	mi := &file_kvstore_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompactRequest) ProtoMessage() {}
+
+func (x *CompactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompactRequest.ProtoReflect.Descriptor instead.
+func (*CompactRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CompactRequest) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+// Watches key, or the keys with prefix, or those in [start, end)
+type WatchRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Key                string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Prefix             string                 `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Start              string                 `protobuf:"bytes,3,opt,name=start,proto3" json:"start,omitempty"`
+	End                string                 `protobuf:"bytes,4,opt,name=end,proto3" json:"end,omitempty"`
+	StartRevision      int64                  `protobuf:"varint,5,opt,name=start_revision,json=startRevision,proto3" json:"start_revision,omitempty"`                  // replay changes from this revision, 0 for new ones only
+	ProgressIntervalMs int32                  `protobuf:"varint,6,opt,name=progress_interval_ms,json=progressIntervalMs,proto3" json:"progress_interval_ms,omitempty"` // send progress when silent this long, 0 for never
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_kvstore_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WatchRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetStartRevision() int64 {
+	if x != nil {
+		return x.StartRevision
+	}
+	return 0
+}
+
+func (x *WatchRequest) GetProgressIntervalMs() int32 {
+	if x != nil {
+		return x.ProgressIntervalMs
+	}
+	return 0
+}
+
+type BatchGetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []string               `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetRequest) Reset() {
+	*x = BatchGetRequest{}
+	mi := &file_kvstore_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetRequest) ProtoMessage() {}
+
+func (x *BatchGetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BatchGetRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type BatchWriteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ops           []*TxnOp               `protobuf:"bytes,1,rep,name=ops,proto3" json:"ops,omitempty"`
+	ClientId      string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Sequence      uint64                 `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchWriteRequest) Reset() {
+	*x = BatchWriteRequest{}
+	mi := &file_kvstore_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchWriteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchWriteRequest) ProtoMessage() {}
+
+func (x *BatchWriteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchWriteRequest.ProtoReflect.Descriptor instead.
+func (*BatchWriteRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BatchWriteRequest) GetOps() []*TxnOp {
+	if x != nil {
+		return x.Ops
+	}
+	return nil
+}
+
+func (x *BatchWriteRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *BatchWriteRequest) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+// Records of a bulk load, sent in chunks of any size
+type BulkLoadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Records       []*KeyValue            `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	SkipExisting  bool                   `protobuf:"varint,2,opt,name=skip_existing,json=skipExisting,proto3" json:"skip_existing,omitempty"` // keep keys that exist; read from the first message
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkLoadRequest) Reset() {
+	*x = BulkLoadRequest{}
+	mi := &file_kvstore_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkLoadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkLoadRequest) ProtoMessage() {}
+
+func (x *BulkLoadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkLoadRequest.ProtoReflect.Descriptor instead.
+func (*BulkLoadRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BulkLoadRequest) GetRecords() []*KeyValue {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *BulkLoadRequest) GetSkipExisting() bool {
+	if x != nil {
+		return x.SkipExisting
+	}
+	return false
+}
+
+type IncrementRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Delta         int64                  `protobuf:"varint,2,opt,name=delta,proto3" json:"delta,omitempty"`
+	ClientId      string                 `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Sequence      uint64                 `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementRequest) Reset() {
+	*x = IncrementRequest{}
+	mi := &file_kvstore_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementRequest) ProtoMessage() {}
+
+func (x *IncrementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementRequest.ProtoReflect.Descriptor instead.
+func (*IncrementRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *IncrementRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *IncrementRequest) GetDelta() int64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+func (x *IncrementRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *IncrementRequest) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+// Responses
+type PutResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Epoch         uint64                 `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`    // leader epoch known to the serving node
+	Context       *VectorClock           `protobuf:"bytes,3,opt,name=context,proto3" json:"context,omitempty"` // leaderless mode: clock of the written version
+	Current       *KeyState              `protobuf:"bytes,4,opt,name=current,proto3" json:"current,omitempty"` // state of the key when a condition failed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutResponse) Reset() {
+	*x = PutResponse{}
+	mi := &file_kvstore_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutResponse) ProtoMessage() {}
+
+func (x *PutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutResponse.ProtoReflect.Descriptor instead.
+func (*PutResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PutResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *PutResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *PutResponse) GetContext() *VectorClock {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *PutResponse) GetCurrent() *KeyState {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+type GetResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Value string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Epoch uint64                 `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	// Leaderless mode: concurrent versions for the client to resolve, and the
+	// context to send with the write that resolves them
+	Siblings      []*Sibling   `protobuf:"bytes,3,rep,name=siblings,proto3" json:"siblings,omitempty"`
+	Context       *VectorClock `protobuf:"bytes,4,opt,name=context,proto3" json:"context,omitempty"`
+	Meta          *KeyMeta     `protobuf:"bytes,5,opt,name=meta,proto3" json:"meta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetResponse) Reset() {
+	*x = GetResponse{}
+	mi := &file_kvstore_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResponse) ProtoMessage() {}
+
+func (x *GetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResponse.ProtoReflect.Descriptor instead.
+func (*GetResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetResponse) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *GetResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *GetResponse) GetSiblings() []*Sibling {
+	if x != nil {
+		return x.Siblings
+	}
+	return nil
+}
+
+func (x *GetResponse) GetContext() *VectorClock {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *GetResponse) GetMeta() *KeyMeta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+type DeleteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Epoch         uint64                 `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Context       *VectorClock           `protobuf:"bytes,3,opt,name=context,proto3" json:"context,omitempty"`
+	Current       *KeyState              `protobuf:"bytes,4,opt,name=current,proto3" json:"current,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteResponse) Reset() {
+	*x = DeleteResponse{}
+	mi := &file_kvstore_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteResponse) ProtoMessage() {}
+
+func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
+func (*DeleteResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeleteResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *DeleteResponse) GetContext() *VectorClock {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *DeleteResponse) GetCurrent() *KeyState {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+// Result of one key of a BatchGet, in the order of the request
+type BatchGetResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Found         bool                   `protobuf:"varint,3,opt,name=found,proto3" json:"found,omitempty"`
+	Meta          *KeyMeta               `protobuf:"bytes,4,opt,name=meta,proto3" json:"meta,omitempty"`
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"` // set when the key could not be read
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetResult) Reset() {
+	*x = BatchGetResult{}
+	mi := &file_kvstore_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetResult) ProtoMessage() {}
+
+func (x *BatchGetResult) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetResult.ProtoReflect.Descriptor instead.
+func (*BatchGetResult) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BatchGetResult) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *BatchGetResult) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *BatchGetResult) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *BatchGetResult) GetMeta() *KeyMeta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *BatchGetResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type BatchGetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*BatchGetResult      `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Epoch         uint64                 `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetResponse) Reset() {
+	*x = BatchGetResponse{}
+	mi := &file_kvstore_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetResponse) ProtoMessage() {}
+
+func (x *BatchGetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *BatchGetResponse) GetResults() []*BatchGetResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *BatchGetResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+type BatchWriteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Errors        []string               `protobuf:"bytes,1,rep,name=errors,proto3" json:"errors,omitempty"` // one per op, empty when it was applied
+	Epoch         uint64                 `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchWriteResponse) Reset() {
+	*x = BatchWriteResponse{}
+	mi := &file_kvstore_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchWriteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchWriteResponse) ProtoMessage() {}
+
+func (x *BatchWriteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchWriteResponse.ProtoReflect.Descriptor instead.
+func (*BatchWriteResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *BatchWriteResponse) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *BatchWriteResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+type BulkLoadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Loaded        int64                  `protobuf:"varint,1,opt,name=loaded,proto3" json:"loaded,omitempty"`
+	Skipped       int64                  `protobuf:"varint,2,opt,name=skipped,proto3" json:"skipped,omitempty"` // existing keys, and repeats of a key, when skipping
+	Failed        int64                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	Errors        []string               `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"` // the first errors, as "key: error"
+	Epoch         uint64                 `protobuf:"varint,5,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkLoadResponse) Reset() {
+	*x = BulkLoadResponse{}
+	mi := &file_kvstore_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkLoadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkLoadResponse) ProtoMessage() {}
+
+func (x *BulkLoadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkLoadResponse.ProtoReflect.Descriptor instead.
+func (*BulkLoadResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *BulkLoadResponse) GetLoaded() int64 {
+	if x != nil {
+		return x.Loaded
+	}
+	return 0
+}
+
+func (x *BulkLoadResponse) GetSkipped() int64 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *BulkLoadResponse) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *BulkLoadResponse) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *BulkLoadResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+type IncrementResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         int64                  `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	Epoch         uint64                 `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementResponse) Reset() {
+	*x = IncrementResponse{}
+	mi := &file_kvstore_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementResponse) ProtoMessage() {}
+
+func (x *IncrementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementResponse.ProtoReflect.Descriptor instead.
+func (*IncrementResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *IncrementResponse) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *IncrementResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+type CompareAndSwapResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Swapped       bool                   `protobuf:"varint,1,opt,name=swapped,proto3" json:"swapped,omitempty"`
+	Epoch         uint64                 `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Current       *KeyState              `protobuf:"bytes,3,opt,name=current,proto3" json:"current,omitempty"` // state of the key when the swap failed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompareAndSwapResponse) Reset() {
+	*x = CompareAndSwapResponse{}
+	mi := &file_kvstore_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareAndSwapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareAndSwapResponse) ProtoMessage() {}
+
+func (x *CompareAndSwapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareAndSwapResponse.ProtoReflect.Descriptor instead.
+func (*CompareAndSwapResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CompareAndSwapResponse) GetSwapped() bool {
+	if x != nil {
+		return x.Swapped
+	}
+	return false
+}
+
+func (x *CompareAndSwapResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *CompareAndSwapResponse) GetCurrent() *KeyState {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+type ScanResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kvs           []*KeyValue            `protobuf:"bytes,1,rep,name=kvs,proto3" json:"kvs,omitempty"`
+	Revision      int64                  `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"` // revision the scan read, to pass when asking for the next page
+	More          bool                   `protobuf:"varint,3,opt,name=more,proto3" json:"more,omitempty"`         // the limit left keys out
+	Epoch         uint64                 `protobuf:"varint,4,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScanResponse) Reset() {
+	*x = ScanResponse{}
+	mi := &file_kvstore_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanResponse) ProtoMessage() {}
+
+func (x *ScanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanResponse.ProtoReflect.Descriptor instead.
+func (*ScanResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ScanResponse) GetKvs() []*KeyValue {
+	if x != nil {
+		return x.Kvs
+	}
+	return nil
+}
+
+func (x *ScanResponse) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *ScanResponse) GetMore() bool {
+	if x != nil {
+		return x.More
+	}
+	return false
+}
+
+func (x *ScanResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+type CountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int64                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Epoch         uint64                 `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountResponse) Reset() {
+	*x = CountResponse{}
+	mi := &file_kvstore_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountResponse) ProtoMessage() {}
+
+func (x *CountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountResponse.ProtoReflect.Descriptor instead.
+func (*CountResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CountResponse) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *CountResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+type DeleteRangeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       int64                  `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	Epoch         uint64                 `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRangeResponse) Reset() {
+	*x = DeleteRangeResponse{}
+	mi := &file_kvstore_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRangeResponse) ProtoMessage() {}
+
+func (x *DeleteRangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRangeResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRangeResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DeleteRangeResponse) GetDeleted() int64 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+func (x *DeleteRangeResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+type CompactResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Epoch         uint64                 `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompactResponse) Reset() {
+	*x = CompactResponse{}
+	mi := &file_kvstore_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompactResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompactResponse) ProtoMessage() {}
+
+func (x *CompactResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompactResponse.ProtoReflect.Descriptor instead.
+func (*CompactResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CompactResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+// The changes of one write, or a progress notification: every change up to
+// revision has been sent
+type WatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*KeyValue            `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"` // deleted is set for deletions
+	Revision      int64                  `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	Progress      bool                   `protobuf:"varint,3,opt,name=progress,proto3" json:"progress,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchResponse) Reset() {
+	*x = WatchResponse{}
+	mi := &file_kvstore_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchResponse) ProtoMessage() {}
+
+func (x *WatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchResponse.ProtoReflect.Descriptor instead.
+func (*WatchResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *WatchResponse) GetEvents() []*KeyValue {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *WatchResponse) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *WatchResponse) GetProgress() bool {
+	if x != nil {
+		return x.Progress
+	}
+	return false
+}
+
+// Revision, time and node the leader assigned to a write
+type Stamp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revision      int64                  `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	Time          int64                  `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"` // unix nanoseconds
+	Writer        int32                  `protobuf:"varint,3,opt,name=writer,proto3" json:"writer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Stamp) Reset() {
+	*x = Stamp{}
+	mi := &file_kvstore_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Stamp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stamp) ProtoMessage() {}
+
+func (x *Stamp) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stamp.ProtoReflect.Descriptor instead.
+func (*Stamp) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Stamp) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *Stamp) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *Stamp) GetWriter() int32 {
+	if x != nil {
+		return x.Writer
+	}
+	return 0
+}
+
+type KeyMeta struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CreateRevision int64                  `protobuf:"varint,1,opt,name=create_revision,json=createRevision,proto3" json:"create_revision,omitempty"`
+	ModRevision    int64                  `protobuf:"varint,2,opt,name=mod_revision,json=modRevision,proto3" json:"mod_revision,omitempty"`
+	Version        int64                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	ModTime        int64                  `protobuf:"varint,4,opt,name=mod_time,json=modTime,proto3" json:"mod_time,omitempty"` // unix nanoseconds
+	Writer         int32                  `protobuf:"varint,5,opt,name=writer,proto3" json:"writer,omitempty"`                  // node that accepted the last write
+	Lease          int64                  `protobuf:"varint,6,opt,name=lease,proto3" json:"lease,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *KeyMeta) Reset() {
+	*x = KeyMeta{}
+	mi := &file_kvstore_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyMeta) ProtoMessage() {}
+
+func (x *KeyMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyMeta.ProtoReflect.Descriptor instead.
+func (*KeyMeta) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *KeyMeta) GetCreateRevision() int64 {
+	if x != nil {
+		return x.CreateRevision
+	}
+	return 0
+}
+
+func (x *KeyMeta) GetModRevision() int64 {
+	if x != nil {
+		return x.ModRevision
+	}
+	return 0
+}
+
+func (x *KeyMeta) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *KeyMeta) GetModTime() int64 {
+	if x != nil {
+		return x.ModTime
+	}
+	return 0
+}
+
+func (x *KeyMeta) GetWriter() int32 {
+	if x != nil {
+		return x.Writer
+	}
+	return 0
+}
+
+func (x *KeyMeta) GetLease() int64 {
+	if x != nil {
+		return x.Lease
+	}
+	return 0
+}
+
+type KeyState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Version       int64                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Exists        bool                   `protobuf:"varint,3,opt,name=exists,proto3" json:"exists,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyState) Reset() {
+	*x = KeyState{}
+	mi := &file_kvstore_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyState) ProtoMessage() {}
+
+func (x *KeyState) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyState.ProtoReflect.Descriptor instead.
+func (*KeyState) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *KeyState) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *KeyState) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *KeyState) GetExists() bool {
+	if x != nil {
+		return x.Exists
+	}
+	return false
+}
+
+// A condition of a transaction; target is value, version, exists or create
+// and op one of =, !=, < and >
+type Compare struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Target        string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	Op            string                 `protobuf:"bytes,3,opt,name=op,proto3" json:"op,omitempty"`
+	Value         string                 `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	Version       int64                  `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	Exists        bool                   `protobuf:"varint,6,opt,name=exists,proto3" json:"exists,omitempty"`
+	Revision      int64                  `protobuf:"varint,7,opt,name=revision,proto3" json:"revision,omitempty"` // create: revision that created the key, 0 if missing
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Compare) Reset() {
+	*x = Compare{}
+	mi := &file_kvstore_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Compare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Compare) ProtoMessage() {}
+
+func (x *Compare) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Compare.ProtoReflect.Descriptor instead.
+func (*Compare) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *Compare) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Compare) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *Compare) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *Compare) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Compare) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Compare) GetExists() bool {
+	if x != nil {
+		return x.Exists
+	}
+	return false
+}
+
+func (x *Compare) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+type TxnOp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Delete        bool                   `protobuf:"varint,3,opt,name=delete,proto3" json:"delete,omitempty"`
+	Lease         int64                  `protobuf:"varint,4,opt,name=lease,proto3" json:"lease,omitempty"`
+	IfAbsent      bool                   `protobuf:"varint,5,opt,name=if_absent,json=ifAbsent,proto3" json:"if_absent,omitempty"` // put only when the key does not exist
+	Increment     bool                   `protobuf:"varint,6,opt,name=increment,proto3" json:"increment,omitempty"`               // put the key's integer value plus delta
+	Delta         int64                  `protobuf:"varint,7,opt,name=delta,proto3" json:"delta,omitempty"`
+	Unsigned      bool                   `protobuf:"varint,8,opt,name=unsigned,proto3" json:"unsigned,omitempty"`   // count as memcached, delta holding an unsigned amount
+	Decrement     bool                   `protobuf:"varint,9,opt,name=decrement,proto3" json:"decrement,omitempty"` // an unsigned increment subtracts delta
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TxnOp) Reset() {
+	*x = TxnOp{}
+	mi := &file_kvstore_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxnOp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxnOp) ProtoMessage() {}
+
+func (x *TxnOp) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxnOp.ProtoReflect.Descriptor instead.
+func (*TxnOp) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *TxnOp) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *TxnOp) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *TxnOp) GetDelete() bool {
+	if x != nil {
+		return x.Delete
+	}
+	return false
+}
+
+func (x *TxnOp) GetLease() int64 {
+	if x != nil {
+		return x.Lease
+	}
+	return 0
+}
+
+func (x *TxnOp) GetIfAbsent() bool {
+	if x != nil {
+		return x.IfAbsent
+	}
+	return false
+}
+
+func (x *TxnOp) GetIncrement() bool {
+	if x != nil {
+		return x.Increment
+	}
+	return false
+}
+
+func (x *TxnOp) GetDelta() int64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+func (x *TxnOp) GetUnsigned() bool {
+	if x != nil {
+		return x.Unsigned
+	}
+	return false
+}
+
+func (x *TxnOp) GetDecrement() bool {
+	if x != nil {
+		return x.Decrement
+	}
+	return false
+}
+
+type TxnRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Compare  []*Compare             `protobuf:"bytes,1,rep,name=compare,proto3" json:"compare,omitempty"`
+	Success  []*TxnOp               `protobuf:"bytes,2,rep,name=success,proto3" json:"success,omitempty"` // applied when every compare holds
+	Failure  []*TxnOp               `protobuf:"bytes,3,rep,name=failure,proto3" json:"failure,omitempty"` // applied otherwise
+	ClientId string                 `protobuf:"bytes,4,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Sequence uint64                 `protobuf:"varint,5,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// Internal: set by the leader when replicating the branch it applied,
+	// which is carried in success
+	Resolved      bool   `protobuf:"varint,6,opt,name=resolved,proto3" json:"resolved,omitempty"`
+	Succeeded     bool   `protobuf:"varint,7,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Stamp         *Stamp `protobuf:"bytes,8,opt,name=stamp,proto3" json:"stamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TxnRequest) Reset() {
+	*x = TxnRequest{}
+	mi := &file_kvstore_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxnRequest) ProtoMessage() {}
+
+func (x *TxnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxnRequest.ProtoReflect.Descriptor instead.
+func (*TxnRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *TxnRequest) GetCompare() []*Compare {
+	if x != nil {
+		return x.Compare
+	}
+	return nil
+}
+
+func (x *TxnRequest) GetSuccess() []*TxnOp {
+	if x != nil {
+		return x.Success
+	}
+	return nil
+}
+
+func (x *TxnRequest) GetFailure() []*TxnOp {
+	if x != nil {
+		return x.Failure
+	}
+	return nil
+}
+
+func (x *TxnRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *TxnRequest) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *TxnRequest) GetResolved() bool {
+	if x != nil {
+		return x.Resolved
+	}
+	return false
+}
+
+func (x *TxnRequest) GetSucceeded() bool {
+	if x != nil {
+		return x.Succeeded
+	}
+	return false
+}
+
+func (x *TxnRequest) GetStamp() *Stamp {
+	if x != nil {
+		return x.Stamp
+	}
+	return nil
+}
+
+type TxnResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Succeeded     bool                   `protobuf:"varint,1,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Epoch         uint64                 `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Current       map[string]*KeyState   `protobuf:"bytes,3,rep,name=current,proto3" json:"current,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // compared keys as the leader evaluated them
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TxnResponse) Reset() {
+	*x = TxnResponse{}
+	mi := &file_kvstore_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxnResponse) ProtoMessage() {}
+
+func (x *TxnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxnResponse.ProtoReflect.Descriptor instead.
+func (*TxnResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *TxnResponse) GetSucceeded() bool {
+	if x != nil {
+		return x.Succeeded
+	}
+	return false
+}
+
+func (x *TxnResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *TxnResponse) GetCurrent() map[string]*KeyState {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+type ReplicateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplicateResponse) Reset() {
+	*x = ReplicateResponse{}
+	mi := &file_kvstore_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplicateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicateResponse) ProtoMessage() {}
+
+func (x *ReplicateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicateResponse.ProtoReflect.Descriptor instead.
+func (*ReplicateResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ReplicateResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type Member struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	Status        MemberStatus           `protobuf:"varint,3,opt,name=status,proto3,enum=MemberStatus" json:"status,omitempty"`
+	Incarnation   uint64                 `protobuf:"varint,4,opt,name=incarnation,proto3" json:"incarnation,omitempty"`
+	Shard         int32                  `protobuf:"varint,5,opt,name=shard,proto3" json:"shard,omitempty"` // replica group the member belongs to
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Member) Reset() {
+	*x = Member{}
+	mi := &file_kvstore_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Member) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Member) ProtoMessage() {}
+
+func (x *Member) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Member.ProtoReflect.Descriptor instead.
+func (*Member) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *Member) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Member) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *Member) GetStatus() MemberStatus {
+	if x != nil {
+		return x.Status
+	}
+	return MemberStatus_ALIVE
+}
+
+func (x *Member) GetIncarnation() uint64 {
+	if x != nil {
+		return x.Incarnation
+	}
+	return 0
+}
+
+func (x *Member) GetShard() int32 {
+	if x != nil {
+		return x.Shard
+	}
+	return 0
+}
+
+type PingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *Member                `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	Updates       []*Member              `protobuf:"bytes,2,rep,name=updates,proto3" json:"updates,omitempty"` // piggybacked membership changes
+	Metadata      *ClusterMetadata       `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PingRequest) Reset() {
+	*x = PingRequest{}
+	mi := &file_kvstore_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PingRequest) ProtoMessage() {}
+
+func (x *PingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PingRequest.ProtoReflect.Descriptor instead.
+func (*PingRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *PingRequest) GetFrom() *Member {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *PingRequest) GetUpdates() []*Member {
+	if x != nil {
+		return x.Updates
+	}
+	return nil
+}
+
+func (x *PingRequest) GetMetadata() *ClusterMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type PingReqRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *Member                `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	Target        *Member                `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"` // member to probe on behalf of the sender
+	Updates       []*Member              `protobuf:"bytes,3,rep,name=updates,proto3" json:"updates,omitempty"`
+	Metadata      *ClusterMetadata       `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PingReqRequest) Reset() {
+	*x = PingReqRequest{}
+	mi := &file_kvstore_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PingReqRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PingReqRequest) ProtoMessage() {}
+
+func (x *PingReqRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PingReqRequest.ProtoReflect.Descriptor instead.
+func (*PingReqRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *PingReqRequest) GetFrom() *Member {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *PingReqRequest) GetTarget() *Member {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *PingReqRequest) GetUpdates() []*Member {
+	if x != nil {
+		return x.Updates
+	}
+	return nil
+}
+
+func (x *PingReqRequest) GetMetadata() *ClusterMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type PingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ack           bool                   `protobuf:"varint,1,opt,name=ack,proto3" json:"ack,omitempty"`
+	Updates       []*Member              `protobuf:"bytes,2,rep,name=updates,proto3" json:"updates,omitempty"`
+	Metadata      *ClusterMetadata       `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PingResponse) Reset() {
+	*x = PingResponse{}
+	mi := &file_kvstore_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PingResponse) ProtoMessage() {}
+
+func (x *PingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PingResponse.ProtoReflect.Descriptor instead.
+func (*PingResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *PingResponse) GetAck() bool {
+	if x != nil {
+		return x.Ack
+	}
+	return false
+}
+
+func (x *PingResponse) GetUpdates() []*Member {
+	if x != nil {
+		return x.Updates
+	}
+	return nil
+}
+
+func (x *PingResponse) GetMetadata() *ClusterMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type JoinRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        *Member                `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinRequest) Reset() {
+	*x = JoinRequest{}
+	mi := &file_kvstore_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinRequest) ProtoMessage() {}
+
+func (x *JoinRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinRequest.ProtoReflect.Descriptor instead.
+func (*JoinRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *JoinRequest) GetMember() *Member {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+type JoinResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*Member              `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	Metadata      *ClusterMetadata       `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinResponse) Reset() {
+	*x = JoinResponse{}
+	mi := &file_kvstore_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinResponse) ProtoMessage() {}
+
+func (x *JoinResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinResponse.ProtoReflect.Descriptor instead.
+func (*JoinResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *JoinResponse) GetMembers() []*Member {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *JoinResponse) GetMetadata() *ClusterMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// Sharding
+type ShardGroup struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	LeaderId      int32                  `protobuf:"varint,2,opt,name=leader_id,json=leaderId,proto3" json:"leader_id,omitempty"`
+	LeaderAddr    string                 `protobuf:"bytes,3,opt,name=leader_addr,json=leaderAddr,proto3" json:"leader_addr,omitempty"`
+	Version       uint64                 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`                  // bumped by the group leader when its ranges change
+	Ranges        []*KeyRange            `protobuf:"bytes,5,rep,name=ranges,proto3" json:"ranges,omitempty"`                     // owned ranges under range partitioning
+	RespAddr      string                 `protobuf:"bytes,6,opt,name=resp_addr,json=respAddr,proto3" json:"resp_addr,omitempty"` // leader's Redis protocol listener, empty when it has none
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShardGroup) Reset() {
+	*x = ShardGroup{}
+	mi := &file_kvstore_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShardGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShardGroup) ProtoMessage() {}
+
+func (x *ShardGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShardGroup.ProtoReflect.Descriptor instead.
+func (*ShardGroup) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ShardGroup) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ShardGroup) GetLeaderId() int32 {
+	if x != nil {
+		return x.LeaderId
+	}
+	return 0
+}
+
+func (x *ShardGroup) GetLeaderAddr() string {
+	if x != nil {
+		return x.LeaderAddr
+	}
+	return ""
+}
+
+func (x *ShardGroup) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ShardGroup) GetRanges() []*KeyRange {
+	if x != nil {
+		return x.Ranges
+	}
+	return nil
+}
+
+func (x *ShardGroup) GetRespAddr() string {
+	if x != nil {
+		return x.RespAddr
+	}
+	return ""
+}
+
+// [start, end) with an empty end meaning unbounded
+type KeyRange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         string                 `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End           string                 `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyRange) Reset() {
+	*x = KeyRange{}
+	mi := &file_kvstore_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyRange) ProtoMessage() {}
+
+func (x *KeyRange) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyRange.ProtoReflect.Descriptor instead.
+func (*KeyRange) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *KeyRange) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *KeyRange) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+// Cluster-wide routing table, the newest version wins when gossiped
+type ClusterMetadata struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       uint64                 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	VirtualNodes  int32                  `protobuf:"varint,2,opt,name=virtual_nodes,json=virtualNodes,proto3" json:"virtual_nodes,omitempty"` // points per shard on the hash ring
+	Shards        []*ShardGroup          `protobuf:"bytes,3,rep,name=shards,proto3" json:"shards,omitempty"`
+	Partitioning  string                 `protobuf:"bytes,4,opt,name=partitioning,proto3" json:"partitioning,omitempty"`                   // "hash" (default) or "range"
+	Replication   string                 `protobuf:"bytes,5,opt,name=replication,proto3" json:"replication,omitempty"`                     // "leader" (default), "leaderless" or "chain"
+	Replicas      int32                  `protobuf:"varint,6,opt,name=replicas,proto3" json:"replicas,omitempty"`                          // leaderless: N replicas per key
+	ReadQuorum    int32                  `protobuf:"varint,7,opt,name=read_quorum,json=readQuorum,proto3" json:"read_quorum,omitempty"`    // leaderless: R
+	WriteQuorum   int32                  `protobuf:"varint,8,opt,name=write_quorum,json=writeQuorum,proto3" json:"write_quorum,omitempty"` // leaderless: W
+	ReadOnly      bool                   `protobuf:"varint,9,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`          // standby of a cross-cluster replication link
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClusterMetadata) Reset() {
+	*x = ClusterMetadata{}
+	mi := &file_kvstore_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClusterMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClusterMetadata) ProtoMessage() {}
+
+func (x *ClusterMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClusterMetadata.ProtoReflect.Descriptor instead.
+func (*ClusterMetadata) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ClusterMetadata) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ClusterMetadata) GetVirtualNodes() int32 {
+	if x != nil {
+		return x.VirtualNodes
+	}
+	return 0
+}
+
+func (x *ClusterMetadata) GetShards() []*ShardGroup {
+	if x != nil {
+		return x.Shards
+	}
+	return nil
+}
+
+func (x *ClusterMetadata) GetPartitioning() string {
+	if x != nil {
+		return x.Partitioning
+	}
+	return ""
+}
+
+func (x *ClusterMetadata) GetReplication() string {
+	if x != nil {
+		return x.Replication
+	}
+	return ""
+}
+
+func (x *ClusterMetadata) GetReplicas() int32 {
+	if x != nil {
+		return x.Replicas
+	}
+	return 0
+}
+
+func (x *ClusterMetadata) GetReadQuorum() int32 {
+	if x != nil {
+		return x.ReadQuorum
+	}
+	return 0
+}
+
+func (x *ClusterMetadata) GetWriteQuorum() int32 {
+	if x != nil {
+		return x.WriteQuorum
+	}
+	return 0
+}
+
+func (x *ClusterMetadata) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+// Rebalancing
+type RebalanceRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	BytesPerSecond int64                  `protobuf:"varint,1,opt,name=bytes_per_second,json=bytesPerSecond,proto3" json:"bytes_per_second,omitempty"` // transfer rate limit, 0 for unlimited
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RebalanceRequest) Reset() {
+	*x = RebalanceRequest{}
+	mi := &file_kvstore_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebalanceRequest) ProtoMessage() {}
+
+func (x *RebalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebalanceRequest.ProtoReflect.Descriptor instead.
+func (*RebalanceRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *RebalanceRequest) GetBytesPerSecond() int64 {
+	if x != nil {
+		return x.BytesPerSecond
+	}
+	return 0
+}
+
+type RebalanceStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RebalanceStatusRequest) Reset() {
+	*x = RebalanceStatusRequest{}
+	mi := &file_kvstore_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebalanceStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebalanceStatusRequest) ProtoMessage() {}
+
+func (x *RebalanceStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebalanceStatusRequest.ProtoReflect.Descriptor instead.
+func (*RebalanceStatusRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{45}
+}
+
+type RangeMove struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Range         *KeyRange              `protobuf:"bytes,1,opt,name=range,proto3" json:"range,omitempty"`
+	FromShard     int32                  `protobuf:"varint,2,opt,name=from_shard,json=fromShard,proto3" json:"from_shard,omitempty"`
+	ToShard       int32                  `protobuf:"varint,3,opt,name=to_shard,json=toShard,proto3" json:"to_shard,omitempty"`
+	State         string                 `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"` // pending, running, done or failed
+	Keys          int64                  `protobuf:"varint,5,opt,name=keys,proto3" json:"keys,omitempty"`
+	Bytes         int64                  `protobuf:"varint,6,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RangeMove) Reset() {
+	*x = RangeMove{}
+	mi := &file_kvstore_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RangeMove) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RangeMove) ProtoMessage() {}
+
+func (x *RangeMove) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RangeMove.ProtoReflect.Descriptor instead.
+func (*RangeMove) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *RangeMove) GetRange() *KeyRange {
+	if x != nil {
+		return x.Range
+	}
+	return nil
+}
+
+func (x *RangeMove) GetFromShard() int32 {
+	if x != nil {
+		return x.FromShard
+	}
+	return 0
+}
+
+func (x *RangeMove) GetToShard() int32 {
+	if x != nil {
+		return x.ToShard
+	}
+	return 0
+}
+
+func (x *RangeMove) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *RangeMove) GetKeys() int64 {
+	if x != nil {
+		return x.Keys
+	}
+	return 0
+}
+
+func (x *RangeMove) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *RangeMove) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type RebalanceStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Moves         []*RangeMove           `protobuf:"bytes,1,rep,name=moves,proto3" json:"moves,omitempty"`
+	Running       bool                   `protobuf:"varint,2,opt,name=running,proto3" json:"running,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RebalanceStatus) Reset() {
+	*x = RebalanceStatus{}
+	mi := &file_kvstore_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebalanceStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebalanceStatus) ProtoMessage() {}
+
+func (x *RebalanceStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebalanceStatus.ProtoReflect.Descriptor instead.
+func (*RebalanceStatus) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *RebalanceStatus) GetMoves() []*RangeMove {
+	if x != nil {
+		return x.Moves
+	}
+	return nil
+}
+
+func (x *RebalanceStatus) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+type MoveRangeRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Range          *KeyRange              `protobuf:"bytes,1,opt,name=range,proto3" json:"range,omitempty"`
+	ToShard        int32                  `protobuf:"varint,2,opt,name=to_shard,json=toShard,proto3" json:"to_shard,omitempty"`
+	ToAddr         string                 `protobuf:"bytes,3,opt,name=to_addr,json=toAddr,proto3" json:"to_addr,omitempty"`
+	BytesPerSecond int64                  `protobuf:"varint,4,opt,name=bytes_per_second,json=bytesPerSecond,proto3" json:"bytes_per_second,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MoveRangeRequest) Reset() {
+	*x = MoveRangeRequest{}
+	mi := &file_kvstore_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveRangeRequest) ProtoMessage() {}
+
+func (x *MoveRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveRangeRequest.ProtoReflect.Descriptor instead.
+func (*MoveRangeRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *MoveRangeRequest) GetRange() *KeyRange {
+	if x != nil {
+		return x.Range
+	}
+	return nil
+}
+
+func (x *MoveRangeRequest) GetToShard() int32 {
+	if x != nil {
+		return x.ToShard
+	}
+	return 0
+}
+
+func (x *MoveRangeRequest) GetToAddr() string {
+	if x != nil {
+		return x.ToAddr
+	}
+	return ""
+}
+
+func (x *MoveRangeRequest) GetBytesPerSecond() int64 {
+	if x != nil {
+		return x.BytesPerSecond
+	}
+	return 0
+}
+
+type MoveRangeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          int64                  `protobuf:"varint,1,opt,name=keys,proto3" json:"keys,omitempty"`
+	Bytes         int64                  `protobuf:"varint,2,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveRangeResponse) Reset() {
+	*x = MoveRangeResponse{}
+	mi := &file_kvstore_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveRangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveRangeResponse) ProtoMessage() {}
+
+func (x *MoveRangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveRangeResponse.ProtoReflect.Descriptor instead.
+func (*MoveRangeResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *MoveRangeResponse) GetKeys() int64 {
+	if x != nil {
+		return x.Keys
+	}
+	return 0
+}
+
+func (x *MoveRangeResponse) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+type KeyValue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Deleted       bool                   `protobuf:"varint,3,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	Meta          *KeyMeta               `protobuf:"bytes,4,opt,name=meta,proto3" json:"meta,omitempty"` // scans and watches
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyValue) Reset() {
+	*x = KeyValue{}
+	mi := &file_kvstore_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyValue) ProtoMessage() {}
+
+func (x *KeyValue) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyValue.ProtoReflect.Descriptor instead.
+func (*KeyValue) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *KeyValue) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *KeyValue) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *KeyValue) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *KeyValue) GetMeta() *KeyMeta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+type RangeChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Range         *KeyRange              `protobuf:"bytes,1,opt,name=range,proto3" json:"range,omitempty"`
+	Commit        bool                   `protobuf:"varint,3,opt,name=commit,proto3" json:"commit,omitempty"` // last chunk: the target takes ownership of the range
+	Records       []*KeyRecord           `protobuf:"bytes,4,rep,name=records,proto3" json:"records,omitempty"`
+	LeaseTtlMs    map[int64]int64        `protobuf:"bytes,5,rep,name=lease_ttl_ms,json=leaseTtlMs,proto3" json:"lease_ttl_ms,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // leases of the records, by the time they have left
+	Stamp         *Stamp                 `protobuf:"bytes,6,opt,name=stamp,proto3" json:"stamp,omitempty"`                                                                                                           // internal: set by the leader when replicating
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RangeChunk) Reset() {
+	*x = RangeChunk{}
+	mi := &file_kvstore_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RangeChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RangeChunk) ProtoMessage() {}
+
+func (x *RangeChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RangeChunk.ProtoReflect.Descriptor instead.
+func (*RangeChunk) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *RangeChunk) GetRange() *KeyRange {
+	if x != nil {
+		return x.Range
+	}
+	return nil
+}
+
+func (x *RangeChunk) GetCommit() bool {
+	if x != nil {
+		return x.Commit
+	}
+	return false
+}
+
+func (x *RangeChunk) GetRecords() []*KeyRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *RangeChunk) GetLeaseTtlMs() map[int64]int64 {
+	if x != nil {
+		return x.LeaseTtlMs
+	}
+	return nil
+}
+
+func (x *RangeChunk) GetStamp() *Stamp {
+	if x != nil {
+		return x.Stamp
+	}
+	return nil
+}
+
+// Whole state of a key moved between groups; neither history nor a
+// collection removes the key
+type KeyRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	History       []*KeyVersion          `protobuf:"bytes,2,rep,name=history,proto3" json:"history,omitempty"` // oldest first
+	Collection    *CollectionValue       `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyRecord) Reset() {
+	*x = KeyRecord{}
+	mi := &file_kvstore_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyRecord) ProtoMessage() {}
+
+func (x *KeyRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyRecord.ProtoReflect.Descriptor instead.
+func (*KeyRecord) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *KeyRecord) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *KeyRecord) GetHistory() []*KeyVersion {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *KeyRecord) GetCollection() *CollectionValue {
+	if x != nil {
+		return x.Collection
+	}
+	return nil
+}
+
+type KeyVersion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Meta          *KeyMeta               `protobuf:"bytes,2,opt,name=meta,proto3" json:"meta,omitempty"`
+	Deleted       bool                   `protobuf:"varint,3,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyVersion) Reset() {
+	*x = KeyVersion{}
+	mi := &file_kvstore_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyVersion) ProtoMessage() {}
+
+func (x *KeyVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyVersion.ProtoReflect.Descriptor instead.
+func (*KeyVersion) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *KeyVersion) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *KeyVersion) GetMeta() *KeyMeta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *KeyVersion) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+// A collection: list elements in order, set members, hash fields with their
+// values or sorted set members with their scores
+type CollectionValue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Elements      []string               `protobuf:"bytes,2,rep,name=elements,proto3" json:"elements,omitempty"`
+	Values        []string               `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+	Scores        []float64              `protobuf:"fixed64,4,rep,packed,name=scores,proto3" json:"scores,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectionValue) Reset() {
+	*x = CollectionValue{}
+	mi := &file_kvstore_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectionValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectionValue) ProtoMessage() {}
+
+func (x *CollectionValue) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectionValue.ProtoReflect.Descriptor instead.
+func (*CollectionValue) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *CollectionValue) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CollectionValue) GetElements() []string {
+	if x != nil {
+		return x.Elements
+	}
+	return nil
+}
+
+func (x *CollectionValue) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *CollectionValue) GetScores() []float64 {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+type InstallRangeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Group         *ShardGroup            `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"` // target group after adopting the range
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstallRangeResponse) Reset() {
+	*x = InstallRangeResponse{}
+	mi := &file_kvstore_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstallRangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstallRangeResponse) ProtoMessage() {}
+
+func (x *InstallRangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstallRangeResponse.ProtoReflect.Descriptor instead.
+func (*InstallRangeResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *InstallRangeResponse) GetGroup() *ShardGroup {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+// Leaderless replication
+type VectorClock struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Counters      map[int32]uint64       `protobuf:"bytes,1,rep,name=counters,proto3" json:"counters,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorClock) Reset() {
+	*x = VectorClock{}
+	mi := &file_kvstore_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorClock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorClock) ProtoMessage() {}
+
+func (x *VectorClock) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorClock.ProtoReflect.Descriptor instead.
+func (*VectorClock) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *VectorClock) GetCounters() map[int32]uint64 {
+	if x != nil {
+		return x.Counters
+	}
+	return nil
+}
+
+type Sibling struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Deleted       bool                   `protobuf:"varint,2,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	Clock         *VectorClock           `protobuf:"bytes,3,opt,name=clock,proto3" json:"clock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Sibling) Reset() {
+	*x = Sibling{}
+	mi := &file_kvstore_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Sibling) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sibling) ProtoMessage() {}
+
+func (x *Sibling) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sibling.ProtoReflect.Descriptor instead.
+func (*Sibling) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *Sibling) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Sibling) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *Sibling) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+type StoreRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Siblings      []*Sibling             `protobuf:"bytes,2,rep,name=siblings,proto3" json:"siblings,omitempty"`
+	HintFor       int32                  `protobuf:"varint,3,opt,name=hint_for,json=hintFor,proto3" json:"hint_for,omitempty"` // set when stored on behalf of an unreachable replica
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoreRequest) Reset() {
+	*x = StoreRequest{}
+	mi := &file_kvstore_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreRequest) ProtoMessage() {}
+
+func (x *StoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreRequest.ProtoReflect.Descriptor instead.
+func (*StoreRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *StoreRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *StoreRequest) GetSiblings() []*Sibling {
+	if x != nil {
+		return x.Siblings
+	}
+	return nil
+}
+
+func (x *StoreRequest) GetHintFor() int32 {
+	if x != nil {
+		return x.HintFor
+	}
+	return 0
+}
+
+type StoreResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoreResponse) Reset() {
+	*x = StoreResponse{}
+	mi := &file_kvstore_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreResponse) ProtoMessage() {}
+
+func (x *StoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreResponse.ProtoReflect.Descriptor instead.
+func (*StoreResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{59}
+}
+
+type FetchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchRequest) Reset() {
+	*x = FetchRequest{}
+	mi := &file_kvstore_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchRequest) ProtoMessage() {}
+
+func (x *FetchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchRequest.ProtoReflect.Descriptor instead.
+func (*FetchRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *FetchRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type FetchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Siblings      []*Sibling             `protobuf:"bytes,1,rep,name=siblings,proto3" json:"siblings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchResponse) Reset() {
+	*x = FetchResponse{}
+	mi := &file_kvstore_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchResponse) ProtoMessage() {}
+
+func (x *FetchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchResponse.ProtoReflect.Descriptor instead.
+func (*FetchResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *FetchResponse) GetSiblings() []*Sibling {
+	if x != nil {
+		return x.Siblings
+	}
+	return nil
+}
+
+// CRDTs
+type CounterIncrementRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Delta         int64                  `protobuf:"varint,2,opt,name=delta,proto3" json:"delta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CounterIncrementRequest) Reset() {
+	*x = CounterIncrementRequest{}
+	mi := &file_kvstore_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CounterIncrementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CounterIncrementRequest) ProtoMessage() {}
+
+func (x *CounterIncrementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CounterIncrementRequest.ProtoReflect.Descriptor instead.
+func (*CounterIncrementRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *CounterIncrementRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *CounterIncrementRequest) GetDelta() int64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+type SetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Members       []string               `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRequest) Reset() {
+	*x = SetRequest{}
+	mi := &file_kvstore_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRequest) ProtoMessage() {}
+
+func (x *SetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRequest.ProtoReflect.Descriptor instead.
+func (*SetRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *SetRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SetRequest) GetMembers() []string {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type RegisterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterRequest) Reset() {
+	*x = RegisterRequest{}
+	mi := &file_kvstore_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterRequest) ProtoMessage() {}
+
+func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterRequest.ProtoReflect.Descriptor instead.
+func (*RegisterRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *RegisterRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type MapRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Field         string                 `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MapRequest) Reset() {
+	*x = MapRequest{}
+	mi := &file_kvstore_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapRequest) ProtoMessage() {}
+
+func (x *MapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapRequest.ProtoReflect.Descriptor instead.
+func (*MapRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *MapRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *MapRequest) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *MapRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type CRDTReadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CRDTReadRequest) Reset() {
+	*x = CRDTReadRequest{}
+	mi := &file_kvstore_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CRDTReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CRDTReadRequest) ProtoMessage() {}
+
+func (x *CRDTReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CRDTReadRequest.ProtoReflect.Descriptor instead.
+func (*CRDTReadRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *CRDTReadRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+// Merged state of a CRDT, only the field matching type is set
+type CRDTValue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // counter, set, register or map; empty if the key is unset
+	Counter       int64                  `protobuf:"varint,2,opt,name=counter,proto3" json:"counter,omitempty"`
+	Members       []string               `protobuf:"bytes,3,rep,name=members,proto3" json:"members,omitempty"`
+	Register      string                 `protobuf:"bytes,4,opt,name=register,proto3" json:"register,omitempty"`
+	Entries       map[string]string      `protobuf:"bytes,5,rep,name=entries,proto3" json:"entries,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CRDTValue) Reset() {
+	*x = CRDTValue{}
+	mi := &file_kvstore_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CRDTValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CRDTValue) ProtoMessage() {}
+
+func (x *CRDTValue) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CRDTValue.ProtoReflect.Descriptor instead.
+func (*CRDTValue) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *CRDTValue) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CRDTValue) GetCounter() int64 {
+	if x != nil {
+		return x.Counter
+	}
+	return 0
+}
+
+func (x *CRDTValue) GetMembers() []string {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *CRDTValue) GetRegister() string {
+	if x != nil {
+		return x.Register
+	}
+	return ""
+}
+
+func (x *CRDTValue) GetEntries() map[string]string {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type CRDTState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	State         []byte                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"` // JSON encoded full state
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CRDTState) Reset() {
+	*x = CRDTState{}
+	mi := &file_kvstore_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CRDTState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CRDTState) ProtoMessage() {}
+
+func (x *CRDTState) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CRDTState.ProtoReflect.Descriptor instead.
+func (*CRDTState) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *CRDTState) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *CRDTState) GetState() []byte {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+type CRDTStates struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	States        []*CRDTState           `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CRDTStates) Reset() {
+	*x = CRDTStates{}
+	mi := &file_kvstore_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CRDTStates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CRDTStates) ProtoMessage() {}
+
+func (x *CRDTStates) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CRDTStates.ProtoReflect.Descriptor instead.
+func (*CRDTStates) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *CRDTStates) GetStates() []*CRDTState {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+// Chain replication
+type ChainWrite struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Op            string                 `protobuf:"bytes,1,opt,name=op,proto3" json:"op,omitempty"` // put, delete or txn
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	ClientId      string                 `protobuf:"bytes,4,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Sequence      uint64                 `protobuf:"varint,5,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Version       uint64                 `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`     // assigned by the head, orders writes to the same key
+	Ops           []*TxnOp               `protobuf:"bytes,7,rep,name=ops,proto3" json:"ops,omitempty"`              // txn: the writes of the branch the head applied
+	Succeeded     bool                   `protobuf:"varint,8,opt,name=succeeded,proto3" json:"succeeded,omitempty"` // txn: whether that was the success branch
+	Stamp         *Stamp                 `protobuf:"bytes,9,opt,name=stamp,proto3" json:"stamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChainWrite) Reset() {
+	*x = ChainWrite{}
+	mi := &file_kvstore_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChainWrite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChainWrite) ProtoMessage() {}
+
+func (x *ChainWrite) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChainWrite.ProtoReflect.Descriptor instead.
+func (*ChainWrite) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *ChainWrite) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *ChainWrite) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ChainWrite) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *ChainWrite) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *ChainWrite) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *ChainWrite) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ChainWrite) GetOps() []*TxnOp {
+	if x != nil {
+		return x.Ops
+	}
+	return nil
+}
+
+func (x *ChainWrite) GetSucceeded() bool {
+	if x != nil {
+		return x.Succeeded
+	}
+	return false
+}
+
+func (x *ChainWrite) GetStamp() *Stamp {
+	if x != nil {
+		return x.Stamp
+	}
+	return nil
+}
+
+type ChainAck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChainAck) Reset() {
+	*x = ChainAck{}
+	mi := &file_kvstore_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChainAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChainAck) ProtoMessage() {}
+
+func (x *ChainAck) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChainAck.ProtoReflect.Descriptor instead.
+func (*ChainAck) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{71}
+}
+
+// Leases
+type LeaseGrantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"` // 0 to have one assigned
+	TtlMs         int64                  `protobuf:"varint,2,opt,name=ttl_ms,json=ttlMs,proto3" json:"ttl_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseGrantRequest) Reset() {
+	*x = LeaseGrantRequest{}
+	mi := &file_kvstore_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseGrantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseGrantRequest) ProtoMessage() {}
+
+func (x *LeaseGrantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseGrantRequest.ProtoReflect.Descriptor instead.
+func (*LeaseGrantRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *LeaseGrantRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *LeaseGrantRequest) GetTtlMs() int64 {
+	if x != nil {
+		return x.TtlMs
+	}
+	return 0
+}
+
+type LeaseGrantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TtlMs         int64                  `protobuf:"varint,2,opt,name=ttl_ms,json=ttlMs,proto3" json:"ttl_ms,omitempty"`
+	Epoch         uint64                 `protobuf:"varint,3,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseGrantResponse) Reset() {
+	*x = LeaseGrantResponse{}
+	mi := &file_kvstore_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseGrantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseGrantResponse) ProtoMessage() {}
+
+func (x *LeaseGrantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseGrantResponse.ProtoReflect.Descriptor instead.
+func (*LeaseGrantResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *LeaseGrantResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *LeaseGrantResponse) GetTtlMs() int64 {
+	if x != nil {
+		return x.TtlMs
+	}
+	return 0
+}
+
+func (x *LeaseGrantResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+type LeaseRevokeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Stamp         *Stamp                 `protobuf:"bytes,2,opt,name=stamp,proto3" json:"stamp,omitempty"` // internal: set by the leader when replicating
+	Keys          []string               `protobuf:"bytes,3,rep,name=keys,proto3" json:"keys,omitempty"`   // internal: the keys the leader deleted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseRevokeRequest) Reset() {
+	*x = LeaseRevokeRequest{}
+	mi := &file_kvstore_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseRevokeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseRevokeRequest) ProtoMessage() {}
+
+func (x *LeaseRevokeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseRevokeRequest.ProtoReflect.Descriptor instead.
+func (*LeaseRevokeRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *LeaseRevokeRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *LeaseRevokeRequest) GetStamp() *Stamp {
+	if x != nil {
+		return x.Stamp
+	}
+	return nil
+}
+
+func (x *LeaseRevokeRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type LeaseRevokeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Epoch         uint64                 `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseRevokeResponse) Reset() {
+	*x = LeaseRevokeResponse{}
+	mi := &file_kvstore_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseRevokeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseRevokeResponse) ProtoMessage() {}
+
+func (x *LeaseRevokeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseRevokeResponse.ProtoReflect.Descriptor instead.
+func (*LeaseRevokeResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *LeaseRevokeResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+type LeaseKeepAliveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseKeepAliveRequest) Reset() {
+	*x = LeaseKeepAliveRequest{}
+	mi := &file_kvstore_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseKeepAliveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseKeepAliveRequest) ProtoMessage() {}
+
+func (x *LeaseKeepAliveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseKeepAliveRequest.ProtoReflect.Descriptor instead.
+func (*LeaseKeepAliveRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *LeaseKeepAliveRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type LeaseKeepAliveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TtlMs         int64                  `protobuf:"varint,2,opt,name=ttl_ms,json=ttlMs,proto3" json:"ttl_ms,omitempty"` // 0 when the lease does not exist anymore
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseKeepAliveResponse) Reset() {
+	*x = LeaseKeepAliveResponse{}
+	mi := &file_kvstore_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseKeepAliveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseKeepAliveResponse) ProtoMessage() {}
+
+func (x *LeaseKeepAliveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseKeepAliveResponse.ProtoReflect.Descriptor instead.
+func (*LeaseKeepAliveResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *LeaseKeepAliveResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *LeaseKeepAliveResponse) GetTtlMs() int64 {
+	if x != nil {
+		return x.TtlMs
+	}
+	return 0
+}
+
+type LeaseTimeToLiveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseTimeToLiveRequest) Reset() {
+	*x = LeaseTimeToLiveRequest{}
+	mi := &file_kvstore_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseTimeToLiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseTimeToLiveRequest) ProtoMessage() {}
+
+func (x *LeaseTimeToLiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseTimeToLiveRequest.ProtoReflect.Descriptor instead.
+func (*LeaseTimeToLiveRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *LeaseTimeToLiveRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type LeaseTimeToLiveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TtlMs         int64                  `protobuf:"varint,2,opt,name=ttl_ms,json=ttlMs,proto3" json:"ttl_ms,omitempty"`
+	RemainingMs   int64                  `protobuf:"varint,3,opt,name=remaining_ms,json=remainingMs,proto3" json:"remaining_ms,omitempty"`
+	Keys          []string               `protobuf:"bytes,4,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseTimeToLiveResponse) Reset() {
+	*x = LeaseTimeToLiveResponse{}
+	mi := &file_kvstore_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseTimeToLiveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseTimeToLiveResponse) ProtoMessage() {}
+
+func (x *LeaseTimeToLiveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseTimeToLiveResponse.ProtoReflect.Descriptor instead.
+func (*LeaseTimeToLiveResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *LeaseTimeToLiveResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *LeaseTimeToLiveResponse) GetTtlMs() int64 {
+	if x != nil {
+		return x.TtlMs
+	}
+	return 0
+}
+
+func (x *LeaseTimeToLiveResponse) GetRemainingMs() int64 {
+	if x != nil {
+		return x.RemainingMs
+	}
+	return 0
+}
+
+func (x *LeaseTimeToLiveResponse) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+// Locks
+type LockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Lease         int64                  `protobuf:"varint,2,opt,name=lease,proto3" json:"lease,omitempty"` // the lock is released when the lease lapses
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LockRequest) Reset() {
+	*x = LockRequest{}
+	mi := &file_kvstore_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LockRequest) ProtoMessage() {}
+
+func (x *LockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LockRequest.ProtoReflect.Descriptor instead.
+func (*LockRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *LockRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LockRequest) GetLease() int64 {
+	if x != nil {
+		return x.Lease
+	}
+	return 0
+}
+
+type LockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`                                        // key holding the lock, to pass to Unlock
+	FencingToken  int64                  `protobuf:"varint,2,opt,name=fencing_token,json=fencingToken,proto3" json:"fencing_token,omitempty"` // grows with every acquisition of the lock
+	Epoch         uint64                 `protobuf:"varint,3,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LockResponse) Reset() {
+	*x = LockResponse{}
+	mi := &file_kvstore_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LockResponse) ProtoMessage() {}
+
+func (x *LockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LockResponse.ProtoReflect.Descriptor instead.
+func (*LockResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *LockResponse) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *LockResponse) GetFencingToken() int64 {
+	if x != nil {
+		return x.FencingToken
+	}
+	return 0
+}
+
+func (x *LockResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+type UnlockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlockRequest) Reset() {
+	*x = UnlockRequest{}
+	mi := &file_kvstore_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockRequest) ProtoMessage() {}
+
+func (x *UnlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockRequest.ProtoReflect.Descriptor instead.
+func (*UnlockRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *UnlockRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type UnlockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Epoch         uint64                 `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlockResponse) Reset() {
+	*x = UnlockResponse{}
+	mi := &file_kvstore_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockResponse) ProtoMessage() {}
+
+func (x *UnlockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockResponse.ProtoReflect.Descriptor instead.
+func (*UnlockResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *UnlockResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+// Elections
+type LeaderKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`            // key the leader campaigned with
+	Revision      int64                  `protobuf:"varint,3,opt,name=revision,proto3" json:"revision,omitempty"` // revision that created the key
+	Lease         int64                  `protobuf:"varint,4,opt,name=lease,proto3" json:"lease,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderKey) Reset() {
+	*x = LeaderKey{}
+	mi := &file_kvstore_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderKey) ProtoMessage() {}
+
+func (x *LeaderKey) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderKey.ProtoReflect.Descriptor instead.
+func (*LeaderKey) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *LeaderKey) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LeaderKey) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *LeaderKey) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *LeaderKey) GetLease() int64 {
+	if x != nil {
+		return x.Lease
+	}
+	return 0
+}
+
+type CampaignRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Lease         int64                  `protobuf:"varint,2,opt,name=lease,proto3" json:"lease,omitempty"`
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CampaignRequest) Reset() {
+	*x = CampaignRequest{}
+	mi := &file_kvstore_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CampaignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignRequest) ProtoMessage() {}
+
+func (x *CampaignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignRequest.ProtoReflect.Descriptor instead.
+func (*CampaignRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *CampaignRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CampaignRequest) GetLease() int64 {
+	if x != nil {
+		return x.Lease
+	}
+	return 0
+}
+
+func (x *CampaignRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type CampaignResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Leader        *LeaderKey             `protobuf:"bytes,1,opt,name=leader,proto3" json:"leader,omitempty"`
+	Epoch         uint64                 `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CampaignResponse) Reset() {
+	*x = CampaignResponse{}
+	mi := &file_kvstore_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CampaignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignResponse) ProtoMessage() {}
+
+func (x *CampaignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignResponse.ProtoReflect.Descriptor instead.
+func (*CampaignResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *CampaignResponse) GetLeader() *LeaderKey {
+	if x != nil {
+		return x.Leader
+	}
+	return nil
+}
+
+func (x *CampaignResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+type ProclaimRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Leader        *LeaderKey             `protobuf:"bytes,1,opt,name=leader,proto3" json:"leader,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProclaimRequest) Reset() {
+	*x = ProclaimRequest{}
+	mi := &file_kvstore_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProclaimRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProclaimRequest) ProtoMessage() {}
+
+func (x *ProclaimRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProclaimRequest.ProtoReflect.Descriptor instead.
+func (*ProclaimRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *ProclaimRequest) GetLeader() *LeaderKey {
+	if x != nil {
+		return x.Leader
+	}
+	return nil
+}
+
+func (x *ProclaimRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type ProclaimResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Epoch         uint64                 `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProclaimResponse) Reset() {
+	*x = ProclaimResponse{}
+	mi := &file_kvstore_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProclaimResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProclaimResponse) ProtoMessage() {}
+
+func (x *ProclaimResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProclaimResponse.ProtoReflect.Descriptor instead.
+func (*ProclaimResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *ProclaimResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+type ResignRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Leader        *LeaderKey             `protobuf:"bytes,1,opt,name=leader,proto3" json:"leader,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResignRequest) Reset() {
+	*x = ResignRequest{}
+	mi := &file_kvstore_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResignRequest) ProtoMessage() {}
+
+func (x *ResignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResignRequest.ProtoReflect.Descriptor instead.
+func (*ResignRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *ResignRequest) GetLeader() *LeaderKey {
+	if x != nil {
+		return x.Leader
+	}
+	return nil
+}
+
+type ResignResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Epoch         uint64                 `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResignResponse) Reset() {
+	*x = ResignResponse{}
+	mi := &file_kvstore_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResignResponse) ProtoMessage() {}
+
+func (x *ResignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResignResponse.ProtoReflect.Descriptor instead.
+func (*ResignResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *ResignResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+type LeaderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderRequest) Reset() {
+	*x = LeaderRequest{}
+	mi := &file_kvstore_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderRequest) ProtoMessage() {}
+
+func (x *LeaderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderRequest.ProtoReflect.Descriptor instead.
+func (*LeaderRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *LeaderRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type LeaderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Leader        *LeaderKey             `protobuf:"bytes,1,opt,name=leader,proto3" json:"leader,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Epoch         uint64                 `protobuf:"varint,3,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderResponse) Reset() {
+	*x = LeaderResponse{}
+	mi := &file_kvstore_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderResponse) ProtoMessage() {}
+
+func (x *LeaderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderResponse.ProtoReflect.Descriptor instead.
+func (*LeaderResponse) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *LeaderResponse) GetLeader() *LeaderKey {
+	if x != nil {
+		return x.Leader
+	}
+	return nil
+}
+
+func (x *LeaderResponse) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *LeaderResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+// Collections
+type ListPushRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Values        []string               `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	Left          bool                   `protobuf:"varint,3,opt,name=left,proto3" json:"left,omitempty"` // push to the head instead of the tail
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPushRequest) Reset() {
+	*x = ListPushRequest{}
+	mi := &file_kvstore_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPushRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPushRequest) ProtoMessage() {}
+
+func (x *ListPushRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPushRequest.ProtoReflect.Descriptor instead.
+func (*ListPushRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *ListPushRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ListPushRequest) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *ListPushRequest) GetLeft() bool {
+	if x != nil {
+		return x.Left
+	}
+	return false
+}
+
+type ListPopRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Left          bool                   `protobuf:"varint,2,opt,name=left,proto3" json:"left,omitempty"`   // pop from the head instead of the tail
+	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"` // 0 for 1
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPopRequest) Reset() {
+	*x = ListPopRequest{}
+	mi := &file_kvstore_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPopRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPopRequest) ProtoMessage() {}
+
+func (x *ListPopRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPopRequest.ProtoReflect.Descriptor instead.
+func (*ListPopRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *ListPopRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ListPopRequest) GetLeft() bool {
+	if x != nil {
+		return x.Left
+	}
+	return false
+}
+
+func (x *ListPopRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// Elements start to stop, both included; negative indexes count from the end
+type ListRangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Start         int64                  `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	Stop          int64                  `protobuf:"varint,3,opt,name=stop,proto3" json:"stop,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRangeRequest) Reset() {
+	*x = ListRangeRequest{}
+	mi := &file_kvstore_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRangeRequest) ProtoMessage() {}
+
+func (x *ListRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRangeRequest.ProtoReflect.Descriptor instead.
+func (*ListRangeRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *ListRangeRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ListRangeRequest) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *ListRangeRequest) GetStop() int64 {
+	if x != nil {
+		return x.Stop
+	}
+	return 0
+}
+
+type MembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Members       []string               `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MembersRequest) Reset() {
+	*x = MembersRequest{}
+	mi := &file_kvstore_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MembersRequest) ProtoMessage() {}
+
+func (x *MembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MembersRequest.ProtoReflect.Descriptor instead.
+func (*MembersRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *MembersRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *MembersRequest) GetMembers() []string {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type CollectionKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectionKeyRequest) Reset() {
+	*x = CollectionKeyRequest{}
+	mi := &file_kvstore_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectionKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectionKeyRequest) ProtoMessage() {}
+
+func (x *CollectionKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectionKeyRequest.ProtoReflect.Descriptor instead.
+func (*CollectionKeyRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *CollectionKeyRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type SetIntersectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []string               `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetIntersectRequest) Reset() {
+	*x = SetIntersectRequest{}
+	mi := &file_kvstore_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetIntersectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIntersectRequest) ProtoMessage() {}
+
+func (x *SetIntersectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIntersectRequest.ProtoReflect.Descriptor instead.
+func (*SetIntersectRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *SetIntersectRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type HashSetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Fields        map[string]string      `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HashSetRequest) Reset() {
+	*x = HashSetRequest{}
+	mi := &file_kvstore_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HashSetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HashSetRequest) ProtoMessage() {}
+
+func (x *HashSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HashSetRequest.ProtoReflect.Descriptor instead.
+func (*HashSetRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *HashSetRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *HashSetRequest) GetFields() map[string]string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type HashFieldsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Fields        []string               `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HashFieldsRequest) Reset() {
+	*x = HashFieldsRequest{}
+	mi := &file_kvstore_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HashFieldsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HashFieldsRequest) ProtoMessage() {}
+
+func (x *HashFieldsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HashFieldsRequest.ProtoReflect.Descriptor instead.
+func (*HashFieldsRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *HashFieldsRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *HashFieldsRequest) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type ScoredMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        string                 `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScoredMember) Reset() {
+	*x = ScoredMember{}
+	mi := &file_kvstore_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScoredMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoredMember) ProtoMessage() {}
+
+func (x *ScoredMember) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoredMember.ProtoReflect.Descriptor instead.
+func (*ScoredMember) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *ScoredMember) GetMember() string {
+	if x != nil {
+		return x.Member
+	}
+	return ""
+}
+
+func (x *ScoredMember) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type ZSetAddRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Members       []*ScoredMember        `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ZSetAddRequest) Reset() {
+	*x = ZSetAddRequest{}
+	mi := &file_kvstore_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ZSetAddRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZSetAddRequest) ProtoMessage() {}
+
+func (x *ZSetAddRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZSetAddRequest.ProtoReflect.Descriptor instead.
+func (*ZSetAddRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *ZSetAddRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ZSetAddRequest) GetMembers() []*ScoredMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+// Members scored from min to max, both included, by score
+type ZSetRangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Min           float64                `protobuf:"fixed64,2,opt,name=min,proto3" json:"min,omitempty"`
+	Max           float64                `protobuf:"fixed64,3,opt,name=max,proto3" json:"max,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ZSetRangeRequest) Reset() {
+	*x = ZSetRangeRequest{}
+	mi := &file_kvstore_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ZSetRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZSetRangeRequest) ProtoMessage() {}
+
+func (x *ZSetRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZSetRangeRequest.ProtoReflect.Descriptor instead.
+func (*ZSetRangeRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *ZSetRangeRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ZSetRangeRequest) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *ZSetRangeRequest) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+type ZSetRankRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Member        string                 `protobuf:"bytes,2,opt,name=member,proto3" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ZSetRankRequest) Reset() {
+	*x = ZSetRankRequest{}
+	mi := &file_kvstore_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ZSetRankRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZSetRankRequest) ProtoMessage() {}
+
+func (x *ZSetRankRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZSetRankRequest.ProtoReflect.Descriptor instead.
+func (*ZSetRankRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *ZSetRankRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ZSetRankRequest) GetMember() string {
+	if x != nil {
+		return x.Member
+	}
+	return ""
+}
+
+type CollectionOp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cmd           string                 `protobuf:"bytes,1,opt,name=cmd,proto3" json:"cmd,omitempty"`
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Keys          []string               `protobuf:"bytes,3,rep,name=keys,proto3" json:"keys,omitempty"`
+	Members       []string               `protobuf:"bytes,4,rep,name=members,proto3" json:"members,omitempty"`
+	Values        []string               `protobuf:"bytes,5,rep,name=values,proto3" json:"values,omitempty"`
+	Scores        []float64              `protobuf:"fixed64,6,rep,packed,name=scores,proto3" json:"scores,omitempty"`
+	Start         int64                  `protobuf:"varint,7,opt,name=start,proto3" json:"start,omitempty"`
+	Stop          int64                  `protobuf:"varint,8,opt,name=stop,proto3" json:"stop,omitempty"`
+	Min           float64                `protobuf:"fixed64,9,opt,name=min,proto3" json:"min,omitempty"`
+	Max           float64                `protobuf:"fixed64,10,opt,name=max,proto3" json:"max,omitempty"`
+	Count         int32                  `protobuf:"varint,11,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectionOp) Reset() {
+	*x = CollectionOp{}
+	mi := &file_kvstore_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectionOp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectionOp) ProtoMessage() {}
+
+func (x *CollectionOp) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectionOp.ProtoReflect.Descriptor instead.
+func (*CollectionOp) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *CollectionOp) GetCmd() string {
+	if x != nil {
+		return x.Cmd
+	}
+	return ""
+}
+
+func (x *CollectionOp) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *CollectionOp) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *CollectionOp) GetMembers() []string {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *CollectionOp) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *CollectionOp) GetScores() []float64 {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+func (x *CollectionOp) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *CollectionOp) GetStop() int64 {
+	if x != nil {
+		return x.Stop
+	}
+	return 0
+}
+
+func (x *CollectionOp) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *CollectionOp) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *CollectionOp) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type CollectionReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Elements      []string               `protobuf:"bytes,1,rep,name=elements,proto3" json:"elements,omitempty"`      // list elements, members or hash fields
+	Values        []string               `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`          // hash values, parallel to elements
+	Scores        []float64              `protobuf:"fixed64,3,rep,packed,name=scores,proto3" json:"scores,omitempty"` // sorted set scores, parallel to elements
+	Count         int64                  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	Found         bool                   `protobuf:"varint,5,opt,name=found,proto3" json:"found,omitempty"`
+	Epoch         uint64                 `protobuf:"varint,6,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectionReply) Reset() {
+	*x = CollectionReply{}
+	mi := &file_kvstore_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectionReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectionReply) ProtoMessage() {}
+
+func (x *CollectionReply) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectionReply.ProtoReflect.Descriptor instead.
+func (*CollectionReply) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *CollectionReply) GetElements() []string {
+	if x != nil {
+		return x.Elements
+	}
+	return nil
+}
+
+func (x *CollectionReply) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *CollectionReply) GetScores() []float64 {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+func (x *CollectionReply) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *CollectionReply) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *CollectionReply) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+// Cross-cluster replication
+type TailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LogId         uint64                 `protobuf:"varint,1,opt,name=log_id,json=logId,proto3" json:"log_id,omitempty"`             // log the standby is following, 0 for none
+	FromIndex     uint64                 `protobuf:"varint,2,opt,name=from_index,json=fromIndex,proto3" json:"from_index,omitempty"` // first index wanted
+	Prefix        string                 `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"`                         // only keys with this prefix
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TailRequest) Reset() {
+	*x = TailRequest{}
+	mi := &file_kvstore_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TailRequest) ProtoMessage() {}
+
+func (x *TailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TailRequest.ProtoReflect.Descriptor instead.
+func (*TailRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *TailRequest) GetLogId() uint64 {
+	if x != nil {
+		return x.LogId
+	}
+	return 0
+}
+
+func (x *TailRequest) GetFromIndex() uint64 {
+	if x != nil {
+		return x.FromIndex
+	}
+	return 0
+}
+
+func (x *TailRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+type LogEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LogId         uint64                 `protobuf:"varint,1,opt,name=log_id,json=logId,proto3" json:"log_id,omitempty"`
+	Index         uint64                 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	Op            string                 `protobuf:"bytes,3,opt,name=op,proto3" json:"op,omitempty"` // put, delete, txn, install, reset or heartbeat
+	Key           string                 `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
+	Value         string                 `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	Committed     int64                  `protobuf:"varint,6,opt,name=committed,proto3" json:"committed,omitempty"`                  // commit time in unix nanoseconds
+	HeadIndex     uint64                 `protobuf:"varint,7,opt,name=head_index,json=headIndex,proto3" json:"head_index,omitempty"` // last index of the source log
+	Snapshot      bool                   `protobuf:"varint,8,opt,name=snapshot,proto3" json:"snapshot,omitempty"`                    // part of the snapshot sent after a reset
+	Ops           []*TxnOp               `protobuf:"bytes,9,rep,name=ops,proto3" json:"ops,omitempty"`                               // txn: the writes applied together
+	Records       []*KeyRecord           `protobuf:"bytes,11,rep,name=records,proto3" json:"records,omitempty"`                      // install: the collections installed whole, or removed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogEntry) Reset() {
+	*x = LogEntry{}
+	mi := &file_kvstore_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogEntry) ProtoMessage() {}
+
+func (x *LogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogEntry.ProtoReflect.Descriptor instead.
+func (*LogEntry) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *LogEntry) GetLogId() uint64 {
+	if x != nil {
+		return x.LogId
+	}
+	return 0
+}
+
+func (x *LogEntry) GetIndex() uint64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *LogEntry) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *LogEntry) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *LogEntry) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *LogEntry) GetCommitted() int64 {
+	if x != nil {
+		return x.Committed
+	}
+	return 0
+}
+
+func (x *LogEntry) GetHeadIndex() uint64 {
+	if x != nil {
+		return x.HeadIndex
+	}
+	return 0
+}
+
+func (x *LogEntry) GetSnapshot() bool {
+	if x != nil {
+		return x.Snapshot
+	}
+	return false
+}
+
+func (x *LogEntry) GetOps() []*TxnOp {
+	if x != nil {
+		return x.Ops
+	}
+	return nil
+}
+
+func (x *LogEntry) GetRecords() []*KeyRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+type StartReplicationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"` // address of the primary node to tail
+	Prefix        string                 `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartReplicationRequest) Reset() {
+	*x = StartReplicationRequest{}
+	mi := &file_kvstore_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartReplicationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartReplicationRequest) ProtoMessage() {}
+
+func (x *StartReplicationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartReplicationRequest.ProtoReflect.Descriptor instead.
+func (*StartReplicationRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *StartReplicationRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *StartReplicationRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+type ReplicationStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplicationStatusRequest) Reset() {
+	*x = ReplicationStatusRequest{}
+	mi := &file_kvstore_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplicationStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicationStatusRequest) ProtoMessage() {}
+
+func (x *ReplicationStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicationStatusRequest.ProtoReflect.Descriptor instead.
+func (*ReplicationStatusRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{110}
+}
+
+type PromoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoteRequest) Reset() {
+	*x = PromoteRequest{}
+	mi := &file_kvstore_proto_msgTypes[111]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoteRequest) ProtoMessage() {}
+
+func (x *PromoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[111]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoteRequest.ProtoReflect.Descriptor instead.
+func (*PromoteRequest) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{111}
+}
+
+type ReplicationStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Prefix        string                 `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Running       bool                   `protobuf:"varint,3,opt,name=running,proto3" json:"running,omitempty"`
+	LogId         uint64                 `protobuf:"varint,4,opt,name=log_id,json=logId,proto3" json:"log_id,omitempty"`
+	AppliedIndex  uint64                 `protobuf:"varint,5,opt,name=applied_index,json=appliedIndex,proto3" json:"applied_index,omitempty"`
+	SourceIndex   uint64                 `protobuf:"varint,6,opt,name=source_index,json=sourceIndex,proto3" json:"source_index,omitempty"`
+	LagEntries    uint64                 `protobuf:"varint,7,opt,name=lag_entries,json=lagEntries,proto3" json:"lag_entries,omitempty"`
+	LagSeconds    float64                `protobuf:"fixed64,8,opt,name=lag_seconds,json=lagSeconds,proto3" json:"lag_seconds,omitempty"`
+	Error         string                 `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	ReadOnly      bool                   `protobuf:"varint,10,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplicationStatus) Reset() {
+	*x = ReplicationStatus{}
+	mi := &file_kvstore_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplicationStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicationStatus) ProtoMessage() {}
+
+func (x *ReplicationStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_kvstore_proto_msgTypes[112]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicationStatus.ProtoReflect.Descriptor instead.
+func (*ReplicationStatus) Descriptor() ([]byte, []int) {
+	return file_kvstore_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *ReplicationStatus) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ReplicationStatus) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ReplicationStatus) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+func (x *ReplicationStatus) GetLogId() uint64 {
+	if x != nil {
+		return x.LogId
+	}
+	return 0
+}
+
+func (x *ReplicationStatus) GetAppliedIndex() uint64 {
+	if x != nil {
+		return x.AppliedIndex
+	}
+	return 0
+}
+
+func (x *ReplicationStatus) GetSourceIndex() uint64 {
+	if x != nil {
+		return x.SourceIndex
+	}
+	return 0
+}
+
+func (x *ReplicationStatus) GetLagEntries() uint64 {
+	if x != nil {
+		return x.LagEntries
+	}
+	return 0
+}
+
+func (x *ReplicationStatus) GetLagSeconds() float64 {
+	if x != nil {
+		return x.LagSeconds
+	}
+	return 0
+}
+
+func (x *ReplicationStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ReplicationStatus) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+var File_kvstore_proto protoreflect.FileDescriptor
+
+const file_kvstore_proto_rawDesc = "" +
+	"\n" +
+	"\rkvstore.proto\"\x85\x02\n" +
+	"\n" +
+	"PutRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x1b\n" +
+	"\tclient_id\x18\x03 \x01(\tR\bclientId\x12\x1a\n" +
+	"\bsequence\x18\x04 \x01(\x04R\bsequence\x12&\n" +
+	"\acontext\x18\x05 \x01(\v2\f.VectorClockR\acontext\x12\x1b\n" +
+	"\tif_absent\x18\x06 \x01(\bR\bifAbsent\x12\x1d\n" +
+	"\n" +
+	"if_version\x18\a \x01(\x03R\tifVersion\x12\x1c\n" +
+	"\x05stamp\x18\b \x01(\v2\x06.StampR\x05stamp\x12\x14\n" +
+	"\x05lease\x18\t \x01(\x03R\x05lease\":\n" +
+	"\n" +
+	"GetRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\x03R\brevision\"\xbf\x01\n" +
+	"\rDeleteRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1b\n" +
+	"\tclient_id\x18\x02 \x01(\tR\bclientId\x12\x1a\n" +
+	"\bsequence\x18\x03 \x01(\x04R\bsequence\x12&\n" +
+	"\acontext\x18\x04 \x01(\v2\f.VectorClockR\acontext\x12\x1d\n" +
+	"\n" +
+	"if_version\x18\x05 \x01(\x03R\tifVersion\x12\x1c\n" +
+	"\x05stamp\x18\x06 \x01(\v2\x06.StampR\x05stamp\"\x94\x01\n" +
+	"\x15CompareAndSwapRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1a\n" +
+	"\bexpected\x18\x02 \x01(\tR\bexpected\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x12\x1b\n" +
+	"\tclient_id\x18\x04 \x01(\tR\bclientId\x12\x1a\n" +
+	"\bsequence\x18\x05 \x01(\x04R\bsequence\"\xa1\x01\n" +
+	"\vScanRequest\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\tR\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\tR\x03end\x12\x16\n" +
+	"\x06prefix\x18\x03 \x01(\tR\x06prefix\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x1a\n" +
+	"\brevision\x18\x05 \x01(\x03R\brevision\x12 \n" +
+	"\vcollections\x18\x06 \x01(\bR\vcollections\"-\n" +
+	"\fCountRequest\x12\x1d\n" +
+	"\n" +
+	"group_only\x18\x01 \x01(\bR\tgroupOnly\"[\n" +
+	"\x12DeleteRangeRequest\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\tR\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\tR\x03end\x12\x1d\n" +
+	"\n" +
+	"group_only\x18\x03 \x01(\bR\tgroupOnly\",\n" +
+	"\x0eCompactRequest\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\x03R\brevision\"\xb9\x01\n" +
+	"\fWatchRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06prefix\x18\x02 \x01(\tR\x06prefix\x12\x14\n" +
+	"\x05start\x18\x03 \x01(\tR\x05start\x12\x10\n" +
+	"\x03end\x18\x04 \x01(\tR\x03end\x12%\n" +
+	"\x0estart_revision\x18\x05 \x01(\x03R\rstartRevision\x120\n" +
+	"\x14progress_interval_ms\x18\x06 \x01(\x05R\x12progressIntervalMs\"%\n" +
+	"\x0fBatchGetRequest\x12\x12\n" +
+	"\x04keys\x18\x01 \x03(\tR\x04keys\"f\n" +
+	"\x11BatchWriteRequest\x12\x18\n" +
+	"\x03ops\x18\x01 \x03(\v2\x06.TxnOpR\x03ops\x12\x1b\n" +
+	"\tclient_id\x18\x02 \x01(\tR\bclientId\x12\x1a\n" +
+	"\bsequence\x18\x03 \x01(\x04R\bsequence\"[\n" +
+	"\x0fBulkLoadRequest\x12#\n" +
+	"\arecords\x18\x01 \x03(\v2\t.KeyValueR\arecords\x12#\n" +
+	"\rskip_existing\x18\x02 \x01(\bR\fskipExisting\"s\n" +
+	"\x10IncrementRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05delta\x18\x02 \x01(\x03R\x05delta\x12\x1b\n" +
+	"\tclient_id\x18\x03 \x01(\tR\bclientId\x12\x1a\n" +
+	"\bsequence\x18\x04 \x01(\x04R\bsequence\"\x8a\x01\n" +
+	"\vPutResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05epoch\x18\x02 \x01(\x04R\x05epoch\x12&\n" +
+	"\acontext\x18\x03 \x01(\v2\f.VectorClockR\acontext\x12#\n" +
+	"\acurrent\x18\x04 \x01(\v2\t.KeyStateR\acurrent\"\xa5\x01\n" +
+	"\vGetResponse\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x14\n" +
+	"\x05epoch\x18\x02 \x01(\x04R\x05epoch\x12$\n" +
+	"\bsiblings\x18\x03 \x03(\v2\b.SiblingR\bsiblings\x12&\n" +
+	"\acontext\x18\x04 \x01(\v2\f.VectorClockR\acontext\x12\x1c\n" +
+	"\x04meta\x18\x05 \x01(\v2\b.KeyMetaR\x04meta\"\x8d\x01\n" +
+	"\x0eDeleteResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05epoch\x18\x02 \x01(\x04R\x05epoch\x12&\n" +
+	"\acontext\x18\x03 \x01(\v2\f.VectorClockR\acontext\x12#\n" +
+	"\acurrent\x18\x04 \x01(\v2\t.KeyStateR\acurrent\"\x82\x01\n" +
+	"\x0eBatchGetResult\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x14\n" +
+	"\x05found\x18\x03 \x01(\bR\x05found\x12\x1c\n" +
+	"\x04meta\x18\x04 \x01(\v2\b.KeyMetaR\x04meta\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"S\n" +
+	"\x10BatchGetResponse\x12)\n" +
+	"\aresults\x18\x01 \x03(\v2\x0f.BatchGetResultR\aresults\x12\x14\n" +
+	"\x05epoch\x18\x02 \x01(\x04R\x05epoch\"B\n" +
+	"\x12BatchWriteResponse\x12\x16\n" +
+	"\x06errors\x18\x01 \x03(\tR\x06errors\x12\x14\n" +
+	"\x05epoch\x18\x02 \x01(\x04R\x05epoch\"\x8a\x01\n" +
+	"\x10BulkLoadResponse\x12\x16\n" +
+	"\x06loaded\x18\x01 \x01(\x03R\x06loaded\x12\x18\n" +
+	"\askipped\x18\x02 \x01(\x03R\askipped\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x03R\x06failed\x12\x16\n" +
+	"\x06errors\x18\x04 \x03(\tR\x06errors\x12\x14\n" +
+	"\x05epoch\x18\x05 \x01(\x04R\x05epoch\"?\n" +
+	"\x11IncrementResponse\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x03R\x05value\x12\x14\n" +
+	"\x05epoch\x18\x02 \x01(\x04R\x05epoch\"m\n" +
+	"\x16CompareAndSwapResponse\x12\x18\n" +
+	"\aswapped\x18\x01 \x01(\bR\aswapped\x12\x14\n" +
+	"\x05epoch\x18\x02 \x01(\x04R\x05epoch\x12#\n" +
+	"\acurrent\x18\x03 \x01(\v2\t.KeyStateR\acurrent\"q\n" +
+	"\fScanResponse\x12\x1b\n" +
+	"\x03kvs\x18\x01 \x03(\v2\t.KeyValueR\x03kvs\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\x03R\brevision\x12\x12\n" +
+	"\x04more\x18\x03 \x01(\bR\x04more\x12\x14\n" +
+	"\x05epoch\x18\x04 \x01(\x04R\x05epoch\";\n" +
+	"\rCountResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x03R\x05count\x12\x14\n" +
+	"\x05epoch\x18\x02 \x01(\x04R\x05epoch\"E\n" +
+	"\x13DeleteRangeResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\x03R\adeleted\x12\x14\n" +
+	"\x05epoch\x18\x02 \x01(\x04R\x05epoch\"'\n" +
+	"\x0fCompactResponse\x12\x14\n" +
+	"\x05epoch\x18\x01 \x01(\x04R\x05epoch\"j\n" +
+	"\rWatchResponse\x12!\n" +
+	"\x06events\x18\x01 \x03(\v2\t.KeyValueR\x06events\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\x03R\brevision\x12\x1a\n" +
+	"\bprogress\x18\x03 \x01(\bR\bprogress\"O\n" +
+	"\x05Stamp\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\x03R\brevision\x12\x12\n" +
+	"\x04time\x18\x02 \x01(\x03R\x04time\x12\x16\n" +
+	"\x06writer\x18\x03 \x01(\x05R\x06writer\"\xb8\x01\n" +
+	"\aKeyMeta\x12'\n" +
+	"\x0fcreate_revision\x18\x01 \x01(\x03R\x0ecreateRevision\x12!\n" +
+	"\fmod_revision\x18\x02 \x01(\x03R\vmodRevision\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\x12\x19\n" +
+	"\bmod_time\x18\x04 \x01(\x03R\amodTime\x12\x16\n" +
+	"\x06writer\x18\x05 \x01(\x05R\x06writer\x12\x14\n" +
+	"\x05lease\x18\x06 \x01(\x03R\x05lease\"R\n" +
+	"\bKeyState\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\x12\x16\n" +
+	"\x06exists\x18\x03 \x01(\bR\x06exists\"\xa7\x01\n" +
+	"\aCompare\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\tR\x06target\x12\x0e\n" +
+	"\x02op\x18\x03 \x01(\tR\x02op\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\tR\x05value\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x03R\aversion\x12\x16\n" +
+	"\x06exists\x18\x06 \x01(\bR\x06exists\x12\x1a\n" +
+	"\brevision\x18\a \x01(\x03R\brevision\"\xe8\x01\n" +
+	"\x05TxnOp\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x16\n" +
+	"\x06delete\x18\x03 \x01(\bR\x06delete\x12\x14\n" +
+	"\x05lease\x18\x04 \x01(\x03R\x05lease\x12\x1b\n" +
+	"\tif_absent\x18\x05 \x01(\bR\bifAbsent\x12\x1c\n" +
+	"\tincrement\x18\x06 \x01(\bR\tincrement\x12\x14\n" +
+	"\x05delta\x18\a \x01(\x03R\x05delta\x12\x1a\n" +
+	"\bunsigned\x18\b \x01(\bR\bunsigned\x12\x1c\n" +
+	"\tdecrement\x18\t \x01(\bR\tdecrement\"\x85\x02\n" +
+	"\n" +
+	"TxnRequest\x12\"\n" +
+	"\acompare\x18\x01 \x03(\v2\b.CompareR\acompare\x12 \n" +
+	"\asuccess\x18\x02 \x03(\v2\x06.TxnOpR\asuccess\x12 \n" +
+	"\afailure\x18\x03 \x03(\v2\x06.TxnOpR\afailure\x12\x1b\n" +
+	"\tclient_id\x18\x04 \x01(\tR\bclientId\x12\x1a\n" +
+	"\bsequence\x18\x05 \x01(\x04R\bsequence\x12\x1a\n" +
+	"\bresolved\x18\x06 \x01(\bR\bresolved\x12\x1c\n" +
+	"\tsucceeded\x18\a \x01(\bR\tsucceeded\x12\x1c\n" +
+	"\x05stamp\x18\b \x01(\v2\x06.StampR\x05stamp\"\xbd\x01\n" +
+	"\vTxnResponse\x12\x1c\n" +
+	"\tsucceeded\x18\x01 \x01(\bR\tsucceeded\x12\x14\n" +
+	"\x05epoch\x18\x02 \x01(\x04R\x05epoch\x123\n" +
+	"\acurrent\x18\x03 \x03(\v2\x19.TxnResponse.CurrentEntryR\acurrent\x1aE\n" +
+	"\fCurrentEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1f\n" +
+	"\x05value\x18\x02 \x01(\v2\t.KeyStateR\x05value:\x028\x01\"-\n" +
+	"\x11ReplicateResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x8b\x01\n" +
+	"\x06Member\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04addr\x18\x02 \x01(\tR\x04addr\x12%\n" +
+	"\x06status\x18\x03 \x01(\x0e2\r.MemberStatusR\x06status\x12 \n" +
+	"\vincarnation\x18\x04 \x01(\x04R\vincarnation\x12\x14\n" +
+	"\x05shard\x18\x05 \x01(\x05R\x05shard\"{\n" +
+	"\vPingRequest\x12\x1b\n" +
+	"\x04from\x18\x01 \x01(\v2\a.MemberR\x04from\x12!\n" +
+	"\aupdates\x18\x02 \x03(\v2\a.MemberR\aupdates\x12,\n" +
+	"\bmetadata\x18\x03 \x01(\v2\x10.ClusterMetadataR\bmetadata\"\x9f\x01\n" +
+	"\x0ePingReqRequest\x12\x1b\n" +
+	"\x04from\x18\x01 \x01(\v2\a.MemberR\x04from\x12\x1f\n" +
+	"\x06target\x18\x02 \x01(\v2\a.MemberR\x06target\x12!\n" +
+	"\aupdates\x18\x03 \x03(\v2\a.MemberR\aupdates\x12,\n" +
+	"\bmetadata\x18\x04 \x01(\v2\x10.ClusterMetadataR\bmetadata\"q\n" +
+	"\fPingResponse\x12\x10\n" +
+	"\x03ack\x18\x01 \x01(\bR\x03ack\x12!\n" +
+	"\aupdates\x18\x02 \x03(\v2\a.MemberR\aupdates\x12,\n" +
+	"\bmetadata\x18\x03 \x01(\v2\x10.ClusterMetadataR\bmetadata\".\n" +
+	"\vJoinRequest\x12\x1f\n" +
+	"\x06member\x18\x01 \x01(\v2\a.MemberR\x06member\"_\n" +
+	"\fJoinResponse\x12!\n" +
+	"\amembers\x18\x01 \x03(\v2\a.MemberR\amembers\x12,\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x10.ClusterMetadataR\bmetadata\"\xb4\x01\n" +
+	"\n" +
+	"ShardGroup\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1b\n" +
+	"\tleader_id\x18\x02 \x01(\x05R\bleaderId\x12\x1f\n" +
+	"\vleader_addr\x18\x03 \x01(\tR\n" +
+	"leaderAddr\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x04R\aversion\x12!\n" +
+	"\x06ranges\x18\x05 \x03(\v2\t.KeyRangeR\x06ranges\x12\x1b\n" +
+	"\tresp_addr\x18\x06 \x01(\tR\brespAddr\"2\n" +
+	"\bKeyRange\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\tR\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\tR\x03end\"\xb8\x02\n" +
+	"\x0fClusterMetadata\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x04R\aversion\x12#\n" +
+	"\rvirtual_nodes\x18\x02 \x01(\x05R\fvirtualNodes\x12#\n" +
+	"\x06shards\x18\x03 \x03(\v2\v.ShardGroupR\x06shards\x12\"\n" +
+	"\fpartitioning\x18\x04 \x01(\tR\fpartitioning\x12 \n" +
+	"\vreplication\x18\x05 \x01(\tR\vreplication\x12\x1a\n" +
+	"\breplicas\x18\x06 \x01(\x05R\breplicas\x12\x1f\n" +
+	"\vread_quorum\x18\a \x01(\x05R\n" +
+	"readQuorum\x12!\n" +
+	"\fwrite_quorum\x18\b \x01(\x05R\vwriteQuorum\x12\x1b\n" +
+	"\tread_only\x18\t \x01(\bR\breadOnly\"<\n" +
+	"\x10RebalanceRequest\x12(\n" +
+	"\x10bytes_per_second\x18\x01 \x01(\x03R\x0ebytesPerSecond\"\x18\n" +
+	"\x16RebalanceStatusRequest\"\xbc\x01\n" +
+	"\tRangeMove\x12\x1f\n" +
+	"\x05range\x18\x01 \x01(\v2\t.KeyRangeR\x05range\x12\x1d\n" +
+	"\n" +
+	"from_shard\x18\x02 \x01(\x05R\tfromShard\x12\x19\n" +
+	"\bto_shard\x18\x03 \x01(\x05R\atoShard\x12\x14\n" +
+	"\x05state\x18\x04 \x01(\tR\x05state\x12\x12\n" +
+	"\x04keys\x18\x05 \x01(\x03R\x04keys\x12\x14\n" +
+	"\x05bytes\x18\x06 \x01(\x03R\x05bytes\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\"M\n" +
+	"\x0fRebalanceStatus\x12 \n" +
+	"\x05moves\x18\x01 \x03(\v2\n" +
+	".RangeMoveR\x05moves\x12\x18\n" +
+	"\arunning\x18\x02 \x01(\bR\arunning\"\x91\x01\n" +
+	"\x10MoveRangeRequest\x12\x1f\n" +
+	"\x05range\x18\x01 \x01(\v2\t.KeyRangeR\x05range\x12\x19\n" +
+	"\bto_shard\x18\x02 \x01(\x05R\atoShard\x12\x17\n" +
+	"\ato_addr\x18\x03 \x01(\tR\x06toAddr\x12(\n" +
+	"\x10bytes_per_second\x18\x04 \x01(\x03R\x0ebytesPerSecond\"=\n" +
+	"\x11MoveRangeResponse\x12\x12\n" +
+	"\x04keys\x18\x01 \x01(\x03R\x04keys\x12\x14\n" +
+	"\x05bytes\x18\x02 \x01(\x03R\x05bytes\"j\n" +
+	"\bKeyValue\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x18\n" +
+	"\adeleted\x18\x03 \x01(\bR\adeleted\x12\x1c\n" +
+	"\x04meta\x18\x04 \x01(\v2\b.KeyMetaR\x04meta\"\x8d\x02\n" +
+	"\n" +
+	"RangeChunk\x12\x1f\n" +
+	"\x05range\x18\x01 \x01(\v2\t.KeyRangeR\x05range\x12\x16\n" +
+	"\x06commit\x18\x03 \x01(\bR\x06commit\x12$\n" +
+	"\arecords\x18\x04 \x03(\v2\n" +
+	".KeyRecordR\arecords\x12=\n" +
+	"\flease_ttl_ms\x18\x05 \x03(\v2\x1b.RangeChunk.LeaseTtlMsEntryR\n" +
+	"leaseTtlMs\x12\x1c\n" +
+	"\x05stamp\x18\x06 \x01(\v2\x06.StampR\x05stamp\x1a=\n" +
+	"\x0fLeaseTtlMsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x03R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01J\x04\b\x02\x10\x03\"v\n" +
+	"\tKeyRecord\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12%\n" +
+	"\ahistory\x18\x02 \x03(\v2\v.KeyVersionR\ahistory\x120\n" +
+	"\n" +
+	"collection\x18\x03 \x01(\v2\x10.CollectionValueR\n" +
+	"collection\"Z\n" +
+	"\n" +
+	"KeyVersion\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x1c\n" +
+	"\x04meta\x18\x02 \x01(\v2\b.KeyMetaR\x04meta\x12\x18\n" +
+	"\adeleted\x18\x03 \x01(\bR\adeleted\"q\n" +
+	"\x0fCollectionValue\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1a\n" +
+	"\belements\x18\x02 \x03(\tR\belements\x12\x16\n" +
+	"\x06values\x18\x03 \x03(\tR\x06values\x12\x16\n" +
+	"\x06scores\x18\x04 \x03(\x01R\x06scores\"9\n" +
+	"\x14InstallRangeResponse\x12!\n" +
+	"\x05group\x18\x01 \x01(\v2\v.ShardGroupR\x05group\"\x82\x01\n" +
+	"\vVectorClock\x126\n" +
+	"\bcounters\x18\x01 \x03(\v2\x1a.VectorClock.CountersEntryR\bcounters\x1a;\n" +
+	"\rCountersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x05R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x04R\x05value:\x028\x01\"]\n" +
+	"\aSibling\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x18\n" +
+	"\adeleted\x18\x02 \x01(\bR\adeleted\x12\"\n" +
+	"\x05clock\x18\x03 \x01(\v2\f.VectorClockR\x05clock\"a\n" +
+	"\fStoreRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12$\n" +
+	"\bsiblings\x18\x02 \x03(\v2\b.SiblingR\bsiblings\x12\x19\n" +
+	"\bhint_for\x18\x03 \x01(\x05R\ahintFor\"\x0f\n" +
+	"\rStoreResponse\" \n" +
+	"\fFetchRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"5\n" +
+	"\rFetchResponse\x12$\n" +
+	"\bsiblings\x18\x01 \x03(\v2\b.SiblingR\bsiblings\"A\n" +
+	"\x17CounterIncrementRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05delta\x18\x02 \x01(\x03R\x05delta\"8\n" +
+	"\n" +
+	"SetRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x18\n" +
+	"\amembers\x18\x02 \x03(\tR\amembers\"9\n" +
+	"\x0fRegisterRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"J\n" +
+	"\n" +
+	"MapRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\"#\n" +
+	"\x0fCRDTReadRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"\xde\x01\n" +
+	"\tCRDTValue\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x18\n" +
+	"\acounter\x18\x02 \x01(\x03R\acounter\x12\x18\n" +
+	"\amembers\x18\x03 \x03(\tR\amembers\x12\x1a\n" +
+	"\bregister\x18\x04 \x01(\tR\bregister\x121\n" +
+	"\aentries\x18\x05 \x03(\v2\x17.CRDTValue.EntriesEntryR\aentries\x1a:\n" +
+	"\fEntriesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"3\n" +
+	"\tCRDTState\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\fR\x05state\"0\n" +
+	"\n" +
+	"CRDTStates\x12\"\n" +
+	"\x06states\x18\x01 \x03(\v2\n" +
+	".CRDTStateR\x06states\"\xed\x01\n" +
+	"\n" +
+	"ChainWrite\x12\x0e\n" +
+	"\x02op\x18\x01 \x01(\tR\x02op\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x12\x1b\n" +
+	"\tclient_id\x18\x04 \x01(\tR\bclientId\x12\x1a\n" +
+	"\bsequence\x18\x05 \x01(\x04R\bsequence\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\x04R\aversion\x12\x18\n" +
+	"\x03ops\x18\a \x03(\v2\x06.TxnOpR\x03ops\x12\x1c\n" +
+	"\tsucceeded\x18\b \x01(\bR\tsucceeded\x12\x1c\n" +
+	"\x05stamp\x18\t \x01(\v2\x06.StampR\x05stamp\"\n" +
+	"\n" +
+	"\bChainAck\":\n" +
+	"\x11LeaseGrantRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x15\n" +
+	"\x06ttl_ms\x18\x02 \x01(\x03R\x05ttlMs\"Q\n" +
+	"\x12LeaseGrantResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x15\n" +
+	"\x06ttl_ms\x18\x02 \x01(\x03R\x05ttlMs\x12\x14\n" +
+	"\x05epoch\x18\x03 \x01(\x04R\x05epoch\"V\n" +
+	"\x12LeaseRevokeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1c\n" +
+	"\x05stamp\x18\x02 \x01(\v2\x06.StampR\x05stamp\x12\x12\n" +
+	"\x04keys\x18\x03 \x03(\tR\x04keys\"+\n" +
+	"\x13LeaseRevokeResponse\x12\x14\n" +
+	"\x05epoch\x18\x01 \x01(\x04R\x05epoch\"'\n" +
+	"\x15LeaseKeepAliveRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"?\n" +
+	"\x16LeaseKeepAliveResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x15\n" +
+	"\x06ttl_ms\x18\x02 \x01(\x03R\x05ttlMs\"(\n" +
+	"\x16LeaseTimeToLiveRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"w\n" +
+	"\x17LeaseTimeToLiveResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x15\n" +
+	"\x06ttl_ms\x18\x02 \x01(\x03R\x05ttlMs\x12!\n" +
+	"\fremaining_ms\x18\x03 \x01(\x03R\vremainingMs\x12\x12\n" +
+	"\x04keys\x18\x04 \x03(\tR\x04keys\"7\n" +
+	"\vLockRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05lease\x18\x02 \x01(\x03R\x05lease\"[\n" +
+	"\fLockResponse\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12#\n" +
+	"\rfencing_token\x18\x02 \x01(\x03R\ffencingToken\x12\x14\n" +
+	"\x05epoch\x18\x03 \x01(\x04R\x05epoch\"!\n" +
+	"\rUnlockRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"&\n" +
+	"\x0eUnlockResponse\x12\x14\n" +
+	"\x05epoch\x18\x01 \x01(\x04R\x05epoch\"c\n" +
+	"\tLeaderKey\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x1a\n" +
+	"\brevision\x18\x03 \x01(\x03R\brevision\x12\x14\n" +
+	"\x05lease\x18\x04 \x01(\x03R\x05lease\"Q\n" +
+	"\x0fCampaignRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05lease\x18\x02 \x01(\x03R\x05lease\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\"L\n" +
+	"\x10CampaignResponse\x12\"\n" +
+	"\x06leader\x18\x01 \x01(\v2\n" +
+	".LeaderKeyR\x06leader\x12\x14\n" +
+	"\x05epoch\x18\x02 \x01(\x04R\x05epoch\"K\n" +
+	"\x0fProclaimRequest\x12\"\n" +
+	"\x06leader\x18\x01 \x01(\v2\n" +
+	".LeaderKeyR\x06leader\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"(\n" +
+	"\x10ProclaimResponse\x12\x14\n" +
+	"\x05epoch\x18\x01 \x01(\x04R\x05epoch\"3\n" +
+	"\rResignRequest\x12\"\n" +
+	"\x06leader\x18\x01 \x01(\v2\n" +
+	".LeaderKeyR\x06leader\"&\n" +
+	"\x0eResignResponse\x12\x14\n" +
+	"\x05epoch\x18\x01 \x01(\x04R\x05epoch\"#\n" +
+	"\rLeaderRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"`\n" +
+	"\x0eLeaderResponse\x12\"\n" +
+	"\x06leader\x18\x01 \x01(\v2\n" +
+	".LeaderKeyR\x06leader\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x14\n" +
+	"\x05epoch\x18\x03 \x01(\x04R\x05epoch\"O\n" +
+	"\x0fListPushRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06values\x18\x02 \x03(\tR\x06values\x12\x12\n" +
+	"\x04left\x18\x03 \x01(\bR\x04left\"L\n" +
+	"\x0eListPopRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
+	"\x04left\x18\x02 \x01(\bR\x04left\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\"N\n" +
+	"\x10ListRangeRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05start\x18\x02 \x01(\x03R\x05start\x12\x12\n" +
+	"\x04stop\x18\x03 \x01(\x03R\x04stop\"<\n" +
+	"\x0eMembersRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x18\n" +
+	"\amembers\x18\x02 \x03(\tR\amembers\"(\n" +
+	"\x14CollectionKeyRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\")\n" +
+	"\x13SetIntersectRequest\x12\x12\n" +
+	"\x04keys\x18\x01 \x03(\tR\x04keys\"\x92\x01\n" +
+	"\x0eHashSetRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x123\n" +
+	"\x06fields\x18\x02 \x03(\v2\x1b.HashSetRequest.FieldsEntryR\x06fields\x1a9\n" +
+	"\vFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"=\n" +
+	"\x11HashFieldsRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06fields\x18\x02 \x03(\tR\x06fields\"<\n" +
+	"\fScoredMember\x12\x16\n" +
+	"\x06member\x18\x01 \x01(\tR\x06member\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\"K\n" +
+	"\x0eZSetAddRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12'\n" +
+	"\amembers\x18\x02 \x03(\v2\r.ScoredMemberR\amembers\"H\n" +
+	"\x10ZSetRangeRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x10\n" +
+	"\x03min\x18\x02 \x01(\x01R\x03min\x12\x10\n" +
+	"\x03max\x18\x03 \x01(\x01R\x03max\";\n" +
+	"\x0fZSetRankRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06member\x18\x02 \x01(\tR\x06member\"\xf4\x01\n" +
+	"\fCollectionOp\x12\x10\n" +
+	"\x03cmd\x18\x01 \x01(\tR\x03cmd\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x12\n" +
+	"\x04keys\x18\x03 \x03(\tR\x04keys\x12\x18\n" +
+	"\amembers\x18\x04 \x03(\tR\amembers\x12\x16\n" +
+	"\x06values\x18\x05 \x03(\tR\x06values\x12\x16\n" +
+	"\x06scores\x18\x06 \x03(\x01R\x06scores\x12\x14\n" +
+	"\x05start\x18\a \x01(\x03R\x05start\x12\x12\n" +
+	"\x04stop\x18\b \x01(\x03R\x04stop\x12\x10\n" +
+	"\x03min\x18\t \x01(\x01R\x03min\x12\x10\n" +
+	"\x03max\x18\n" +
+	" \x01(\x01R\x03max\x12\x14\n" +
+	"\x05count\x18\v \x01(\x05R\x05count\"\x9f\x01\n" +
+	"\x0fCollectionReply\x12\x1a\n" +
+	"\belements\x18\x01 \x03(\tR\belements\x12\x16\n" +
+	"\x06values\x18\x02 \x03(\tR\x06values\x12\x16\n" +
+	"\x06scores\x18\x03 \x03(\x01R\x06scores\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x03R\x05count\x12\x14\n" +
+	"\x05found\x18\x05 \x01(\bR\x05found\x12\x14\n" +
+	"\x05epoch\x18\x06 \x01(\x04R\x05epoch\"[\n" +
+	"\vTailRequest\x12\x15\n" +
+	"\x06log_id\x18\x01 \x01(\x04R\x05logId\x12\x1d\n" +
+	"\n" +
+	"from_index\x18\x02 \x01(\x04R\tfromIndex\x12\x16\n" +
+	"\x06prefix\x18\x03 \x01(\tR\x06prefix\"\x8e\x02\n" +
+	"\bLogEntry\x12\x15\n" +
+	"\x06log_id\x18\x01 \x01(\x04R\x05logId\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\x04R\x05index\x12\x0e\n" +
+	"\x02op\x18\x03 \x01(\tR\x02op\x12\x10\n" +
+	"\x03key\x18\x04 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x05 \x01(\tR\x05value\x12\x1c\n" +
+	"\tcommitted\x18\x06 \x01(\x03R\tcommitted\x12\x1d\n" +
+	"\n" +
+	"head_index\x18\a \x01(\x04R\theadIndex\x12\x1a\n" +
+	"\bsnapshot\x18\b \x01(\bR\bsnapshot\x12\x18\n" +
+	"\x03ops\x18\t \x03(\v2\x06.TxnOpR\x03ops\x12$\n" +
+	"\arecords\x18\v \x03(\v2\n" +
+	".KeyRecordR\arecordsJ\x04\b\n" +
+	"\x10\v\"I\n" +
+	"\x17StartReplicationRequest\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x16\n" +
+	"\x06prefix\x18\x02 \x01(\tR\x06prefix\"\x1a\n" +
+	"\x18ReplicationStatusRequest\"\x10\n" +
+	"\x0ePromoteRequest\"\xb1\x02\n" +
+	"\x11ReplicationStatus\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x16\n" +
+	"\x06prefix\x18\x02 \x01(\tR\x06prefix\x12\x18\n" +
+	"\arunning\x18\x03 \x01(\bR\arunning\x12\x15\n" +
+	"\x06log_id\x18\x04 \x01(\x04R\x05logId\x12#\n" +
+	"\rapplied_index\x18\x05 \x01(\x04R\fappliedIndex\x12!\n" +
+	"\fsource_index\x18\x06 \x01(\x04R\vsourceIndex\x12\x1f\n" +
+	"\vlag_entries\x18\a \x01(\x04R\n" +
+	"lagEntries\x12\x1f\n" +
+	"\vlag_seconds\x18\b \x01(\x01R\n" +
+	"lagSeconds\x12\x14\n" +
+	"\x05error\x18\t \x01(\tR\x05error\x12\x1b\n" +
+	"\tread_only\x18\n" +
+	" \x01(\bR\breadOnly*0\n" +
+	"\fMemberStatus\x12\t\n" +
+	"\x05ALIVE\x10\x00\x12\v\n" +
+	"\aSUSPECT\x10\x01\x12\b\n" +
+	"\x04DEAD\x10\x022\xbf\x05\n" +
+	"\aKVStore\x12 \n" +
+	"\x03Put\x12\v.PutRequest\x1a\f.PutResponse\x12 \n" +
+	"\x03Get\x12\v.GetRequest\x1a\f.GetResponse\x12)\n" +
+	"\x06Delete\x12\x0e.DeleteRequest\x1a\x0f.DeleteResponse\x12 \n" +
+	"\x03Txn\x12\v.TxnRequest\x1a\f.TxnResponse\x12A\n" +
+	"\x0eCompareAndSwap\x12\x16.CompareAndSwapRequest\x1a\x17.CompareAndSwapResponse\x12#\n" +
+	"\x04Scan\x12\f.ScanRequest\x1a\r.ScanResponse\x12&\n" +
+	"\x05Count\x12\r.CountRequest\x1a\x0e.CountResponse\x128\n" +
+	"\vDeleteRange\x12\x13.DeleteRangeRequest\x1a\x14.DeleteRangeResponse\x12,\n" +
+	"\aCompact\x12\x0f.CompactRequest\x1a\x10.CompactResponse\x12(\n" +
+	"\x05Watch\x12\r.WatchRequest\x1a\x0e.WatchResponse0\x01\x12/\n" +
+	"\bBatchGet\x12\x10.BatchGetRequest\x1a\x11.BatchGetResponse\x125\n" +
+	"\n" +
+	"BatchWrite\x12\x12.BatchWriteRequest\x1a\x13.BatchWriteResponse\x121\n" +
+	"\bBulkLoad\x12\x10.BulkLoadRequest\x1a\x11.BulkLoadResponse(\x01\x122\n" +
+	"\tIncrement\x12\x11.IncrementRequest\x1a\x12.IncrementResponse\x122\n" +
+	"\tDecrement\x12\x11.IncrementRequest\x1a\x12.IncrementResponse2}\n" +
+	"\x06Gossip\x12#\n" +
+	"\x04Ping\x12\f.PingRequest\x1a\r.PingResponse\x12)\n" +
+	"\aPingReq\x12\x0f.PingReqRequest\x1a\r.PingResponse\x12#\n" +
+	"\x04Join\x12\f.JoinRequest\x1a\r.JoinResponse2\xd8\x03\n" +
+	"\x05Admin\x124\n" +
+	"\rPlanRebalance\x12\x11.RebalanceRequest\x1a\x10.RebalanceStatus\x125\n" +
+	"\x0eStartRebalance\x12\x11.RebalanceRequest\x1a\x10.RebalanceStatus\x12?\n" +
+	"\x12GetRebalanceStatus\x12\x17.RebalanceStatusRequest\x1a\x10.RebalanceStatus\x122\n" +
+	"\tMoveRange\x12\x11.MoveRangeRequest\x1a\x12.MoveRangeResponse\x124\n" +
+	"\fInstallRange\x12\v.RangeChunk\x1a\x15.InstallRangeResponse(\x01\x12@\n" +
+	"\x10StartReplication\x12\x18.StartReplicationRequest\x1a\x12.ReplicationStatus\x12E\n" +
+	"\x14GetReplicationStatus\x12\x19.ReplicationStatusRequest\x1a\x12.ReplicationStatus\x12.\n" +
+	"\aPromote\x12\x0f.PromoteRequest\x1a\x12.ReplicationStatus2Y\n" +
+	"\aReplica\x12&\n" +
+	"\x05Store\x12\r.StoreRequest\x1a\x0e.StoreResponse\x12&\n" +
+	"\x05Fetch\x12\r.FetchRequest\x1a\x0e.FetchResponse2\xc6\x02\n" +
+	"\x04CRDT\x121\n" +
+	"\tIncrement\x12\x18.CounterIncrementRequest\x1a\n" +
+	".CRDTValue\x12#\n" +
+	"\bAddToSet\x12\v.SetRequest\x1a\n" +
+	".CRDTValue\x12(\n" +
+	"\rRemoveFromSet\x12\v.SetRequest\x1a\n" +
+	".CRDTValue\x12+\n" +
+	"\vSetRegister\x12\x10.RegisterRequest\x1a\n" +
+	".CRDTValue\x12!\n" +
+	"\x06MapPut\x12\v.MapRequest\x1a\n" +
+	".CRDTValue\x12$\n" +
+	"\tMapRemove\x12\v.MapRequest\x1a\n" +
+	".CRDTValue\x12$\n" +
+	"\x04Read\x12\x10.CRDTReadRequest\x1a\n" +
+	".CRDTValue\x12 \n" +
+	"\x04Sync\x12\v.CRDTStates\x1a\v.CRDTStates2O\n" +
+	"\x05Chain\x12#\n" +
+	"\tPropagate\x12\v.ChainWrite\x1a\t.ChainAck\x12!\n" +
+	"\x04Read\x12\v.GetRequest\x1a\f.GetResponse2\xab\x02\n" +
+	"\x05Lease\x120\n" +
+	"\x05Grant\x12\x12.LeaseGrantRequest\x1a\x13.LeaseGrantResponse\x123\n" +
+	"\x06Revoke\x12\x13.LeaseRevokeRequest\x1a\x14.LeaseRevokeResponse\x12@\n" +
+	"\tKeepAlive\x12\x16.LeaseKeepAliveRequest\x1a\x17.LeaseKeepAliveResponse(\x010\x01\x12?\n" +
+	"\n" +
+	"TimeToLive\x12\x17.LeaseTimeToLiveRequest\x1a\x18.LeaseTimeToLiveResponse\x128\n" +
+	"\x05Renew\x12\x16.LeaseKeepAliveRequest\x1a\x17.LeaseKeepAliveResponse2V\n" +
+	"\x04Lock\x12#\n" +
+	"\x04Lock\x12\f.LockRequest\x1a\r.LockResponse\x12)\n" +
+	"\x06Unlock\x12\x0e.UnlockRequest\x1a\x0f.UnlockResponse2\xf0\x01\n" +
+	"\bElection\x12/\n" +
+	"\bCampaign\x12\x10.CampaignRequest\x1a\x11.CampaignResponse\x12/\n" +
+	"\bProclaim\x12\x10.ProclaimRequest\x1a\x11.ProclaimResponse\x12)\n" +
+	"\x06Resign\x12\x0e.ResignRequest\x1a\x0f.ResignResponse\x12)\n" +
+	"\x06Leader\x12\x0e.LeaderRequest\x1a\x0f.LeaderResponse\x12,\n" +
+	"\aObserve\x12\x0e.LeaderRequest\x1a\x0f.LeaderResponse0\x012\xa5\x06\n" +
+	"\vCollections\x12.\n" +
+	"\bListPush\x12\x10.ListPushRequest\x1a\x10.CollectionReply\x12,\n" +
+	"\aListPop\x12\x0f.ListPopRequest\x1a\x10.CollectionReply\x120\n" +
+	"\tListRange\x12\x11.ListRangeRequest\x1a\x10.CollectionReply\x12+\n" +
+	"\x06SetAdd\x12\x0f.MembersRequest\x1a\x10.CollectionReply\x12.\n" +
+	"\tSetRemove\x12\x0f.MembersRequest\x1a\x10.CollectionReply\x125\n" +
+	"\n" +
+	"SetMembers\x12\x15.CollectionKeyRequest\x1a\x10.CollectionReply\x126\n" +
+	"\fSetIntersect\x12\x14.SetIntersectRequest\x1a\x10.CollectionReply\x12,\n" +
+	"\aHashSet\x12\x0f.HashSetRequest\x1a\x10.CollectionReply\x12/\n" +
+	"\aHashGet\x12\x12.HashFieldsRequest\x1a\x10.CollectionReply\x122\n" +
+	"\n" +
+	"HashDelete\x12\x12.HashFieldsRequest\x1a\x10.CollectionReply\x125\n" +
+	"\n" +
+	"HashGetAll\x12\x15.CollectionKeyRequest\x1a\x10.CollectionReply\x12,\n" +
+	"\aZSetAdd\x12\x0f.ZSetAddRequest\x1a\x10.CollectionReply\x12/\n" +
+	"\n" +
+	"ZSetRemove\x12\x0f.MembersRequest\x1a\x10.CollectionReply\x127\n" +
+	"\x10ZSetRangeByScore\x12\x11.ZSetRangeRequest\x1a\x10.CollectionReply\x12.\n" +
+	"\bZSetRank\x12\x10.ZSetRankRequest\x1a\x10.CollectionReply\x12(\n" +
+	"\x05Apply\x12\r.CollectionOp\x1a\x10.CollectionReply2*\n" +
+	"\x05OpLog\x12!\n" +
+	"\x04Tail\x12\f.TailRequest\x1a\t.LogEntry0\x01B\tZ\a./;mainb\x06proto3"
 
 var (
 	file_kvstore_proto_rawDescOnce sync.Once
@@ -378,28 +7603,337 @@ func file_kvstore_proto_rawDescGZIP() []byte {
 	return file_kvstore_proto_rawDescData
 }
 
-var file_kvstore_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_kvstore_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_kvstore_proto_msgTypes = make([]protoimpl.MessageInfo, 118)
 var file_kvstore_proto_goTypes = []any{
-	(*PutRequest)(nil),        // 0: PutRequest
-	(*GetRequest)(nil),        // 1: GetRequest
-	(*DeleteRequest)(nil),     // 2: DeleteRequest
-	(*PutResponse)(nil),       // 3: PutResponse
-	(*GetResponse)(nil),       // 4: GetResponse
-	(*DeleteResponse)(nil),    // 5: DeleteResponse
-	(*ReplicateResponse)(nil), // 6: ReplicateResponse
+	(MemberStatus)(0),                // 0: MemberStatus
+	(*PutRequest)(nil),               // 1: PutRequest
+	(*GetRequest)(nil),               // 2: GetRequest
+	(*DeleteRequest)(nil),            // 3: DeleteRequest
+	(*CompareAndSwapRequest)(nil),    // 4: CompareAndSwapRequest
+	(*ScanRequest)(nil),              // 5: ScanRequest
+	(*CountRequest)(nil),             // 6: CountRequest
+	(*DeleteRangeRequest)(nil),       // 7: DeleteRangeRequest
+	(*CompactRequest)(nil),           // 8: CompactRequest
+	(*WatchRequest)(nil),             // 9: WatchRequest
+	(*BatchGetRequest)(nil),          // 10: BatchGetRequest
+	(*BatchWriteRequest)(nil),        // 11: BatchWriteRequest
+	(*BulkLoadRequest)(nil),          // 12: BulkLoadRequest
+	(*IncrementRequest)(nil),         // 13: IncrementRequest
+	(*PutResponse)(nil),              // 14: PutResponse
+	(*GetResponse)(nil),              // 15: GetResponse
+	(*DeleteResponse)(nil),           // 16: DeleteResponse
+	(*BatchGetResult)(nil),           // 17: BatchGetResult
+	(*BatchGetResponse)(nil),         // 18: BatchGetResponse
+	(*BatchWriteResponse)(nil),       // 19: BatchWriteResponse
+	(*BulkLoadResponse)(nil),         // 20: BulkLoadResponse
+	(*IncrementResponse)(nil),        // 21: IncrementResponse
+	(*CompareAndSwapResponse)(nil),   // 22: CompareAndSwapResponse
+	(*ScanResponse)(nil),             // 23: ScanResponse
+	(*CountResponse)(nil),            // 24: CountResponse
+	(*DeleteRangeResponse)(nil),      // 25: DeleteRangeResponse
+	(*CompactResponse)(nil),          // 26: CompactResponse
+	(*WatchResponse)(nil),            // 27: WatchResponse
+	(*Stamp)(nil),                    // 28: Stamp
+	(*KeyMeta)(nil),                  // 29: KeyMeta
+	(*KeyState)(nil),                 // 30: KeyState
+	(*Compare)(nil),                  // 31: Compare
+	(*TxnOp)(nil),                    // 32: TxnOp
+	(*TxnRequest)(nil),               // 33: TxnRequest
+	(*TxnResponse)(nil),              // 34: TxnResponse
+	(*ReplicateResponse)(nil),        // 35: ReplicateResponse
+	(*Member)(nil),                   // 36: Member
+	(*PingRequest)(nil),              // 37: PingRequest
+	(*PingReqRequest)(nil),           // 38: PingReqRequest
+	(*PingResponse)(nil),             // 39: PingResponse
+	(*JoinRequest)(nil),              // 40: JoinRequest
+	(*JoinResponse)(nil),             // 41: JoinResponse
+	(*ShardGroup)(nil),               // 42: ShardGroup
+	(*KeyRange)(nil),                 // 43: KeyRange
+	(*ClusterMetadata)(nil),          // 44: ClusterMetadata
+	(*RebalanceRequest)(nil),         // 45: RebalanceRequest
+	(*RebalanceStatusRequest)(nil),   // 46: RebalanceStatusRequest
+	(*RangeMove)(nil),                // 47: RangeMove
+	(*RebalanceStatus)(nil),          // 48: RebalanceStatus
+	(*MoveRangeRequest)(nil),         // 49: MoveRangeRequest
+	(*MoveRangeResponse)(nil),        // 50: MoveRangeResponse
+	(*KeyValue)(nil),                 // 51: KeyValue
+	(*RangeChunk)(nil),               // 52: RangeChunk
+	(*KeyRecord)(nil),                // 53: KeyRecord
+	(*KeyVersion)(nil),               // 54: KeyVersion
+	(*CollectionValue)(nil),          // 55: CollectionValue
+	(*InstallRangeResponse)(nil),     // 56: InstallRangeResponse
+	(*VectorClock)(nil),              // 57: VectorClock
+	(*Sibling)(nil),                  // 58: Sibling
+	(*StoreRequest)(nil),             // 59: StoreRequest
+	(*StoreResponse)(nil),            // 60: StoreResponse
+	(*FetchRequest)(nil),             // 61: FetchRequest
+	(*FetchResponse)(nil),            // 62: FetchResponse
+	(*CounterIncrementRequest)(nil),  // 63: CounterIncrementRequest
+	(*SetRequest)(nil),               // 64: SetRequest
+	(*RegisterRequest)(nil),          // 65: RegisterRequest
+	(*MapRequest)(nil),               // 66: MapRequest
+	(*CRDTReadRequest)(nil),          // 67: CRDTReadRequest
+	(*CRDTValue)(nil),                // 68: CRDTValue
+	(*CRDTState)(nil),                // 69: CRDTState
+	(*CRDTStates)(nil),               // 70: CRDTStates
+	(*ChainWrite)(nil),               // 71: ChainWrite
+	(*ChainAck)(nil),                 // 72: ChainAck
+	(*LeaseGrantRequest)(nil),        // 73: LeaseGrantRequest
+	(*LeaseGrantResponse)(nil),       // 74: LeaseGrantResponse
+	(*LeaseRevokeRequest)(nil),       // 75: LeaseRevokeRequest
+	(*LeaseRevokeResponse)(nil),      // 76: LeaseRevokeResponse
+	(*LeaseKeepAliveRequest)(nil),    // 77: LeaseKeepAliveRequest
+	(*LeaseKeepAliveResponse)(nil),   // 78: LeaseKeepAliveResponse
+	(*LeaseTimeToLiveRequest)(nil),   // 79: LeaseTimeToLiveRequest
+	(*LeaseTimeToLiveResponse)(nil),  // 80: LeaseTimeToLiveResponse
+	(*LockRequest)(nil),              // 81: LockRequest
+	(*LockResponse)(nil),             // 82: LockResponse
+	(*UnlockRequest)(nil),            // 83: UnlockRequest
+	(*UnlockResponse)(nil),           // 84: UnlockResponse
+	(*LeaderKey)(nil),                // 85: LeaderKey
+	(*CampaignRequest)(nil),          // 86: CampaignRequest
+	(*CampaignResponse)(nil),         // 87: CampaignResponse
+	(*ProclaimRequest)(nil),          // 88: ProclaimRequest
+	(*ProclaimResponse)(nil),         // 89: ProclaimResponse
+	(*ResignRequest)(nil),            // 90: ResignRequest
+	(*ResignResponse)(nil),           // 91: ResignResponse
+	(*LeaderRequest)(nil),            // 92: LeaderRequest
+	(*LeaderResponse)(nil),           // 93: LeaderResponse
+	(*ListPushRequest)(nil),          // 94: ListPushRequest
+	(*ListPopRequest)(nil),           // 95: ListPopRequest
+	(*ListRangeRequest)(nil),         // 96: ListRangeRequest
+	(*MembersRequest)(nil),           // 97: MembersRequest
+	(*CollectionKeyRequest)(nil),     // 98: CollectionKeyRequest
+	(*SetIntersectRequest)(nil),      // 99: SetIntersectRequest
+	(*HashSetRequest)(nil),           // 100: HashSetRequest
+	(*HashFieldsRequest)(nil),        // 101: HashFieldsRequest
+	(*ScoredMember)(nil),             // 102: ScoredMember
+	(*ZSetAddRequest)(nil),           // 103: ZSetAddRequest
+	(*ZSetRangeRequest)(nil),         // 104: ZSetRangeRequest
+	(*ZSetRankRequest)(nil),          // 105: ZSetRankRequest
+	(*CollectionOp)(nil),             // 106: CollectionOp
+	(*CollectionReply)(nil),          // 107: CollectionReply
+	(*TailRequest)(nil),              // 108: TailRequest
+	(*LogEntry)(nil),                 // 109: LogEntry
+	(*StartReplicationRequest)(nil),  // 110: StartReplicationRequest
+	(*ReplicationStatusRequest)(nil), // 111: ReplicationStatusRequest
+	(*PromoteRequest)(nil),           // 112: PromoteRequest
+	(*ReplicationStatus)(nil),        // 113: ReplicationStatus
+	nil,                              // 114: TxnResponse.CurrentEntry
+	nil,                              // 115: RangeChunk.LeaseTtlMsEntry
+	nil,                              // 116: VectorClock.CountersEntry
+	nil,                              // 117: CRDTValue.EntriesEntry
+	nil,                              // 118: HashSetRequest.FieldsEntry
 }
 var file_kvstore_proto_depIdxs = []int32{
-	0, // 0: KVStore.Put:input_type -> PutRequest
-	1, // 1: KVStore.Get:input_type -> GetRequest
-	2, // 2: KVStore.Delete:input_type -> DeleteRequest
-	3, // 3: KVStore.Put:output_type -> PutResponse
-	4, // 4: KVStore.Get:output_type -> GetResponse
-	5, // 5: KVStore.Delete:output_type -> DeleteResponse
-	3, // [3:6] is the sub-list for method output_type
-	0, // [0:3] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	57,  // 0: PutRequest.context:type_name -> VectorClock
+	28,  // 1: PutRequest.stamp:type_name -> Stamp
+	57,  // 2: DeleteRequest.context:type_name -> VectorClock
+	28,  // 3: DeleteRequest.stamp:type_name -> Stamp
+	32,  // 4: BatchWriteRequest.ops:type_name -> TxnOp
+	51,  // 5: BulkLoadRequest.records:type_name -> KeyValue
+	57,  // 6: PutResponse.context:type_name -> VectorClock
+	30,  // 7: PutResponse.current:type_name -> KeyState
+	58,  // 8: GetResponse.siblings:type_name -> Sibling
+	57,  // 9: GetResponse.context:type_name -> VectorClock
+	29,  // 10: GetResponse.meta:type_name -> KeyMeta
+	57,  // 11: DeleteResponse.context:type_name -> VectorClock
+	30,  // 12: DeleteResponse.current:type_name -> KeyState
+	29,  // 13: BatchGetResult.meta:type_name -> KeyMeta
+	17,  // 14: BatchGetResponse.results:type_name -> BatchGetResult
+	30,  // 15: CompareAndSwapResponse.current:type_name -> KeyState
+	51,  // 16: ScanResponse.kvs:type_name -> KeyValue
+	51,  // 17: WatchResponse.events:type_name -> KeyValue
+	31,  // 18: TxnRequest.compare:type_name -> Compare
+	32,  // 19: TxnRequest.success:type_name -> TxnOp
+	32,  // 20: TxnRequest.failure:type_name -> TxnOp
+	28,  // 21: TxnRequest.stamp:type_name -> Stamp
+	114, // 22: TxnResponse.current:type_name -> TxnResponse.CurrentEntry
+	0,   // 23: Member.status:type_name -> MemberStatus
+	36,  // 24: PingRequest.from:type_name -> Member
+	36,  // 25: PingRequest.updates:type_name -> Member
+	44,  // 26: PingRequest.metadata:type_name -> ClusterMetadata
+	36,  // 27: PingReqRequest.from:type_name -> Member
+	36,  // 28: PingReqRequest.target:type_name -> Member
+	36,  // 29: PingReqRequest.updates:type_name -> Member
+	44,  // 30: PingReqRequest.metadata:type_name -> ClusterMetadata
+	36,  // 31: PingResponse.updates:type_name -> Member
+	44,  // 32: PingResponse.metadata:type_name -> ClusterMetadata
+	36,  // 33: JoinRequest.member:type_name -> Member
+	36,  // 34: JoinResponse.members:type_name -> Member
+	44,  // 35: JoinResponse.metadata:type_name -> ClusterMetadata
+	43,  // 36: ShardGroup.ranges:type_name -> KeyRange
+	42,  // 37: ClusterMetadata.shards:type_name -> ShardGroup
+	43,  // 38: RangeMove.range:type_name -> KeyRange
+	47,  // 39: RebalanceStatus.moves:type_name -> RangeMove
+	43,  // 40: MoveRangeRequest.range:type_name -> KeyRange
+	29,  // 41: KeyValue.meta:type_name -> KeyMeta
+	43,  // 42: RangeChunk.range:type_name -> KeyRange
+	53,  // 43: RangeChunk.records:type_name -> KeyRecord
+	115, // 44: RangeChunk.lease_ttl_ms:type_name -> RangeChunk.LeaseTtlMsEntry
+	28,  // 45: RangeChunk.stamp:type_name -> Stamp
+	54,  // 46: KeyRecord.history:type_name -> KeyVersion
+	55,  // 47: KeyRecord.collection:type_name -> CollectionValue
+	29,  // 48: KeyVersion.meta:type_name -> KeyMeta
+	42,  // 49: InstallRangeResponse.group:type_name -> ShardGroup
+	116, // 50: VectorClock.counters:type_name -> VectorClock.CountersEntry
+	57,  // 51: Sibling.clock:type_name -> VectorClock
+	58,  // 52: StoreRequest.siblings:type_name -> Sibling
+	58,  // 53: FetchResponse.siblings:type_name -> Sibling
+	117, // 54: CRDTValue.entries:type_name -> CRDTValue.EntriesEntry
+	69,  // 55: CRDTStates.states:type_name -> CRDTState
+	32,  // 56: ChainWrite.ops:type_name -> TxnOp
+	28,  // 57: ChainWrite.stamp:type_name -> Stamp
+	28,  // 58: LeaseRevokeRequest.stamp:type_name -> Stamp
+	85,  // 59: CampaignResponse.leader:type_name -> LeaderKey
+	85,  // 60: ProclaimRequest.leader:type_name -> LeaderKey
+	85,  // 61: ResignRequest.leader:type_name -> LeaderKey
+	85,  // 62: LeaderResponse.leader:type_name -> LeaderKey
+	118, // 63: HashSetRequest.fields:type_name -> HashSetRequest.FieldsEntry
+	102, // 64: ZSetAddRequest.members:type_name -> ScoredMember
+	32,  // 65: LogEntry.ops:type_name -> TxnOp
+	53,  // 66: LogEntry.records:type_name -> KeyRecord
+	30,  // 67: TxnResponse.CurrentEntry.value:type_name -> KeyState
+	1,   // 68: KVStore.Put:input_type -> PutRequest
+	2,   // 69: KVStore.Get:input_type -> GetRequest
+	3,   // 70: KVStore.Delete:input_type -> DeleteRequest
+	33,  // 71: KVStore.Txn:input_type -> TxnRequest
+	4,   // 72: KVStore.CompareAndSwap:input_type -> CompareAndSwapRequest
+	5,   // 73: KVStore.Scan:input_type -> ScanRequest
+	6,   // 74: KVStore.Count:input_type -> CountRequest
+	7,   // 75: KVStore.DeleteRange:input_type -> DeleteRangeRequest
+	8,   // 76: KVStore.Compact:input_type -> CompactRequest
+	9,   // 77: KVStore.Watch:input_type -> WatchRequest
+	10,  // 78: KVStore.BatchGet:input_type -> BatchGetRequest
+	11,  // 79: KVStore.BatchWrite:input_type -> BatchWriteRequest
+	12,  // 80: KVStore.BulkLoad:input_type -> BulkLoadRequest
+	13,  // 81: KVStore.Increment:input_type -> IncrementRequest
+	13,  // 82: KVStore.Decrement:input_type -> IncrementRequest
+	37,  // 83: Gossip.Ping:input_type -> PingRequest
+	38,  // 84: Gossip.PingReq:input_type -> PingReqRequest
+	40,  // 85: Gossip.Join:input_type -> JoinRequest
+	45,  // 86: Admin.PlanRebalance:input_type -> RebalanceRequest
+	45,  // 87: Admin.StartRebalance:input_type -> RebalanceRequest
+	46,  // 88: Admin.GetRebalanceStatus:input_type -> RebalanceStatusRequest
+	49,  // 89: Admin.MoveRange:input_type -> MoveRangeRequest
+	52,  // 90: Admin.InstallRange:input_type -> RangeChunk
+	110, // 91: Admin.StartReplication:input_type -> StartReplicationRequest
+	111, // 92: Admin.GetReplicationStatus:input_type -> ReplicationStatusRequest
+	112, // 93: Admin.Promote:input_type -> PromoteRequest
+	59,  // 94: Replica.Store:input_type -> StoreRequest
+	61,  // 95: Replica.Fetch:input_type -> FetchRequest
+	63,  // 96: CRDT.Increment:input_type -> CounterIncrementRequest
+	64,  // 97: CRDT.AddToSet:input_type -> SetRequest
+	64,  // 98: CRDT.RemoveFromSet:input_type -> SetRequest
+	65,  // 99: CRDT.SetRegister:input_type -> RegisterRequest
+	66,  // 100: CRDT.MapPut:input_type -> MapRequest
+	66,  // 101: CRDT.MapRemove:input_type -> MapRequest
+	67,  // 102: CRDT.Read:input_type -> CRDTReadRequest
+	70,  // 103: CRDT.Sync:input_type -> CRDTStates
+	71,  // 104: Chain.Propagate:input_type -> ChainWrite
+	2,   // 105: Chain.Read:input_type -> GetRequest
+	73,  // 106: Lease.Grant:input_type -> LeaseGrantRequest
+	75,  // 107: Lease.Revoke:input_type -> LeaseRevokeRequest
+	77,  // 108: Lease.KeepAlive:input_type -> LeaseKeepAliveRequest
+	79,  // 109: Lease.TimeToLive:input_type -> LeaseTimeToLiveRequest
+	77,  // 110: Lease.Renew:input_type -> LeaseKeepAliveRequest
+	81,  // 111: Lock.Lock:input_type -> LockRequest
+	83,  // 112: Lock.Unlock:input_type -> UnlockRequest
+	86,  // 113: Election.Campaign:input_type -> CampaignRequest
+	88,  // 114: Election.Proclaim:input_type -> ProclaimRequest
+	90,  // 115: Election.Resign:input_type -> ResignRequest
+	92,  // 116: Election.Leader:input_type -> LeaderRequest
+	92,  // 117: Election.Observe:input_type -> LeaderRequest
+	94,  // 118: Collections.ListPush:input_type -> ListPushRequest
+	95,  // 119: Collections.ListPop:input_type -> ListPopRequest
+	96,  // 120: Collections.ListRange:input_type -> ListRangeRequest
+	97,  // 121: Collections.SetAdd:input_type -> MembersRequest
+	97,  // 122: Collections.SetRemove:input_type -> MembersRequest
+	98,  // 123: Collections.SetMembers:input_type -> CollectionKeyRequest
+	99,  // 124: Collections.SetIntersect:input_type -> SetIntersectRequest
+	100, // 125: Collections.HashSet:input_type -> HashSetRequest
+	101, // 126: Collections.HashGet:input_type -> HashFieldsRequest
+	101, // 127: Collections.HashDelete:input_type -> HashFieldsRequest
+	98,  // 128: Collections.HashGetAll:input_type -> CollectionKeyRequest
+	103, // 129: Collections.ZSetAdd:input_type -> ZSetAddRequest
+	97,  // 130: Collections.ZSetRemove:input_type -> MembersRequest
+	104, // 131: Collections.ZSetRangeByScore:input_type -> ZSetRangeRequest
+	105, // 132: Collections.ZSetRank:input_type -> ZSetRankRequest
+	106, // 133: Collections.Apply:input_type -> CollectionOp
+	108, // 134: OpLog.Tail:input_type -> TailRequest
+	14,  // 135: KVStore.Put:output_type -> PutResponse
+	15,  // 136: KVStore.Get:output_type -> GetResponse
+	16,  // 137: KVStore.Delete:output_type -> DeleteResponse
+	34,  // 138: KVStore.Txn:output_type -> TxnResponse
+	22,  // 139: KVStore.CompareAndSwap:output_type -> CompareAndSwapResponse
+	23,  // 140: KVStore.Scan:output_type -> ScanResponse
+	24,  // 141: KVStore.Count:output_type -> CountResponse
+	25,  // 142: KVStore.DeleteRange:output_type -> DeleteRangeResponse
+	26,  // 143: KVStore.Compact:output_type -> CompactResponse
+	27,  // 144: KVStore.Watch:output_type -> WatchResponse
+	18,  // 145: KVStore.BatchGet:output_type -> BatchGetResponse
+	19,  // 146: KVStore.BatchWrite:output_type -> BatchWriteResponse
+	20,  // 147: KVStore.BulkLoad:output_type -> BulkLoadResponse
+	21,  // 148: KVStore.Increment:output_type -> IncrementResponse
+	21,  // 149: KVStore.Decrement:output_type -> IncrementResponse
+	39,  // 150: Gossip.Ping:output_type -> PingResponse
+	39,  // 151: Gossip.PingReq:output_type -> PingResponse
+	41,  // 152: Gossip.Join:output_type -> JoinResponse
+	48,  // 153: Admin.PlanRebalance:output_type -> RebalanceStatus
+	48,  // 154: Admin.StartRebalance:output_type -> RebalanceStatus
+	48,  // 155: Admin.GetRebalanceStatus:output_type -> RebalanceStatus
+	50,  // 156: Admin.MoveRange:output_type -> MoveRangeResponse
+	56,  // 157: Admin.InstallRange:output_type -> InstallRangeResponse
+	113, // 158: Admin.StartReplication:output_type -> ReplicationStatus
+	113, // 159: Admin.GetReplicationStatus:output_type -> ReplicationStatus
+	113, // 160: Admin.Promote:output_type -> ReplicationStatus
+	60,  // 161: Replica.Store:output_type -> StoreResponse
+	62,  // 162: Replica.Fetch:output_type -> FetchResponse
+	68,  // 163: CRDT.Increment:output_type -> CRDTValue
+	68,  // 164: CRDT.AddToSet:output_type -> CRDTValue
+	68,  // 165: CRDT.RemoveFromSet:output_type -> CRDTValue
+	68,  // 166: CRDT.SetRegister:output_type -> CRDTValue
+	68,  // 167: CRDT.MapPut:output_type -> CRDTValue
+	68,  // 168: CRDT.MapRemove:output_type -> CRDTValue
+	68,  // 169: CRDT.Read:output_type -> CRDTValue
+	70,  // 170: CRDT.Sync:output_type -> CRDTStates
+	72,  // 171: Chain.Propagate:output_type -> ChainAck
+	15,  // 172: Chain.Read:output_type -> GetResponse
+	74,  // 173: Lease.Grant:output_type -> LeaseGrantResponse
+	76,  // 174: Lease.Revoke:output_type -> LeaseRevokeResponse
+	78,  // 175: Lease.KeepAlive:output_type -> LeaseKeepAliveResponse
+	80,  // 176: Lease.TimeToLive:output_type -> LeaseTimeToLiveResponse
+	78,  // 177: Lease.Renew:output_type -> LeaseKeepAliveResponse
+	82,  // 178: Lock.Lock:output_type -> LockResponse
+	84,  // 179: Lock.Unlock:output_type -> UnlockResponse
+	87,  // 180: Election.Campaign:output_type -> CampaignResponse
+	89,  // 181: Election.Proclaim:output_type -> ProclaimResponse
+	91,  // 182: Election.Resign:output_type -> ResignResponse
+	93,  // 183: Election.Leader:output_type -> LeaderResponse
+	93,  // 184: Election.Observe:output_type -> LeaderResponse
+	107, // 185: Collections.ListPush:output_type -> CollectionReply
+	107, // 186: Collections.ListPop:output_type -> CollectionReply
+	107, // 187: Collections.ListRange:output_type -> CollectionReply
+	107, // 188: Collections.SetAdd:output_type -> CollectionReply
+	107, // 189: Collections.SetRemove:output_type -> CollectionReply
+	107, // 190: Collections.SetMembers:output_type -> CollectionReply
+	107, // 191: Collections.SetIntersect:output_type -> CollectionReply
+	107, // 192: Collections.HashSet:output_type -> CollectionReply
+	107, // 193: Collections.HashGet:output_type -> CollectionReply
+	107, // 194: Collections.HashDelete:output_type -> CollectionReply
+	107, // 195: Collections.HashGetAll:output_type -> CollectionReply
+	107, // 196: Collections.ZSetAdd:output_type -> CollectionReply
+	107, // 197: Collections.ZSetRemove:output_type -> CollectionReply
+	107, // 198: Collections.ZSetRangeByScore:output_type -> CollectionReply
+	107, // 199: Collections.ZSetRank:output_type -> CollectionReply
+	107, // 200: Collections.Apply:output_type -> CollectionReply
+	109, // 201: OpLog.Tail:output_type -> LogEntry
+	135, // [135:202] is the sub-list for method output_type
+	68,  // [68:135] is the sub-list for method input_type
+	68,  // [68:68] is the sub-list for extension type_name
+	68,  // [68:68] is the sub-list for extension extendee
+	0,   // [0:68] is the sub-list for field type_name
 }
 
 func init() { file_kvstore_proto_init() }
@@ -412,13 +7946,14 @@ func file_kvstore_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_kvstore_proto_rawDesc), len(file_kvstore_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      1,
+			NumMessages:   118,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   11,
 		},
 		GoTypes:           file_kvstore_proto_goTypes,
 		DependencyIndexes: file_kvstore_proto_depIdxs,
+		EnumInfos:         file_kvstore_proto_enumTypes,
 		MessageInfos:      file_kvstore_proto_msgTypes,
 	}.Build()
 	File_kvstore_proto = out.File
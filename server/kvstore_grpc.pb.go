@@ -19,9 +19,21 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	KVStore_Put_FullMethodName    = "/KVStore/Put"
-	KVStore_Get_FullMethodName    = "/KVStore/Get"
-	KVStore_Delete_FullMethodName = "/KVStore/Delete"
+	KVStore_Put_FullMethodName            = "/KVStore/Put"
+	KVStore_Get_FullMethodName            = "/KVStore/Get"
+	KVStore_Delete_FullMethodName         = "/KVStore/Delete"
+	KVStore_Txn_FullMethodName            = "/KVStore/Txn"
+	KVStore_CompareAndSwap_FullMethodName = "/KVStore/CompareAndSwap"
+	KVStore_Scan_FullMethodName           = "/KVStore/Scan"
+	KVStore_Count_FullMethodName          = "/KVStore/Count"
+	KVStore_DeleteRange_FullMethodName    = "/KVStore/DeleteRange"
+	KVStore_Compact_FullMethodName        = "/KVStore/Compact"
+	KVStore_Watch_FullMethodName          = "/KVStore/Watch"
+	KVStore_BatchGet_FullMethodName       = "/KVStore/BatchGet"
+	KVStore_BatchWrite_FullMethodName     = "/KVStore/BatchWrite"
+	KVStore_BulkLoad_FullMethodName       = "/KVStore/BulkLoad"
+	KVStore_Increment_FullMethodName      = "/KVStore/Increment"
+	KVStore_Decrement_FullMethodName      = "/KVStore/Decrement"
 )
 
 // KVStoreClient is the client API for KVStore service.
@@ -32,6 +44,27 @@ type KVStoreClient interface {
 	Put(ctx context.Context, in *PutRequest, opts ...grpc.CallOption) (*PutResponse, error)
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	Txn(ctx context.Context, in *TxnRequest, opts ...grpc.CallOption) (*TxnResponse, error)
+	CompareAndSwap(ctx context.Context, in *CompareAndSwapRequest, opts ...grpc.CallOption) (*CompareAndSwapResponse, error)
+	Scan(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (*ScanResponse, error)
+	// Counts the keys of the cluster, or of the serving group only
+	Count(ctx context.Context, in *CountRequest, opts ...grpc.CallOption) (*CountResponse, error)
+	// Deletes the keys of [start, end) in every group, or in the serving group only
+	DeleteRange(ctx context.Context, in *DeleteRangeRequest, opts ...grpc.CallOption) (*DeleteRangeResponse, error)
+	// Discards the history of the serving group before a revision
+	Compact(ctx context.Context, in *CompactRequest, opts ...grpc.CallOption) (*CompactResponse, error)
+	// Streams the changes to a key, prefix or range
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchResponse], error)
+	// Reads many keys in one call, reporting each as found or missing
+	BatchGet(ctx context.Context, in *BatchGetRequest, opts ...grpc.CallOption) (*BatchGetResponse, error)
+	// Applies many puts and deletes, as one replicated entry per shard
+	BatchWrite(ctx context.Context, in *BatchWriteRequest, opts ...grpc.CallOption) (*BatchWriteResponse, error)
+	// Loads a stream of records in large batches, answering with the counts
+	BulkLoad(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[BulkLoadRequest, BulkLoadResponse], error)
+	// Add to or subtract from the integer held by a key, a missing key
+	// counting as 0, and return the result
+	Increment(ctx context.Context, in *IncrementRequest, opts ...grpc.CallOption) (*IncrementResponse, error)
+	Decrement(ctx context.Context, in *IncrementRequest, opts ...grpc.CallOption) (*IncrementResponse, error)
 }
 
 type kVStoreClient struct {
@@ -72,6 +105,138 @@ func (c *kVStoreClient) Delete(ctx context.Context, in *DeleteRequest, opts ...g
 	return out, nil
 }
 
+func (c *kVStoreClient) Txn(ctx context.Context, in *TxnRequest, opts ...grpc.CallOption) (*TxnResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TxnResponse)
+	err := c.cc.Invoke(ctx, KVStore_Txn_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVStoreClient) CompareAndSwap(ctx context.Context, in *CompareAndSwapRequest, opts ...grpc.CallOption) (*CompareAndSwapResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompareAndSwapResponse)
+	err := c.cc.Invoke(ctx, KVStore_CompareAndSwap_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVStoreClient) Scan(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (*ScanResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScanResponse)
+	err := c.cc.Invoke(ctx, KVStore_Scan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVStoreClient) Count(ctx context.Context, in *CountRequest, opts ...grpc.CallOption) (*CountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountResponse)
+	err := c.cc.Invoke(ctx, KVStore_Count_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVStoreClient) DeleteRange(ctx context.Context, in *DeleteRangeRequest, opts ...grpc.CallOption) (*DeleteRangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteRangeResponse)
+	err := c.cc.Invoke(ctx, KVStore_DeleteRange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVStoreClient) Compact(ctx context.Context, in *CompactRequest, opts ...grpc.CallOption) (*CompactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompactResponse)
+	err := c.cc.Invoke(ctx, KVStore_Compact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVStoreClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &KVStore_ServiceDesc.Streams[0], KVStore_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, WatchResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KVStore_WatchClient = grpc.ServerStreamingClient[WatchResponse]
+
+func (c *kVStoreClient) BatchGet(ctx context.Context, in *BatchGetRequest, opts ...grpc.CallOption) (*BatchGetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetResponse)
+	err := c.cc.Invoke(ctx, KVStore_BatchGet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVStoreClient) BatchWrite(ctx context.Context, in *BatchWriteRequest, opts ...grpc.CallOption) (*BatchWriteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchWriteResponse)
+	err := c.cc.Invoke(ctx, KVStore_BatchWrite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVStoreClient) BulkLoad(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[BulkLoadRequest, BulkLoadResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &KVStore_ServiceDesc.Streams[1], KVStore_BulkLoad_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[BulkLoadRequest, BulkLoadResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KVStore_BulkLoadClient = grpc.ClientStreamingClient[BulkLoadRequest, BulkLoadResponse]
+
+func (c *kVStoreClient) Increment(ctx context.Context, in *IncrementRequest, opts ...grpc.CallOption) (*IncrementResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IncrementResponse)
+	err := c.cc.Invoke(ctx, KVStore_Increment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVStoreClient) Decrement(ctx context.Context, in *IncrementRequest, opts ...grpc.CallOption) (*IncrementResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IncrementResponse)
+	err := c.cc.Invoke(ctx, KVStore_Decrement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // KVStoreServer is the server API for KVStore service.
 // All implementations must embed UnimplementedKVStoreServer
 // for forward compatibility.
@@ -80,6 +245,27 @@ type KVStoreServer interface {
 	Put(context.Context, *PutRequest) (*PutResponse, error)
 	Get(context.Context, *GetRequest) (*GetResponse, error)
 	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	Txn(context.Context, *TxnRequest) (*TxnResponse, error)
+	CompareAndSwap(context.Context, *CompareAndSwapRequest) (*CompareAndSwapResponse, error)
+	Scan(context.Context, *ScanRequest) (*ScanResponse, error)
+	// Counts the keys of the cluster, or of the serving group only
+	Count(context.Context, *CountRequest) (*CountResponse, error)
+	// Deletes the keys of [start, end) in every group, or in the serving group only
+	DeleteRange(context.Context, *DeleteRangeRequest) (*DeleteRangeResponse, error)
+	// Discards the history of the serving group before a revision
+	Compact(context.Context, *CompactRequest) (*CompactResponse, error)
+	// Streams the changes to a key, prefix or range
+	Watch(*WatchRequest, grpc.ServerStreamingServer[WatchResponse]) error
+	// Reads many keys in one call, reporting each as found or missing
+	BatchGet(context.Context, *BatchGetRequest) (*BatchGetResponse, error)
+	// Applies many puts and deletes, as one replicated entry per shard
+	BatchWrite(context.Context, *BatchWriteRequest) (*BatchWriteResponse, error)
+	// Loads a stream of records in large batches, answering with the counts
+	BulkLoad(grpc.ClientStreamingServer[BulkLoadRequest, BulkLoadResponse]) error
+	// Add to or subtract from the integer held by a key, a missing key
+	// counting as 0, and return the result
+	Increment(context.Context, *IncrementRequest) (*IncrementResponse, error)
+	Decrement(context.Context, *IncrementRequest) (*IncrementResponse, error)
 	mustEmbedUnimplementedKVStoreServer()
 }
 
@@ -99,6 +285,42 @@ func (UnimplementedKVStoreServer) Get(context.Context, *GetRequest) (*GetRespons
 func (UnimplementedKVStoreServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedKVStoreServer) Txn(context.Context, *TxnRequest) (*TxnResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Txn not implemented")
+}
+func (UnimplementedKVStoreServer) CompareAndSwap(context.Context, *CompareAndSwapRequest) (*CompareAndSwapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompareAndSwap not implemented")
+}
+func (UnimplementedKVStoreServer) Scan(context.Context, *ScanRequest) (*ScanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Scan not implemented")
+}
+func (UnimplementedKVStoreServer) Count(context.Context, *CountRequest) (*CountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Count not implemented")
+}
+func (UnimplementedKVStoreServer) DeleteRange(context.Context, *DeleteRangeRequest) (*DeleteRangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRange not implemented")
+}
+func (UnimplementedKVStoreServer) Compact(context.Context, *CompactRequest) (*CompactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Compact not implemented")
+}
+func (UnimplementedKVStoreServer) Watch(*WatchRequest, grpc.ServerStreamingServer[WatchResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedKVStoreServer) BatchGet(context.Context, *BatchGetRequest) (*BatchGetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGet not implemented")
+}
+func (UnimplementedKVStoreServer) BatchWrite(context.Context, *BatchWriteRequest) (*BatchWriteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchWrite not implemented")
+}
+func (UnimplementedKVStoreServer) BulkLoad(grpc.ClientStreamingServer[BulkLoadRequest, BulkLoadResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method BulkLoad not implemented")
+}
+func (UnimplementedKVStoreServer) Increment(context.Context, *IncrementRequest) (*IncrementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Increment not implemented")
+}
+func (UnimplementedKVStoreServer) Decrement(context.Context, *IncrementRequest) (*IncrementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Decrement not implemented")
+}
 func (UnimplementedKVStoreServer) mustEmbedUnimplementedKVStoreServer() {}
 func (UnimplementedKVStoreServer) testEmbeddedByValue()                 {}
 
@@ -174,6 +396,204 @@ func _KVStore_Delete_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _KVStore_Txn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVStoreServer).Txn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVStore_Txn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVStoreServer).Txn(ctx, req.(*TxnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVStore_CompareAndSwap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompareAndSwapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVStoreServer).CompareAndSwap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVStore_CompareAndSwap_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVStoreServer).CompareAndSwap(ctx, req.(*CompareAndSwapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVStore_Scan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVStoreServer).Scan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVStore_Scan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVStoreServer).Scan(ctx, req.(*ScanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVStore_Count_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVStoreServer).Count(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVStore_Count_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVStoreServer).Count(ctx, req.(*CountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVStore_DeleteRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVStoreServer).DeleteRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVStore_DeleteRange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVStoreServer).DeleteRange(ctx, req.(*DeleteRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVStore_Compact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVStoreServer).Compact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVStore_Compact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVStoreServer).Compact(ctx, req.(*CompactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVStore_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(KVStoreServer).Watch(m, &grpc.GenericServerStream[WatchRequest, WatchResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KVStore_WatchServer = grpc.ServerStreamingServer[WatchResponse]
+
+func _KVStore_BatchGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVStoreServer).BatchGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVStore_BatchGet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVStoreServer).BatchGet(ctx, req.(*BatchGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVStore_BatchWrite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchWriteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVStoreServer).BatchWrite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVStore_BatchWrite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVStoreServer).BatchWrite(ctx, req.(*BatchWriteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVStore_BulkLoad_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(KVStoreServer).BulkLoad(&grpc.GenericServerStream[BulkLoadRequest, BulkLoadResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KVStore_BulkLoadServer = grpc.ClientStreamingServer[BulkLoadRequest, BulkLoadResponse]
+
+func _KVStore_Increment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IncrementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVStoreServer).Increment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVStore_Increment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVStoreServer).Increment(ctx, req.(*IncrementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KVStore_Decrement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IncrementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVStoreServer).Decrement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KVStore_Decrement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVStoreServer).Decrement(ctx, req.(*IncrementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // KVStore_ServiceDesc is the grpc.ServiceDesc for KVStore service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -193,7 +613,2736 @@ var KVStore_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _KVStore_Delete_Handler,
 		},
-	},
-	Streams:  []grpc.StreamDesc{},
+		{
+			MethodName: "Txn",
+			Handler:    _KVStore_Txn_Handler,
+		},
+		{
+			MethodName: "CompareAndSwap",
+			Handler:    _KVStore_CompareAndSwap_Handler,
+		},
+		{
+			MethodName: "Scan",
+			Handler:    _KVStore_Scan_Handler,
+		},
+		{
+			MethodName: "Count",
+			Handler:    _KVStore_Count_Handler,
+		},
+		{
+			MethodName: "DeleteRange",
+			Handler:    _KVStore_DeleteRange_Handler,
+		},
+		{
+			MethodName: "Compact",
+			Handler:    _KVStore_Compact_Handler,
+		},
+		{
+			MethodName: "BatchGet",
+			Handler:    _KVStore_BatchGet_Handler,
+		},
+		{
+			MethodName: "BatchWrite",
+			Handler:    _KVStore_BatchWrite_Handler,
+		},
+		{
+			MethodName: "Increment",
+			Handler:    _KVStore_Increment_Handler,
+		},
+		{
+			MethodName: "Decrement",
+			Handler:    _KVStore_Decrement_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _KVStore_Watch_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "BulkLoad",
+			Handler:       _KVStore_BulkLoad_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "kvstore.proto",
+}
+
+const (
+	Gossip_Ping_FullMethodName    = "/Gossip/Ping"
+	Gossip_PingReq_FullMethodName = "/Gossip/PingReq"
+	Gossip_Join_FullMethodName    = "/Gossip/Join"
+)
+
+// GossipClient is the client API for Gossip service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Internal SWIM protocol used by nodes for membership and failure detection
+type GossipClient interface {
+	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
+	PingReq(ctx context.Context, in *PingReqRequest, opts ...grpc.CallOption) (*PingResponse, error)
+	Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error)
+}
+
+type gossipClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGossipClient(cc grpc.ClientConnInterface) GossipClient {
+	return &gossipClient{cc}
+}
+
+func (c *gossipClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PingResponse)
+	err := c.cc.Invoke(ctx, Gossip_Ping_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gossipClient) PingReq(ctx context.Context, in *PingReqRequest, opts ...grpc.CallOption) (*PingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PingResponse)
+	err := c.cc.Invoke(ctx, Gossip_PingReq_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gossipClient) Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JoinResponse)
+	err := c.cc.Invoke(ctx, Gossip_Join_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GossipServer is the server API for Gossip service.
+// All implementations must embed UnimplementedGossipServer
+// for forward compatibility.
+//
+// Internal SWIM protocol used by nodes for membership and failure detection
+type GossipServer interface {
+	Ping(context.Context, *PingRequest) (*PingResponse, error)
+	PingReq(context.Context, *PingReqRequest) (*PingResponse, error)
+	Join(context.Context, *JoinRequest) (*JoinResponse, error)
+	mustEmbedUnimplementedGossipServer()
+}
+
+// UnimplementedGossipServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGossipServer struct{}
+
+func (UnimplementedGossipServer) Ping(context.Context, *PingRequest) (*PingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
+}
+func (UnimplementedGossipServer) PingReq(context.Context, *PingReqRequest) (*PingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PingReq not implemented")
+}
+func (UnimplementedGossipServer) Join(context.Context, *JoinRequest) (*JoinResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Join not implemented")
+}
+func (UnimplementedGossipServer) mustEmbedUnimplementedGossipServer() {}
+func (UnimplementedGossipServer) testEmbeddedByValue()                {}
+
+// UnsafeGossipServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GossipServer will
+// result in compilation errors.
+type UnsafeGossipServer interface {
+	mustEmbedUnimplementedGossipServer()
+}
+
+func RegisterGossipServer(s grpc.ServiceRegistrar, srv GossipServer) {
+	// If the following call pancis, it indicates UnimplementedGossipServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Gossip_ServiceDesc, srv)
+}
+
+func _Gossip_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GossipServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Gossip_Ping_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GossipServer).Ping(ctx, req.(*PingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gossip_PingReq_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PingReqRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GossipServer).PingReq(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Gossip_PingReq_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GossipServer).PingReq(ctx, req.(*PingReqRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gossip_Join_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GossipServer).Join(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Gossip_Join_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GossipServer).Join(ctx, req.(*JoinRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Gossip_ServiceDesc is the grpc.ServiceDesc for Gossip service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Gossip_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "Gossip",
+	HandlerType: (*GossipServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Ping",
+			Handler:    _Gossip_Ping_Handler,
+		},
+		{
+			MethodName: "PingReq",
+			Handler:    _Gossip_PingReq_Handler,
+		},
+		{
+			MethodName: "Join",
+			Handler:    _Gossip_Join_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "kvstore.proto",
+}
+
+const (
+	Admin_PlanRebalance_FullMethodName        = "/Admin/PlanRebalance"
+	Admin_StartRebalance_FullMethodName       = "/Admin/StartRebalance"
+	Admin_GetRebalanceStatus_FullMethodName   = "/Admin/GetRebalanceStatus"
+	Admin_MoveRange_FullMethodName            = "/Admin/MoveRange"
+	Admin_InstallRange_FullMethodName         = "/Admin/InstallRange"
+	Admin_StartReplication_FullMethodName     = "/Admin/StartReplication"
+	Admin_GetReplicationStatus_FullMethodName = "/Admin/GetReplicationStatus"
+	Admin_Promote_FullMethodName              = "/Admin/Promote"
+)
+
+// AdminClient is the client API for Admin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Operator APIs for moving data between replica groups
+type AdminClient interface {
+	PlanRebalance(ctx context.Context, in *RebalanceRequest, opts ...grpc.CallOption) (*RebalanceStatus, error)
+	StartRebalance(ctx context.Context, in *RebalanceRequest, opts ...grpc.CallOption) (*RebalanceStatus, error)
+	GetRebalanceStatus(ctx context.Context, in *RebalanceStatusRequest, opts ...grpc.CallOption) (*RebalanceStatus, error)
+	// Internal: asks the source group leader to hand a range to another group
+	MoveRange(ctx context.Context, in *MoveRangeRequest, opts ...grpc.CallOption) (*MoveRangeResponse, error)
+	// Internal: snapshot and catch-up stream from the source to the target leader
+	InstallRange(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[RangeChunk, InstallRangeResponse], error)
+	// Cross-cluster replication, called on a node of the standby cluster
+	StartReplication(ctx context.Context, in *StartReplicationRequest, opts ...grpc.CallOption) (*ReplicationStatus, error)
+	GetReplicationStatus(ctx context.Context, in *ReplicationStatusRequest, opts ...grpc.CallOption) (*ReplicationStatus, error)
+	// Stops the link and makes the standby cluster writable
+	Promote(ctx context.Context, in *PromoteRequest, opts ...grpc.CallOption) (*ReplicationStatus, error)
+}
+
+type adminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminClient(cc grpc.ClientConnInterface) AdminClient {
+	return &adminClient{cc}
+}
+
+func (c *adminClient) PlanRebalance(ctx context.Context, in *RebalanceRequest, opts ...grpc.CallOption) (*RebalanceStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RebalanceStatus)
+	err := c.cc.Invoke(ctx, Admin_PlanRebalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) StartRebalance(ctx context.Context, in *RebalanceRequest, opts ...grpc.CallOption) (*RebalanceStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RebalanceStatus)
+	err := c.cc.Invoke(ctx, Admin_StartRebalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetRebalanceStatus(ctx context.Context, in *RebalanceStatusRequest, opts ...grpc.CallOption) (*RebalanceStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RebalanceStatus)
+	err := c.cc.Invoke(ctx, Admin_GetRebalanceStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) MoveRange(ctx context.Context, in *MoveRangeRequest, opts ...grpc.CallOption) (*MoveRangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveRangeResponse)
+	err := c.cc.Invoke(ctx, Admin_MoveRange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) InstallRange(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[RangeChunk, InstallRangeResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Admin_ServiceDesc.Streams[0], Admin_InstallRange_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[RangeChunk, InstallRangeResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Admin_InstallRangeClient = grpc.ClientStreamingClient[RangeChunk, InstallRangeResponse]
+
+func (c *adminClient) StartReplication(ctx context.Context, in *StartReplicationRequest, opts ...grpc.CallOption) (*ReplicationStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReplicationStatus)
+	err := c.cc.Invoke(ctx, Admin_StartReplication_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetReplicationStatus(ctx context.Context, in *ReplicationStatusRequest, opts ...grpc.CallOption) (*ReplicationStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReplicationStatus)
+	err := c.cc.Invoke(ctx, Admin_GetReplicationStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) Promote(ctx context.Context, in *PromoteRequest, opts ...grpc.CallOption) (*ReplicationStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReplicationStatus)
+	err := c.cc.Invoke(ctx, Admin_Promote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServer is the server API for Admin service.
+// All implementations must embed UnimplementedAdminServer
+// for forward compatibility.
+//
+// Operator APIs for moving data between replica groups
+type AdminServer interface {
+	PlanRebalance(context.Context, *RebalanceRequest) (*RebalanceStatus, error)
+	StartRebalance(context.Context, *RebalanceRequest) (*RebalanceStatus, error)
+	GetRebalanceStatus(context.Context, *RebalanceStatusRequest) (*RebalanceStatus, error)
+	// Internal: asks the source group leader to hand a range to another group
+	MoveRange(context.Context, *MoveRangeRequest) (*MoveRangeResponse, error)
+	// Internal: snapshot and catch-up stream from the source to the target leader
+	InstallRange(grpc.ClientStreamingServer[RangeChunk, InstallRangeResponse]) error
+	// Cross-cluster replication, called on a node of the standby cluster
+	StartReplication(context.Context, *StartReplicationRequest) (*ReplicationStatus, error)
+	GetReplicationStatus(context.Context, *ReplicationStatusRequest) (*ReplicationStatus, error)
+	// Stops the link and makes the standby cluster writable
+	Promote(context.Context, *PromoteRequest) (*ReplicationStatus, error)
+	mustEmbedUnimplementedAdminServer()
+}
+
+// UnimplementedAdminServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAdminServer struct{}
+
+func (UnimplementedAdminServer) PlanRebalance(context.Context, *RebalanceRequest) (*RebalanceStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlanRebalance not implemented")
+}
+func (UnimplementedAdminServer) StartRebalance(context.Context, *RebalanceRequest) (*RebalanceStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartRebalance not implemented")
+}
+func (UnimplementedAdminServer) GetRebalanceStatus(context.Context, *RebalanceStatusRequest) (*RebalanceStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRebalanceStatus not implemented")
+}
+func (UnimplementedAdminServer) MoveRange(context.Context, *MoveRangeRequest) (*MoveRangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveRange not implemented")
+}
+func (UnimplementedAdminServer) InstallRange(grpc.ClientStreamingServer[RangeChunk, InstallRangeResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method InstallRange not implemented")
+}
+func (UnimplementedAdminServer) StartReplication(context.Context, *StartReplicationRequest) (*ReplicationStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartReplication not implemented")
+}
+func (UnimplementedAdminServer) GetReplicationStatus(context.Context, *ReplicationStatusRequest) (*ReplicationStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReplicationStatus not implemented")
+}
+func (UnimplementedAdminServer) Promote(context.Context, *PromoteRequest) (*ReplicationStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Promote not implemented")
+}
+func (UnimplementedAdminServer) mustEmbedUnimplementedAdminServer() {}
+func (UnimplementedAdminServer) testEmbeddedByValue()               {}
+
+// UnsafeAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServer will
+// result in compilation errors.
+type UnsafeAdminServer interface {
+	mustEmbedUnimplementedAdminServer()
+}
+
+func RegisterAdminServer(s grpc.ServiceRegistrar, srv AdminServer) {
+	// If the following call pancis, it indicates UnimplementedAdminServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Admin_ServiceDesc, srv)
+}
+
+func _Admin_PlanRebalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RebalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).PlanRebalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_PlanRebalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).PlanRebalance(ctx, req.(*RebalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_StartRebalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RebalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).StartRebalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_StartRebalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).StartRebalance(ctx, req.(*RebalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetRebalanceStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RebalanceStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetRebalanceStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_GetRebalanceStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetRebalanceStatus(ctx, req.(*RebalanceStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_MoveRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).MoveRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_MoveRange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).MoveRange(ctx, req.(*MoveRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_InstallRange_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AdminServer).InstallRange(&grpc.GenericServerStream[RangeChunk, InstallRangeResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Admin_InstallRangeServer = grpc.ClientStreamingServer[RangeChunk, InstallRangeResponse]
+
+func _Admin_StartReplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartReplicationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).StartReplication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_StartReplication_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).StartReplication(ctx, req.(*StartReplicationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetReplicationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplicationStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetReplicationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_GetReplicationStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetReplicationStatus(ctx, req.(*ReplicationStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_Promote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).Promote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_Promote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).Promote(ctx, req.(*PromoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Admin_ServiceDesc is the grpc.ServiceDesc for Admin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Admin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "Admin",
+	HandlerType: (*AdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PlanRebalance",
+			Handler:    _Admin_PlanRebalance_Handler,
+		},
+		{
+			MethodName: "StartRebalance",
+			Handler:    _Admin_StartRebalance_Handler,
+		},
+		{
+			MethodName: "GetRebalanceStatus",
+			Handler:    _Admin_GetRebalanceStatus_Handler,
+		},
+		{
+			MethodName: "MoveRange",
+			Handler:    _Admin_MoveRange_Handler,
+		},
+		{
+			MethodName: "StartReplication",
+			Handler:    _Admin_StartReplication_Handler,
+		},
+		{
+			MethodName: "GetReplicationStatus",
+			Handler:    _Admin_GetReplicationStatus_Handler,
+		},
+		{
+			MethodName: "Promote",
+			Handler:    _Admin_Promote_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "InstallRange",
+			Handler:       _Admin_InstallRange_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "kvstore.proto",
+}
+
+const (
+	Replica_Store_FullMethodName = "/Replica/Store"
+	Replica_Fetch_FullMethodName = "/Replica/Fetch"
+)
+
+// ReplicaClient is the client API for Replica service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Internal replica-to-replica API of the leaderless replication mode
+type ReplicaClient interface {
+	Store(ctx context.Context, in *StoreRequest, opts ...grpc.CallOption) (*StoreResponse, error)
+	Fetch(ctx context.Context, in *FetchRequest, opts ...grpc.CallOption) (*FetchResponse, error)
+}
+
+type replicaClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReplicaClient(cc grpc.ClientConnInterface) ReplicaClient {
+	return &replicaClient{cc}
+}
+
+func (c *replicaClient) Store(ctx context.Context, in *StoreRequest, opts ...grpc.CallOption) (*StoreResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StoreResponse)
+	err := c.cc.Invoke(ctx, Replica_Store_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *replicaClient) Fetch(ctx context.Context, in *FetchRequest, opts ...grpc.CallOption) (*FetchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FetchResponse)
+	err := c.cc.Invoke(ctx, Replica_Fetch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReplicaServer is the server API for Replica service.
+// All implementations must embed UnimplementedReplicaServer
+// for forward compatibility.
+//
+// Internal replica-to-replica API of the leaderless replication mode
+type ReplicaServer interface {
+	Store(context.Context, *StoreRequest) (*StoreResponse, error)
+	Fetch(context.Context, *FetchRequest) (*FetchResponse, error)
+	mustEmbedUnimplementedReplicaServer()
+}
+
+// UnimplementedReplicaServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedReplicaServer struct{}
+
+func (UnimplementedReplicaServer) Store(context.Context, *StoreRequest) (*StoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Store not implemented")
+}
+func (UnimplementedReplicaServer) Fetch(context.Context, *FetchRequest) (*FetchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Fetch not implemented")
+}
+func (UnimplementedReplicaServer) mustEmbedUnimplementedReplicaServer() {}
+func (UnimplementedReplicaServer) testEmbeddedByValue()                 {}
+
+// UnsafeReplicaServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReplicaServer will
+// result in compilation errors.
+type UnsafeReplicaServer interface {
+	mustEmbedUnimplementedReplicaServer()
+}
+
+func RegisterReplicaServer(s grpc.ServiceRegistrar, srv ReplicaServer) {
+	// If the following call pancis, it indicates UnimplementedReplicaServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Replica_ServiceDesc, srv)
+}
+
+func _Replica_Store_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReplicaServer).Store(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Replica_Store_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReplicaServer).Store(ctx, req.(*StoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Replica_Fetch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReplicaServer).Fetch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Replica_Fetch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReplicaServer).Fetch(ctx, req.(*FetchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Replica_ServiceDesc is the grpc.ServiceDesc for Replica service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Replica_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "Replica",
+	HandlerType: (*ReplicaServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Store",
+			Handler:    _Replica_Store_Handler,
+		},
+		{
+			MethodName: "Fetch",
+			Handler:    _Replica_Fetch_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "kvstore.proto",
+}
+
+const (
+	CRDT_Increment_FullMethodName     = "/CRDT/Increment"
+	CRDT_AddToSet_FullMethodName      = "/CRDT/AddToSet"
+	CRDT_RemoveFromSet_FullMethodName = "/CRDT/RemoveFromSet"
+	CRDT_SetRegister_FullMethodName   = "/CRDT/SetRegister"
+	CRDT_MapPut_FullMethodName        = "/CRDT/MapPut"
+	CRDT_MapRemove_FullMethodName     = "/CRDT/MapRemove"
+	CRDT_Read_FullMethodName          = "/CRDT/Read"
+	CRDT_Sync_FullMethodName          = "/CRDT/Sync"
+)
+
+// CRDTClient is the client API for CRDT service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Conflict-free replicated data types, writable on any node
+type CRDTClient interface {
+	Increment(ctx context.Context, in *CounterIncrementRequest, opts ...grpc.CallOption) (*CRDTValue, error)
+	AddToSet(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*CRDTValue, error)
+	RemoveFromSet(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*CRDTValue, error)
+	SetRegister(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*CRDTValue, error)
+	MapPut(ctx context.Context, in *MapRequest, opts ...grpc.CallOption) (*CRDTValue, error)
+	MapRemove(ctx context.Context, in *MapRequest, opts ...grpc.CallOption) (*CRDTValue, error)
+	Read(ctx context.Context, in *CRDTReadRequest, opts ...grpc.CallOption) (*CRDTValue, error)
+	// Internal: push-pull anti-entropy between replicas
+	Sync(ctx context.Context, in *CRDTStates, opts ...grpc.CallOption) (*CRDTStates, error)
+}
+
+type cRDTClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCRDTClient(cc grpc.ClientConnInterface) CRDTClient {
+	return &cRDTClient{cc}
+}
+
+func (c *cRDTClient) Increment(ctx context.Context, in *CounterIncrementRequest, opts ...grpc.CallOption) (*CRDTValue, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CRDTValue)
+	err := c.cc.Invoke(ctx, CRDT_Increment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cRDTClient) AddToSet(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*CRDTValue, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CRDTValue)
+	err := c.cc.Invoke(ctx, CRDT_AddToSet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cRDTClient) RemoveFromSet(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*CRDTValue, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CRDTValue)
+	err := c.cc.Invoke(ctx, CRDT_RemoveFromSet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cRDTClient) SetRegister(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*CRDTValue, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CRDTValue)
+	err := c.cc.Invoke(ctx, CRDT_SetRegister_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cRDTClient) MapPut(ctx context.Context, in *MapRequest, opts ...grpc.CallOption) (*CRDTValue, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CRDTValue)
+	err := c.cc.Invoke(ctx, CRDT_MapPut_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cRDTClient) MapRemove(ctx context.Context, in *MapRequest, opts ...grpc.CallOption) (*CRDTValue, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CRDTValue)
+	err := c.cc.Invoke(ctx, CRDT_MapRemove_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cRDTClient) Read(ctx context.Context, in *CRDTReadRequest, opts ...grpc.CallOption) (*CRDTValue, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CRDTValue)
+	err := c.cc.Invoke(ctx, CRDT_Read_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cRDTClient) Sync(ctx context.Context, in *CRDTStates, opts ...grpc.CallOption) (*CRDTStates, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CRDTStates)
+	err := c.cc.Invoke(ctx, CRDT_Sync_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CRDTServer is the server API for CRDT service.
+// All implementations must embed UnimplementedCRDTServer
+// for forward compatibility.
+//
+// Conflict-free replicated data types, writable on any node
+type CRDTServer interface {
+	Increment(context.Context, *CounterIncrementRequest) (*CRDTValue, error)
+	AddToSet(context.Context, *SetRequest) (*CRDTValue, error)
+	RemoveFromSet(context.Context, *SetRequest) (*CRDTValue, error)
+	SetRegister(context.Context, *RegisterRequest) (*CRDTValue, error)
+	MapPut(context.Context, *MapRequest) (*CRDTValue, error)
+	MapRemove(context.Context, *MapRequest) (*CRDTValue, error)
+	Read(context.Context, *CRDTReadRequest) (*CRDTValue, error)
+	// Internal: push-pull anti-entropy between replicas
+	Sync(context.Context, *CRDTStates) (*CRDTStates, error)
+	mustEmbedUnimplementedCRDTServer()
+}
+
+// UnimplementedCRDTServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCRDTServer struct{}
+
+func (UnimplementedCRDTServer) Increment(context.Context, *CounterIncrementRequest) (*CRDTValue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Increment not implemented")
+}
+func (UnimplementedCRDTServer) AddToSet(context.Context, *SetRequest) (*CRDTValue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddToSet not implemented")
+}
+func (UnimplementedCRDTServer) RemoveFromSet(context.Context, *SetRequest) (*CRDTValue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveFromSet not implemented")
+}
+func (UnimplementedCRDTServer) SetRegister(context.Context, *RegisterRequest) (*CRDTValue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRegister not implemented")
+}
+func (UnimplementedCRDTServer) MapPut(context.Context, *MapRequest) (*CRDTValue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MapPut not implemented")
+}
+func (UnimplementedCRDTServer) MapRemove(context.Context, *MapRequest) (*CRDTValue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MapRemove not implemented")
+}
+func (UnimplementedCRDTServer) Read(context.Context, *CRDTReadRequest) (*CRDTValue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Read not implemented")
+}
+func (UnimplementedCRDTServer) Sync(context.Context, *CRDTStates) (*CRDTStates, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Sync not implemented")
+}
+func (UnimplementedCRDTServer) mustEmbedUnimplementedCRDTServer() {}
+func (UnimplementedCRDTServer) testEmbeddedByValue()              {}
+
+// UnsafeCRDTServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CRDTServer will
+// result in compilation errors.
+type UnsafeCRDTServer interface {
+	mustEmbedUnimplementedCRDTServer()
+}
+
+func RegisterCRDTServer(s grpc.ServiceRegistrar, srv CRDTServer) {
+	// If the following call pancis, it indicates UnimplementedCRDTServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CRDT_ServiceDesc, srv)
+}
+
+func _CRDT_Increment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CounterIncrementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CRDTServer).Increment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CRDT_Increment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CRDTServer).Increment(ctx, req.(*CounterIncrementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CRDT_AddToSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CRDTServer).AddToSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CRDT_AddToSet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CRDTServer).AddToSet(ctx, req.(*SetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CRDT_RemoveFromSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CRDTServer).RemoveFromSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CRDT_RemoveFromSet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CRDTServer).RemoveFromSet(ctx, req.(*SetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CRDT_SetRegister_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CRDTServer).SetRegister(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CRDT_SetRegister_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CRDTServer).SetRegister(ctx, req.(*RegisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CRDT_MapPut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CRDTServer).MapPut(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CRDT_MapPut_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CRDTServer).MapPut(ctx, req.(*MapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CRDT_MapRemove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CRDTServer).MapRemove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CRDT_MapRemove_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CRDTServer).MapRemove(ctx, req.(*MapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CRDT_Read_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CRDTReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CRDTServer).Read(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CRDT_Read_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CRDTServer).Read(ctx, req.(*CRDTReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CRDT_Sync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CRDTStates)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CRDTServer).Sync(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CRDT_Sync_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CRDTServer).Sync(ctx, req.(*CRDTStates))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CRDT_ServiceDesc is the grpc.ServiceDesc for CRDT service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CRDT_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "CRDT",
+	HandlerType: (*CRDTServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Increment",
+			Handler:    _CRDT_Increment_Handler,
+		},
+		{
+			MethodName: "AddToSet",
+			Handler:    _CRDT_AddToSet_Handler,
+		},
+		{
+			MethodName: "RemoveFromSet",
+			Handler:    _CRDT_RemoveFromSet_Handler,
+		},
+		{
+			MethodName: "SetRegister",
+			Handler:    _CRDT_SetRegister_Handler,
+		},
+		{
+			MethodName: "MapPut",
+			Handler:    _CRDT_MapPut_Handler,
+		},
+		{
+			MethodName: "MapRemove",
+			Handler:    _CRDT_MapRemove_Handler,
+		},
+		{
+			MethodName: "Read",
+			Handler:    _CRDT_Read_Handler,
+		},
+		{
+			MethodName: "Sync",
+			Handler:    _CRDT_Sync_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "kvstore.proto",
+}
+
+const (
+	Chain_Propagate_FullMethodName = "/Chain/Propagate"
+	Chain_Read_FullMethodName      = "/Chain/Read"
+)
+
+// ChainClient is the client API for Chain service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Internal node-to-node API of the chain replication mode
+type ChainClient interface {
+	Propagate(ctx context.Context, in *ChainWrite, opts ...grpc.CallOption) (*ChainAck, error)
+	Read(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
+}
+
+type chainClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewChainClient(cc grpc.ClientConnInterface) ChainClient {
+	return &chainClient{cc}
+}
+
+func (c *chainClient) Propagate(ctx context.Context, in *ChainWrite, opts ...grpc.CallOption) (*ChainAck, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChainAck)
+	err := c.cc.Invoke(ctx, Chain_Propagate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chainClient) Read(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetResponse)
+	err := c.cc.Invoke(ctx, Chain_Read_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ChainServer is the server API for Chain service.
+// All implementations must embed UnimplementedChainServer
+// for forward compatibility.
+//
+// Internal node-to-node API of the chain replication mode
+type ChainServer interface {
+	Propagate(context.Context, *ChainWrite) (*ChainAck, error)
+	Read(context.Context, *GetRequest) (*GetResponse, error)
+	mustEmbedUnimplementedChainServer()
+}
+
+// UnimplementedChainServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedChainServer struct{}
+
+func (UnimplementedChainServer) Propagate(context.Context, *ChainWrite) (*ChainAck, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Propagate not implemented")
+}
+func (UnimplementedChainServer) Read(context.Context, *GetRequest) (*GetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Read not implemented")
+}
+func (UnimplementedChainServer) mustEmbedUnimplementedChainServer() {}
+func (UnimplementedChainServer) testEmbeddedByValue()               {}
+
+// UnsafeChainServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ChainServer will
+// result in compilation errors.
+type UnsafeChainServer interface {
+	mustEmbedUnimplementedChainServer()
+}
+
+func RegisterChainServer(s grpc.ServiceRegistrar, srv ChainServer) {
+	// If the following call pancis, it indicates UnimplementedChainServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Chain_ServiceDesc, srv)
+}
+
+func _Chain_Propagate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChainWrite)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChainServer).Propagate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chain_Propagate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChainServer).Propagate(ctx, req.(*ChainWrite))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chain_Read_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChainServer).Read(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chain_Read_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChainServer).Read(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Chain_ServiceDesc is the grpc.ServiceDesc for Chain service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Chain_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "Chain",
+	HandlerType: (*ChainServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Propagate",
+			Handler:    _Chain_Propagate_Handler,
+		},
+		{
+			MethodName: "Read",
+			Handler:    _Chain_Read_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "kvstore.proto",
+}
+
+const (
+	Lease_Grant_FullMethodName      = "/Lease/Grant"
+	Lease_Revoke_FullMethodName     = "/Lease/Revoke"
+	Lease_KeepAlive_FullMethodName  = "/Lease/KeepAlive"
+	Lease_TimeToLive_FullMethodName = "/Lease/TimeToLive"
+	Lease_Renew_FullMethodName      = "/Lease/Renew"
+)
+
+// LeaseClient is the client API for Lease service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Leases: keys attached to a lease are deleted together when it is revoked
+// or expires without a keep-alive
+type LeaseClient interface {
+	Grant(ctx context.Context, in *LeaseGrantRequest, opts ...grpc.CallOption) (*LeaseGrantResponse, error)
+	Revoke(ctx context.Context, in *LeaseRevokeRequest, opts ...grpc.CallOption) (*LeaseRevokeResponse, error)
+	KeepAlive(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[LeaseKeepAliveRequest, LeaseKeepAliveResponse], error)
+	TimeToLive(ctx context.Context, in *LeaseTimeToLiveRequest, opts ...grpc.CallOption) (*LeaseTimeToLiveResponse, error)
+	// Internal: a follower passes a keep-alive on to the leader
+	Renew(ctx context.Context, in *LeaseKeepAliveRequest, opts ...grpc.CallOption) (*LeaseKeepAliveResponse, error)
+}
+
+type leaseClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLeaseClient(cc grpc.ClientConnInterface) LeaseClient {
+	return &leaseClient{cc}
+}
+
+func (c *leaseClient) Grant(ctx context.Context, in *LeaseGrantRequest, opts ...grpc.CallOption) (*LeaseGrantResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaseGrantResponse)
+	err := c.cc.Invoke(ctx, Lease_Grant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaseClient) Revoke(ctx context.Context, in *LeaseRevokeRequest, opts ...grpc.CallOption) (*LeaseRevokeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaseRevokeResponse)
+	err := c.cc.Invoke(ctx, Lease_Revoke_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaseClient) KeepAlive(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[LeaseKeepAliveRequest, LeaseKeepAliveResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Lease_ServiceDesc.Streams[0], Lease_KeepAlive_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[LeaseKeepAliveRequest, LeaseKeepAliveResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Lease_KeepAliveClient = grpc.BidiStreamingClient[LeaseKeepAliveRequest, LeaseKeepAliveResponse]
+
+func (c *leaseClient) TimeToLive(ctx context.Context, in *LeaseTimeToLiveRequest, opts ...grpc.CallOption) (*LeaseTimeToLiveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaseTimeToLiveResponse)
+	err := c.cc.Invoke(ctx, Lease_TimeToLive_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaseClient) Renew(ctx context.Context, in *LeaseKeepAliveRequest, opts ...grpc.CallOption) (*LeaseKeepAliveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaseKeepAliveResponse)
+	err := c.cc.Invoke(ctx, Lease_Renew_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LeaseServer is the server API for Lease service.
+// All implementations must embed UnimplementedLeaseServer
+// for forward compatibility.
+//
+// Leases: keys attached to a lease are deleted together when it is revoked
+// or expires without a keep-alive
+type LeaseServer interface {
+	Grant(context.Context, *LeaseGrantRequest) (*LeaseGrantResponse, error)
+	Revoke(context.Context, *LeaseRevokeRequest) (*LeaseRevokeResponse, error)
+	KeepAlive(grpc.BidiStreamingServer[LeaseKeepAliveRequest, LeaseKeepAliveResponse]) error
+	TimeToLive(context.Context, *LeaseTimeToLiveRequest) (*LeaseTimeToLiveResponse, error)
+	// Internal: a follower passes a keep-alive on to the leader
+	Renew(context.Context, *LeaseKeepAliveRequest) (*LeaseKeepAliveResponse, error)
+	mustEmbedUnimplementedLeaseServer()
+}
+
+// UnimplementedLeaseServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLeaseServer struct{}
+
+func (UnimplementedLeaseServer) Grant(context.Context, *LeaseGrantRequest) (*LeaseGrantResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Grant not implemented")
+}
+func (UnimplementedLeaseServer) Revoke(context.Context, *LeaseRevokeRequest) (*LeaseRevokeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Revoke not implemented")
+}
+func (UnimplementedLeaseServer) KeepAlive(grpc.BidiStreamingServer[LeaseKeepAliveRequest, LeaseKeepAliveResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method KeepAlive not implemented")
+}
+func (UnimplementedLeaseServer) TimeToLive(context.Context, *LeaseTimeToLiveRequest) (*LeaseTimeToLiveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TimeToLive not implemented")
+}
+func (UnimplementedLeaseServer) Renew(context.Context, *LeaseKeepAliveRequest) (*LeaseKeepAliveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Renew not implemented")
+}
+func (UnimplementedLeaseServer) mustEmbedUnimplementedLeaseServer() {}
+func (UnimplementedLeaseServer) testEmbeddedByValue()               {}
+
+// UnsafeLeaseServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LeaseServer will
+// result in compilation errors.
+type UnsafeLeaseServer interface {
+	mustEmbedUnimplementedLeaseServer()
+}
+
+func RegisterLeaseServer(s grpc.ServiceRegistrar, srv LeaseServer) {
+	// If the following call pancis, it indicates UnimplementedLeaseServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Lease_ServiceDesc, srv)
+}
+
+func _Lease_Grant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaseGrantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaseServer).Grant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Lease_Grant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaseServer).Grant(ctx, req.(*LeaseGrantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lease_Revoke_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaseRevokeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaseServer).Revoke(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Lease_Revoke_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaseServer).Revoke(ctx, req.(*LeaseRevokeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lease_KeepAlive_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LeaseServer).KeepAlive(&grpc.GenericServerStream[LeaseKeepAliveRequest, LeaseKeepAliveResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Lease_KeepAliveServer = grpc.BidiStreamingServer[LeaseKeepAliveRequest, LeaseKeepAliveResponse]
+
+func _Lease_TimeToLive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaseTimeToLiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaseServer).TimeToLive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Lease_TimeToLive_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaseServer).TimeToLive(ctx, req.(*LeaseTimeToLiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lease_Renew_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaseKeepAliveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaseServer).Renew(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Lease_Renew_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaseServer).Renew(ctx, req.(*LeaseKeepAliveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Lease_ServiceDesc is the grpc.ServiceDesc for Lease service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Lease_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "Lease",
+	HandlerType: (*LeaseServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Grant",
+			Handler:    _Lease_Grant_Handler,
+		},
+		{
+			MethodName: "Revoke",
+			Handler:    _Lease_Revoke_Handler,
+		},
+		{
+			MethodName: "TimeToLive",
+			Handler:    _Lease_TimeToLive_Handler,
+		},
+		{
+			MethodName: "Renew",
+			Handler:    _Lease_Renew_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "KeepAlive",
+			Handler:       _Lease_KeepAlive_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "kvstore.proto",
+}
+
+const (
+	Lock_Lock_FullMethodName   = "/Lock/Lock"
+	Lock_Unlock_FullMethodName = "/Lock/Unlock"
+)
+
+// LockClient is the client API for Lock service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Distributed locks held by leases
+type LockClient interface {
+	// Blocks until the lease holds the lock; waiters are served in order
+	Lock(ctx context.Context, in *LockRequest, opts ...grpc.CallOption) (*LockResponse, error)
+	Unlock(ctx context.Context, in *UnlockRequest, opts ...grpc.CallOption) (*UnlockResponse, error)
+}
+
+type lockClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLockClient(cc grpc.ClientConnInterface) LockClient {
+	return &lockClient{cc}
+}
+
+func (c *lockClient) Lock(ctx context.Context, in *LockRequest, opts ...grpc.CallOption) (*LockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LockResponse)
+	err := c.cc.Invoke(ctx, Lock_Lock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lockClient) Unlock(ctx context.Context, in *UnlockRequest, opts ...grpc.CallOption) (*UnlockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnlockResponse)
+	err := c.cc.Invoke(ctx, Lock_Unlock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LockServer is the server API for Lock service.
+// All implementations must embed UnimplementedLockServer
+// for forward compatibility.
+//
+// Distributed locks held by leases
+type LockServer interface {
+	// Blocks until the lease holds the lock; waiters are served in order
+	Lock(context.Context, *LockRequest) (*LockResponse, error)
+	Unlock(context.Context, *UnlockRequest) (*UnlockResponse, error)
+	mustEmbedUnimplementedLockServer()
+}
+
+// UnimplementedLockServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLockServer struct{}
+
+func (UnimplementedLockServer) Lock(context.Context, *LockRequest) (*LockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Lock not implemented")
+}
+func (UnimplementedLockServer) Unlock(context.Context, *UnlockRequest) (*UnlockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unlock not implemented")
+}
+func (UnimplementedLockServer) mustEmbedUnimplementedLockServer() {}
+func (UnimplementedLockServer) testEmbeddedByValue()              {}
+
+// UnsafeLockServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LockServer will
+// result in compilation errors.
+type UnsafeLockServer interface {
+	mustEmbedUnimplementedLockServer()
+}
+
+func RegisterLockServer(s grpc.ServiceRegistrar, srv LockServer) {
+	// If the following call pancis, it indicates UnimplementedLockServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Lock_ServiceDesc, srv)
+}
+
+func _Lock_Lock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LockServer).Lock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Lock_Lock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LockServer).Lock(ctx, req.(*LockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lock_Unlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LockServer).Unlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Lock_Unlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LockServer).Unlock(ctx, req.(*UnlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Lock_ServiceDesc is the grpc.ServiceDesc for Lock service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Lock_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "Lock",
+	HandlerType: (*LockServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Lock",
+			Handler:    _Lock_Lock_Handler,
+		},
+		{
+			MethodName: "Unlock",
+			Handler:    _Lock_Unlock_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "kvstore.proto",
+}
+
+const (
+	Election_Campaign_FullMethodName = "/Election/Campaign"
+	Election_Proclaim_FullMethodName = "/Election/Proclaim"
+	Election_Resign_FullMethodName   = "/Election/Resign"
+	Election_Leader_FullMethodName   = "/Election/Leader"
+	Election_Observe_FullMethodName  = "/Election/Observe"
+)
+
+// ElectionClient is the client API for Election service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Leader election among clients, each campaigning with a lease; the oldest
+// candidate leads until it resigns or its lease lapses
+type ElectionClient interface {
+	// Blocks until the lease leads the election
+	Campaign(ctx context.Context, in *CampaignRequest, opts ...grpc.CallOption) (*CampaignResponse, error)
+	Proclaim(ctx context.Context, in *ProclaimRequest, opts ...grpc.CallOption) (*ProclaimResponse, error)
+	Resign(ctx context.Context, in *ResignRequest, opts ...grpc.CallOption) (*ResignResponse, error)
+	Leader(ctx context.Context, in *LeaderRequest, opts ...grpc.CallOption) (*LeaderResponse, error)
+	// Streams the leader each time it or its value changes
+	Observe(ctx context.Context, in *LeaderRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LeaderResponse], error)
+}
+
+type electionClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewElectionClient(cc grpc.ClientConnInterface) ElectionClient {
+	return &electionClient{cc}
+}
+
+func (c *electionClient) Campaign(ctx context.Context, in *CampaignRequest, opts ...grpc.CallOption) (*CampaignResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CampaignResponse)
+	err := c.cc.Invoke(ctx, Election_Campaign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *electionClient) Proclaim(ctx context.Context, in *ProclaimRequest, opts ...grpc.CallOption) (*ProclaimResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProclaimResponse)
+	err := c.cc.Invoke(ctx, Election_Proclaim_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *electionClient) Resign(ctx context.Context, in *ResignRequest, opts ...grpc.CallOption) (*ResignResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResignResponse)
+	err := c.cc.Invoke(ctx, Election_Resign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *electionClient) Leader(ctx context.Context, in *LeaderRequest, opts ...grpc.CallOption) (*LeaderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaderResponse)
+	err := c.cc.Invoke(ctx, Election_Leader_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *electionClient) Observe(ctx context.Context, in *LeaderRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LeaderResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Election_ServiceDesc.Streams[0], Election_Observe_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[LeaderRequest, LeaderResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Election_ObserveClient = grpc.ServerStreamingClient[LeaderResponse]
+
+// ElectionServer is the server API for Election service.
+// All implementations must embed UnimplementedElectionServer
+// for forward compatibility.
+//
+// Leader election among clients, each campaigning with a lease; the oldest
+// candidate leads until it resigns or its lease lapses
+type ElectionServer interface {
+	// Blocks until the lease leads the election
+	Campaign(context.Context, *CampaignRequest) (*CampaignResponse, error)
+	Proclaim(context.Context, *ProclaimRequest) (*ProclaimResponse, error)
+	Resign(context.Context, *ResignRequest) (*ResignResponse, error)
+	Leader(context.Context, *LeaderRequest) (*LeaderResponse, error)
+	// Streams the leader each time it or its value changes
+	Observe(*LeaderRequest, grpc.ServerStreamingServer[LeaderResponse]) error
+	mustEmbedUnimplementedElectionServer()
+}
+
+// UnimplementedElectionServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedElectionServer struct{}
+
+func (UnimplementedElectionServer) Campaign(context.Context, *CampaignRequest) (*CampaignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Campaign not implemented")
+}
+func (UnimplementedElectionServer) Proclaim(context.Context, *ProclaimRequest) (*ProclaimResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Proclaim not implemented")
+}
+func (UnimplementedElectionServer) Resign(context.Context, *ResignRequest) (*ResignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resign not implemented")
+}
+func (UnimplementedElectionServer) Leader(context.Context, *LeaderRequest) (*LeaderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Leader not implemented")
+}
+func (UnimplementedElectionServer) Observe(*LeaderRequest, grpc.ServerStreamingServer[LeaderResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Observe not implemented")
+}
+func (UnimplementedElectionServer) mustEmbedUnimplementedElectionServer() {}
+func (UnimplementedElectionServer) testEmbeddedByValue()                  {}
+
+// UnsafeElectionServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ElectionServer will
+// result in compilation errors.
+type UnsafeElectionServer interface {
+	mustEmbedUnimplementedElectionServer()
+}
+
+func RegisterElectionServer(s grpc.ServiceRegistrar, srv ElectionServer) {
+	// If the following call pancis, it indicates UnimplementedElectionServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Election_ServiceDesc, srv)
+}
+
+func _Election_Campaign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CampaignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElectionServer).Campaign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Election_Campaign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElectionServer).Campaign(ctx, req.(*CampaignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Election_Proclaim_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProclaimRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElectionServer).Proclaim(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Election_Proclaim_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElectionServer).Proclaim(ctx, req.(*ProclaimRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Election_Resign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElectionServer).Resign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Election_Resign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElectionServer).Resign(ctx, req.(*ResignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Election_Leader_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElectionServer).Leader(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Election_Leader_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElectionServer).Leader(ctx, req.(*LeaderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Election_Observe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(LeaderRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ElectionServer).Observe(m, &grpc.GenericServerStream[LeaderRequest, LeaderResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Election_ObserveServer = grpc.ServerStreamingServer[LeaderResponse]
+
+// Election_ServiceDesc is the grpc.ServiceDesc for Election service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Election_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "Election",
+	HandlerType: (*ElectionServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Campaign",
+			Handler:    _Election_Campaign_Handler,
+		},
+		{
+			MethodName: "Proclaim",
+			Handler:    _Election_Proclaim_Handler,
+		},
+		{
+			MethodName: "Resign",
+			Handler:    _Election_Resign_Handler,
+		},
+		{
+			MethodName: "Leader",
+			Handler:    _Election_Leader_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Observe",
+			Handler:       _Election_Observe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "kvstore.proto",
+}
+
+const (
+	Collections_ListPush_FullMethodName         = "/Collections/ListPush"
+	Collections_ListPop_FullMethodName          = "/Collections/ListPop"
+	Collections_ListRange_FullMethodName        = "/Collections/ListRange"
+	Collections_SetAdd_FullMethodName           = "/Collections/SetAdd"
+	Collections_SetRemove_FullMethodName        = "/Collections/SetRemove"
+	Collections_SetMembers_FullMethodName       = "/Collections/SetMembers"
+	Collections_SetIntersect_FullMethodName     = "/Collections/SetIntersect"
+	Collections_HashSet_FullMethodName          = "/Collections/HashSet"
+	Collections_HashGet_FullMethodName          = "/Collections/HashGet"
+	Collections_HashDelete_FullMethodName       = "/Collections/HashDelete"
+	Collections_HashGetAll_FullMethodName       = "/Collections/HashGetAll"
+	Collections_ZSetAdd_FullMethodName          = "/Collections/ZSetAdd"
+	Collections_ZSetRemove_FullMethodName       = "/Collections/ZSetRemove"
+	Collections_ZSetRangeByScore_FullMethodName = "/Collections/ZSetRangeByScore"
+	Collections_ZSetRank_FullMethodName         = "/Collections/ZSetRank"
+	Collections_Apply_FullMethodName            = "/Collections/Apply"
+)
+
+// CollectionsClient is the client API for Collections service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Redis-like collections held in the keyspace next to plain values; a
+// command against a key of another type fails with WRONGTYPE
+type CollectionsClient interface {
+	ListPush(ctx context.Context, in *ListPushRequest, opts ...grpc.CallOption) (*CollectionReply, error)
+	ListPop(ctx context.Context, in *ListPopRequest, opts ...grpc.CallOption) (*CollectionReply, error)
+	ListRange(ctx context.Context, in *ListRangeRequest, opts ...grpc.CallOption) (*CollectionReply, error)
+	SetAdd(ctx context.Context, in *MembersRequest, opts ...grpc.CallOption) (*CollectionReply, error)
+	SetRemove(ctx context.Context, in *MembersRequest, opts ...grpc.CallOption) (*CollectionReply, error)
+	SetMembers(ctx context.Context, in *CollectionKeyRequest, opts ...grpc.CallOption) (*CollectionReply, error)
+	SetIntersect(ctx context.Context, in *SetIntersectRequest, opts ...grpc.CallOption) (*CollectionReply, error)
+	HashSet(ctx context.Context, in *HashSetRequest, opts ...grpc.CallOption) (*CollectionReply, error)
+	HashGet(ctx context.Context, in *HashFieldsRequest, opts ...grpc.CallOption) (*CollectionReply, error)
+	HashDelete(ctx context.Context, in *HashFieldsRequest, opts ...grpc.CallOption) (*CollectionReply, error)
+	HashGetAll(ctx context.Context, in *CollectionKeyRequest, opts ...grpc.CallOption) (*CollectionReply, error)
+	ZSetAdd(ctx context.Context, in *ZSetAddRequest, opts ...grpc.CallOption) (*CollectionReply, error)
+	ZSetRemove(ctx context.Context, in *MembersRequest, opts ...grpc.CallOption) (*CollectionReply, error)
+	ZSetRangeByScore(ctx context.Context, in *ZSetRangeRequest, opts ...grpc.CallOption) (*CollectionReply, error)
+	ZSetRank(ctx context.Context, in *ZSetRankRequest, opts ...grpc.CallOption) (*CollectionReply, error)
+	// Internal: runs a command a peer forwarded, replicated or routed
+	Apply(ctx context.Context, in *CollectionOp, opts ...grpc.CallOption) (*CollectionReply, error)
+}
+
+type collectionsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCollectionsClient(cc grpc.ClientConnInterface) CollectionsClient {
+	return &collectionsClient{cc}
+}
+
+func (c *collectionsClient) ListPush(ctx context.Context, in *ListPushRequest, opts ...grpc.CallOption) (*CollectionReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionReply)
+	err := c.cc.Invoke(ctx, Collections_ListPush_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionsClient) ListPop(ctx context.Context, in *ListPopRequest, opts ...grpc.CallOption) (*CollectionReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionReply)
+	err := c.cc.Invoke(ctx, Collections_ListPop_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionsClient) ListRange(ctx context.Context, in *ListRangeRequest, opts ...grpc.CallOption) (*CollectionReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionReply)
+	err := c.cc.Invoke(ctx, Collections_ListRange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionsClient) SetAdd(ctx context.Context, in *MembersRequest, opts ...grpc.CallOption) (*CollectionReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionReply)
+	err := c.cc.Invoke(ctx, Collections_SetAdd_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionsClient) SetRemove(ctx context.Context, in *MembersRequest, opts ...grpc.CallOption) (*CollectionReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionReply)
+	err := c.cc.Invoke(ctx, Collections_SetRemove_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionsClient) SetMembers(ctx context.Context, in *CollectionKeyRequest, opts ...grpc.CallOption) (*CollectionReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionReply)
+	err := c.cc.Invoke(ctx, Collections_SetMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionsClient) SetIntersect(ctx context.Context, in *SetIntersectRequest, opts ...grpc.CallOption) (*CollectionReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionReply)
+	err := c.cc.Invoke(ctx, Collections_SetIntersect_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionsClient) HashSet(ctx context.Context, in *HashSetRequest, opts ...grpc.CallOption) (*CollectionReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionReply)
+	err := c.cc.Invoke(ctx, Collections_HashSet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionsClient) HashGet(ctx context.Context, in *HashFieldsRequest, opts ...grpc.CallOption) (*CollectionReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionReply)
+	err := c.cc.Invoke(ctx, Collections_HashGet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionsClient) HashDelete(ctx context.Context, in *HashFieldsRequest, opts ...grpc.CallOption) (*CollectionReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionReply)
+	err := c.cc.Invoke(ctx, Collections_HashDelete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionsClient) HashGetAll(ctx context.Context, in *CollectionKeyRequest, opts ...grpc.CallOption) (*CollectionReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionReply)
+	err := c.cc.Invoke(ctx, Collections_HashGetAll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionsClient) ZSetAdd(ctx context.Context, in *ZSetAddRequest, opts ...grpc.CallOption) (*CollectionReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionReply)
+	err := c.cc.Invoke(ctx, Collections_ZSetAdd_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionsClient) ZSetRemove(ctx context.Context, in *MembersRequest, opts ...grpc.CallOption) (*CollectionReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionReply)
+	err := c.cc.Invoke(ctx, Collections_ZSetRemove_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionsClient) ZSetRangeByScore(ctx context.Context, in *ZSetRangeRequest, opts ...grpc.CallOption) (*CollectionReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionReply)
+	err := c.cc.Invoke(ctx, Collections_ZSetRangeByScore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionsClient) ZSetRank(ctx context.Context, in *ZSetRankRequest, opts ...grpc.CallOption) (*CollectionReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionReply)
+	err := c.cc.Invoke(ctx, Collections_ZSetRank_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collectionsClient) Apply(ctx context.Context, in *CollectionOp, opts ...grpc.CallOption) (*CollectionReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionReply)
+	err := c.cc.Invoke(ctx, Collections_Apply_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CollectionsServer is the server API for Collections service.
+// All implementations must embed UnimplementedCollectionsServer
+// for forward compatibility.
+//
+// Redis-like collections held in the keyspace next to plain values; a
+// command against a key of another type fails with WRONGTYPE
+type CollectionsServer interface {
+	ListPush(context.Context, *ListPushRequest) (*CollectionReply, error)
+	ListPop(context.Context, *ListPopRequest) (*CollectionReply, error)
+	ListRange(context.Context, *ListRangeRequest) (*CollectionReply, error)
+	SetAdd(context.Context, *MembersRequest) (*CollectionReply, error)
+	SetRemove(context.Context, *MembersRequest) (*CollectionReply, error)
+	SetMembers(context.Context, *CollectionKeyRequest) (*CollectionReply, error)
+	SetIntersect(context.Context, *SetIntersectRequest) (*CollectionReply, error)
+	HashSet(context.Context, *HashSetRequest) (*CollectionReply, error)
+	HashGet(context.Context, *HashFieldsRequest) (*CollectionReply, error)
+	HashDelete(context.Context, *HashFieldsRequest) (*CollectionReply, error)
+	HashGetAll(context.Context, *CollectionKeyRequest) (*CollectionReply, error)
+	ZSetAdd(context.Context, *ZSetAddRequest) (*CollectionReply, error)
+	ZSetRemove(context.Context, *MembersRequest) (*CollectionReply, error)
+	ZSetRangeByScore(context.Context, *ZSetRangeRequest) (*CollectionReply, error)
+	ZSetRank(context.Context, *ZSetRankRequest) (*CollectionReply, error)
+	// Internal: runs a command a peer forwarded, replicated or routed
+	Apply(context.Context, *CollectionOp) (*CollectionReply, error)
+	mustEmbedUnimplementedCollectionsServer()
+}
+
+// UnimplementedCollectionsServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCollectionsServer struct{}
+
+func (UnimplementedCollectionsServer) ListPush(context.Context, *ListPushRequest) (*CollectionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPush not implemented")
+}
+func (UnimplementedCollectionsServer) ListPop(context.Context, *ListPopRequest) (*CollectionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPop not implemented")
+}
+func (UnimplementedCollectionsServer) ListRange(context.Context, *ListRangeRequest) (*CollectionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRange not implemented")
+}
+func (UnimplementedCollectionsServer) SetAdd(context.Context, *MembersRequest) (*CollectionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAdd not implemented")
+}
+func (UnimplementedCollectionsServer) SetRemove(context.Context, *MembersRequest) (*CollectionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRemove not implemented")
+}
+func (UnimplementedCollectionsServer) SetMembers(context.Context, *CollectionKeyRequest) (*CollectionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMembers not implemented")
+}
+func (UnimplementedCollectionsServer) SetIntersect(context.Context, *SetIntersectRequest) (*CollectionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetIntersect not implemented")
+}
+func (UnimplementedCollectionsServer) HashSet(context.Context, *HashSetRequest) (*CollectionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HashSet not implemented")
+}
+func (UnimplementedCollectionsServer) HashGet(context.Context, *HashFieldsRequest) (*CollectionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HashGet not implemented")
+}
+func (UnimplementedCollectionsServer) HashDelete(context.Context, *HashFieldsRequest) (*CollectionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HashDelete not implemented")
+}
+func (UnimplementedCollectionsServer) HashGetAll(context.Context, *CollectionKeyRequest) (*CollectionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HashGetAll not implemented")
+}
+func (UnimplementedCollectionsServer) ZSetAdd(context.Context, *ZSetAddRequest) (*CollectionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ZSetAdd not implemented")
+}
+func (UnimplementedCollectionsServer) ZSetRemove(context.Context, *MembersRequest) (*CollectionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ZSetRemove not implemented")
+}
+func (UnimplementedCollectionsServer) ZSetRangeByScore(context.Context, *ZSetRangeRequest) (*CollectionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ZSetRangeByScore not implemented")
+}
+func (UnimplementedCollectionsServer) ZSetRank(context.Context, *ZSetRankRequest) (*CollectionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ZSetRank not implemented")
+}
+func (UnimplementedCollectionsServer) Apply(context.Context, *CollectionOp) (*CollectionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Apply not implemented")
+}
+func (UnimplementedCollectionsServer) mustEmbedUnimplementedCollectionsServer() {}
+func (UnimplementedCollectionsServer) testEmbeddedByValue()                     {}
+
+// UnsafeCollectionsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CollectionsServer will
+// result in compilation errors.
+type UnsafeCollectionsServer interface {
+	mustEmbedUnimplementedCollectionsServer()
+}
+
+func RegisterCollectionsServer(s grpc.ServiceRegistrar, srv CollectionsServer) {
+	// If the following call pancis, it indicates UnimplementedCollectionsServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Collections_ServiceDesc, srv)
+}
+
+func _Collections_ListPush_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPushRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionsServer).ListPush(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Collections_ListPush_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionsServer).ListPush(ctx, req.(*ListPushRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Collections_ListPop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionsServer).ListPop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Collections_ListPop_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionsServer).ListPop(ctx, req.(*ListPopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Collections_ListRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionsServer).ListRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Collections_ListRange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionsServer).ListRange(ctx, req.(*ListRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Collections_SetAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionsServer).SetAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Collections_SetAdd_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionsServer).SetAdd(ctx, req.(*MembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Collections_SetRemove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionsServer).SetRemove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Collections_SetRemove_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionsServer).SetRemove(ctx, req.(*MembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Collections_SetMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectionKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionsServer).SetMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Collections_SetMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionsServer).SetMembers(ctx, req.(*CollectionKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Collections_SetIntersect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetIntersectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionsServer).SetIntersect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Collections_SetIntersect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionsServer).SetIntersect(ctx, req.(*SetIntersectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Collections_HashSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HashSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionsServer).HashSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Collections_HashSet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionsServer).HashSet(ctx, req.(*HashSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Collections_HashGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HashFieldsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionsServer).HashGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Collections_HashGet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionsServer).HashGet(ctx, req.(*HashFieldsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Collections_HashDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HashFieldsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionsServer).HashDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Collections_HashDelete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionsServer).HashDelete(ctx, req.(*HashFieldsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Collections_HashGetAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectionKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionsServer).HashGetAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Collections_HashGetAll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionsServer).HashGetAll(ctx, req.(*CollectionKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Collections_ZSetAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ZSetAddRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionsServer).ZSetAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Collections_ZSetAdd_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionsServer).ZSetAdd(ctx, req.(*ZSetAddRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Collections_ZSetRemove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionsServer).ZSetRemove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Collections_ZSetRemove_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionsServer).ZSetRemove(ctx, req.(*MembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Collections_ZSetRangeByScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ZSetRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionsServer).ZSetRangeByScore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Collections_ZSetRangeByScore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionsServer).ZSetRangeByScore(ctx, req.(*ZSetRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Collections_ZSetRank_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ZSetRankRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionsServer).ZSetRank(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Collections_ZSetRank_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionsServer).ZSetRank(ctx, req.(*ZSetRankRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Collections_Apply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectionOp)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectionsServer).Apply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Collections_Apply_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectionsServer).Apply(ctx, req.(*CollectionOp))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Collections_ServiceDesc is the grpc.ServiceDesc for Collections service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Collections_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "Collections",
+	HandlerType: (*CollectionsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListPush",
+			Handler:    _Collections_ListPush_Handler,
+		},
+		{
+			MethodName: "ListPop",
+			Handler:    _Collections_ListPop_Handler,
+		},
+		{
+			MethodName: "ListRange",
+			Handler:    _Collections_ListRange_Handler,
+		},
+		{
+			MethodName: "SetAdd",
+			Handler:    _Collections_SetAdd_Handler,
+		},
+		{
+			MethodName: "SetRemove",
+			Handler:    _Collections_SetRemove_Handler,
+		},
+		{
+			MethodName: "SetMembers",
+			Handler:    _Collections_SetMembers_Handler,
+		},
+		{
+			MethodName: "SetIntersect",
+			Handler:    _Collections_SetIntersect_Handler,
+		},
+		{
+			MethodName: "HashSet",
+			Handler:    _Collections_HashSet_Handler,
+		},
+		{
+			MethodName: "HashGet",
+			Handler:    _Collections_HashGet_Handler,
+		},
+		{
+			MethodName: "HashDelete",
+			Handler:    _Collections_HashDelete_Handler,
+		},
+		{
+			MethodName: "HashGetAll",
+			Handler:    _Collections_HashGetAll_Handler,
+		},
+		{
+			MethodName: "ZSetAdd",
+			Handler:    _Collections_ZSetAdd_Handler,
+		},
+		{
+			MethodName: "ZSetRemove",
+			Handler:    _Collections_ZSetRemove_Handler,
+		},
+		{
+			MethodName: "ZSetRangeByScore",
+			Handler:    _Collections_ZSetRangeByScore_Handler,
+		},
+		{
+			MethodName: "ZSetRank",
+			Handler:    _Collections_ZSetRank_Handler,
+		},
+		{
+			MethodName: "Apply",
+			Handler:    _Collections_Apply_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "kvstore.proto",
+}
+
+const (
+	OpLog_Tail_FullMethodName = "/OpLog/Tail"
+)
+
+// OpLogClient is the client API for OpLog service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Committed operation log of a node, tailed by standby clusters
+type OpLogClient interface {
+	Tail(ctx context.Context, in *TailRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LogEntry], error)
+}
+
+type opLogClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOpLogClient(cc grpc.ClientConnInterface) OpLogClient {
+	return &opLogClient{cc}
+}
+
+func (c *opLogClient) Tail(ctx context.Context, in *TailRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LogEntry], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &OpLog_ServiceDesc.Streams[0], OpLog_Tail_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TailRequest, LogEntry]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OpLog_TailClient = grpc.ServerStreamingClient[LogEntry]
+
+// OpLogServer is the server API for OpLog service.
+// All implementations must embed UnimplementedOpLogServer
+// for forward compatibility.
+//
+// Committed operation log of a node, tailed by standby clusters
+type OpLogServer interface {
+	Tail(*TailRequest, grpc.ServerStreamingServer[LogEntry]) error
+	mustEmbedUnimplementedOpLogServer()
+}
+
+// UnimplementedOpLogServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedOpLogServer struct{}
+
+func (UnimplementedOpLogServer) Tail(*TailRequest, grpc.ServerStreamingServer[LogEntry]) error {
+	return status.Errorf(codes.Unimplemented, "method Tail not implemented")
+}
+func (UnimplementedOpLogServer) mustEmbedUnimplementedOpLogServer() {}
+func (UnimplementedOpLogServer) testEmbeddedByValue()               {}
+
+// UnsafeOpLogServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OpLogServer will
+// result in compilation errors.
+type UnsafeOpLogServer interface {
+	mustEmbedUnimplementedOpLogServer()
+}
+
+func RegisterOpLogServer(s grpc.ServiceRegistrar, srv OpLogServer) {
+	// If the following call pancis, it indicates UnimplementedOpLogServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&OpLog_ServiceDesc, srv)
+}
+
+func _OpLog_Tail_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TailRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OpLogServer).Tail(m, &grpc.GenericServerStream[TailRequest, LogEntry]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OpLog_TailServer = grpc.ServerStreamingServer[LogEntry]
+
+// OpLog_ServiceDesc is the grpc.ServiceDesc for OpLog service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OpLog_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "OpLog",
+	HandlerType: (*OpLogServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Tail",
+			Handler:       _OpLog_Tail_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "kvstore.proto",
 }
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"kvstore/iface"
+	"kvstore/storage"
+	"time"
+)
+
+type leaseServer struct {
+	node iface.NodeAPI
+	UnimplementedLeaseServer
+}
+
+func (s *leaseServer) Grant(ctx context.Context, req *LeaseGrantRequest) (*LeaseGrantResponse, error) {
+	ttl := time.Duration(req.TtlMs) * time.Millisecond
+	id, err := s.node.HandleLeaseGrant(requestMeta(ctx), req.Id, ttl)
+	return &LeaseGrantResponse{Id: id, TtlMs: req.TtlMs, Epoch: s.node.GetEpoch()}, toStatus(err)
+}
+
+func (s *leaseServer) Revoke(ctx context.Context, req *LeaseRevokeRequest) (*LeaseRevokeResponse, error) {
+	meta := requestMeta(ctx)
+	meta.Stamp, meta.Revoked = StampFromProto(req.Stamp), req.Keys
+	err := s.node.HandleLeaseRevoke(meta, req.Id)
+	return &LeaseRevokeResponse{Epoch: s.node.GetEpoch()}, toStatus(err)
+}
+
+// KeepAlive answers every keep-alive with the lease's TTL, 0 once the lease
+// is gone, until the client closes the stream.
+func (s *leaseServer) KeepAlive(stream Lease_KeepAliveServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		resp, err := s.renew(req.Id)
+		if err != nil {
+			return toStatus(err)
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *leaseServer) Renew(ctx context.Context, req *LeaseKeepAliveRequest) (*LeaseKeepAliveResponse, error) {
+	resp, err := s.renew(req.Id)
+	return resp, toStatus(err)
+}
+
+func (s *leaseServer) renew(id int64) (*LeaseKeepAliveResponse, error) {
+	ttl, err := s.node.HandleLeaseKeepAlive(id)
+	if errors.Is(err, storage.ErrLeaseNotFound) {
+		return &LeaseKeepAliveResponse{Id: id}, nil
+	}
+	return &LeaseKeepAliveResponse{Id: id, TtlMs: ttl.Milliseconds()}, err
+}
+
+func (s *leaseServer) TimeToLive(ctx context.Context, req *LeaseTimeToLiveRequest) (*LeaseTimeToLiveResponse, error) {
+	info, err := s.node.HandleLeaseTimeToLive(req.Id)
+	return LeaseInfoToProto(info), toStatus(err)
+}
+
+func LeaseInfoToProto(info iface.LeaseInfo) *LeaseTimeToLiveResponse {
+	return &LeaseTimeToLiveResponse{
+		Id:          info.ID,
+		TtlMs:       info.TTL.Milliseconds(),
+		RemainingMs: info.Remaining.Milliseconds(),
+		Keys:        info.Keys,
+	}
+}
+
+func LeaseInfoFromProto(resp *LeaseTimeToLiveResponse) iface.LeaseInfo {
+	return iface.LeaseInfo{
+		ID:        resp.GetId(),
+		TTL:       time.Duration(resp.GetTtlMs()) * time.Millisecond,
+		Remaining: time.Duration(resp.GetRemainingMs()) * time.Millisecond,
+		Keys:      resp.GetKeys(),
+	}
+}
@@ -0,0 +1,21 @@
+package server
+
+import (
+	"context"
+	"kvstore/iface"
+)
+
+type lockServer struct {
+	node iface.NodeAPI
+	UnimplementedLockServer
+}
+
+func (s *lockServer) Lock(ctx context.Context, req *LockRequest) (*LockResponse, error) {
+	key, token, err := s.node.HandleLock(ctx, req.Name, req.Lease)
+	return &LockResponse{Key: key, FencingToken: token, Epoch: s.node.GetEpoch()}, toStatus(err)
+}
+
+func (s *lockServer) Unlock(ctx context.Context, req *UnlockRequest) (*UnlockResponse, error) {
+	err := s.node.HandleUnlock(requestMeta(ctx), req.Key)
+	return &UnlockResponse{Epoch: s.node.GetEpoch()}, toStatus(err)
+}
@@ -0,0 +1,325 @@
+package server
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"kvstore/iface"
+	"kvstore/storage"
+	"log"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	memcacheMaxKey  = 250     // longest key, as in memcached
+	memcacheMaxItem = 1 << 20 // largest value, memcached's default item size
+	memcacheMaxTTL  = 30 * 24 * 60 * 60
+)
+
+// MemcacheServer speaks the memcached text protocol. Storage commands are
+// transactions on the key, so add, replace and cas are checked by the leader
+// of the key's group, followers forward them and keys of other shards are
+// routed to their owner, like writes through gRPC. The CAS unique of an item
+// is its version and its expiry a lease. Client flags are not stored: items
+// are returned with flags 0.
+type MemcacheServer struct {
+	node iface.NodeAPI
+}
+
+func NewMemcacheServer(n iface.NodeAPI) *MemcacheServer {
+	return &MemcacheServer{node: n}
+}
+
+func (s *MemcacheServer) StartMemcacheServer(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Printf("failed to listen: %v", err)
+		return err
+	}
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go s.serve(conn)
+	}
+}
+
+// serve runs the commands of conn until the client quits or the connection
+// fails. Replies are flushed once no pipelined command is left to read.
+func (s *MemcacheServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r, w := bufio.NewReader(conn), bufio.NewWriter(conn)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		args := strings.Fields(line)
+		if len(args) == 0 {
+			w.WriteString("ERROR\r\n")
+		} else if args[0] == "quit" {
+			w.Flush()
+			return
+		} else if err := s.exec(r, w, args); err != nil {
+			return // the data block of a storage command could not be read
+		}
+		if r.Buffered() == 0 {
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// exec runs one command. It fails only when the connection is unusable.
+func (s *MemcacheServer) exec(r *bufio.Reader, w *bufio.Writer, args []string) error {
+	cmd, args := args[0], args[1:]
+	noreply := len(args) > 0 && args[len(args)-1] == "noreply"
+	if noreply {
+		args = args[:len(args)-1]
+	}
+	reply := func(format string, a ...any) {
+		if !noreply {
+			fmt.Fprintf(w, format+"\r\n", a...)
+		}
+	}
+	for _, key := range args[:min(len(args), keyArgs(cmd))] {
+		if len(key) > memcacheMaxKey {
+			reply("CLIENT_ERROR key longer than %d bytes", memcacheMaxKey)
+			return nil
+		}
+	}
+
+	switch cmd {
+	case "get", "gets":
+		if len(args) == 0 {
+			w.WriteString("ERROR\r\n")
+			return nil
+		}
+		s.get(w, args, cmd == "gets")
+	case "set", "add", "replace", "cas":
+		return s.store(r, cmd, args, reply)
+	case "delete":
+		if len(args) != 1 {
+			reply("CLIENT_ERROR bad command line format")
+			return nil
+		}
+		ok, err := deleteIfExists(s.node, args[0])
+		reply("%s", memcacheOutcome(ok, err, "DELETED"))
+	case "incr", "decr":
+		if len(args) != 2 {
+			reply("CLIENT_ERROR bad command line format")
+			return nil
+		}
+		s.incr(cmd == "decr", args[0], args[1], reply)
+	case "touch":
+		if len(args) != 2 {
+			reply("CLIENT_ERROR bad command line format")
+			return nil
+		}
+		ttl, err := memcacheTTL(args[1])
+		if err != nil {
+			reply("CLIENT_ERROR %v", err)
+			return nil
+		}
+		var ok bool
+		if ttl < 0 {
+			ok, err = deleteIfExists(s.node, args[0])
+		} else {
+			ok, err = setExpiry(s.node, args[0], ttl)
+		}
+		reply("%s", memcacheOutcome(ok, err, "TOUCHED"))
+	case "version":
+		w.WriteString("VERSION kvstore\r\n")
+	default:
+		w.WriteString("ERROR\r\n")
+	}
+	return nil
+}
+
+// keyArgs returns how many of the arguments of cmd are keys checked before
+// running it. Storage commands check their key once their data is read.
+func keyArgs(cmd string) int {
+	switch cmd {
+	case "get", "gets":
+		return math.MaxInt
+	case "set", "add", "replace", "cas":
+		return 0
+	}
+	return 1
+}
+
+// get writes the items among keys that exist. A key holding a collection is
+// a miss.
+func (s *MemcacheServer) get(w *bufio.Writer, keys []string, cas bool) {
+	for _, key := range keys {
+		value, meta, err := s.node.HandleGetMeta(key)
+		if errors.Is(err, storage.ErrWrongType) {
+			continue
+		} else if err != nil {
+			fmt.Fprintf(w, "%s\r\n", memcacheError(err))
+			return
+		} else if meta.Version == 0 {
+			continue
+		}
+		if cas {
+			fmt.Fprintf(w, "VALUE %s 0 %d %d\r\n%s\r\n", key, len(value), meta.Version, value)
+		} else {
+			fmt.Fprintf(w, "VALUE %s 0 %d\r\n%s\r\n", key, len(value), value)
+		}
+	}
+	w.WriteString("END\r\n")
+}
+
+// store reads the data block of a storage command and writes it as one
+// transaction: add requires the key to be missing, replace to exist and cas
+// to be at the version gets returned. An expiry attaches the key to a new
+// lease, and an expiry in the past deletes it.
+func (s *MemcacheServer) store(r *bufio.Reader, cmd string, args []string, reply func(string, ...any)) error {
+	want := 4
+	if cmd == "cas" {
+		want = 5
+	}
+	if len(args) != want {
+		reply("CLIENT_ERROR bad command line format")
+		return nil
+	}
+	key := args[0]
+	_, flagsErr := strconv.ParseUint(args[1], 10, 32)
+	size, err := strconv.Atoi(args[3])
+	if err != nil || size < 0 {
+		reply("CLIENT_ERROR bad data chunk")
+		return errors.New("bad data chunk")
+	} else if size > memcacheMaxItem {
+		reply("SERVER_ERROR object too large for cache")
+		_, err := r.Discard(size + 2)
+		return err
+	}
+	data := make([]byte, size+2)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return err
+	}
+	if string(data[size:]) != "\r\n" {
+		reply("CLIENT_ERROR bad data chunk")
+		return nil
+	}
+	value := string(data[:size])
+
+	ttl, err := memcacheTTL(args[2])
+	switch {
+	case len(key) > memcacheMaxKey:
+		reply("CLIENT_ERROR key longer than %d bytes", memcacheMaxKey)
+		return nil
+	case flagsErr != nil:
+		reply("CLIENT_ERROR bad command line format")
+		return nil
+	case err != nil:
+		reply("CLIENT_ERROR %v", err)
+		return nil
+	}
+
+	var txn iface.Txn
+	switch cmd {
+	case "add", "replace":
+		txn.Compares = []storage.Compare{{Key: key, Target: storage.CMP_EXISTS, Op: storage.CMP_EQ, Exists: cmd == "replace"}}
+	case "cas":
+		version, err := strconv.ParseInt(args[4], 10, 64)
+		if err != nil {
+			reply("CLIENT_ERROR bad command line format")
+			return nil
+		}
+		txn.Compares = []storage.Compare{
+			{Key: key, Target: storage.CMP_EXISTS, Op: storage.CMP_EQ, Exists: true},
+			{Key: key, Target: storage.CMP_VERSION, Op: storage.CMP_EQ, Version: version},
+		}
+	}
+	write := storage.BatchOp{Key: key, Value: value, Delete: ttl < 0}
+	if ttl > 0 {
+		if write.Lease, err = s.node.HandleLeaseGrant(iface.RequestMeta{}, 0, ttl); err != nil {
+			reply("%s", memcacheError(err))
+			return nil
+		}
+	}
+	txn.Success = []storage.BatchOp{write}
+
+	ok, current, err := s.node.HandleTxn(iface.RequestMeta{}, txn)
+	switch {
+	case err != nil:
+		reply("%s", memcacheError(err))
+	case ok:
+		reply("STORED")
+	case cmd == "cas" && current[key].Exists:
+		reply("EXISTS")
+	case cmd == "cas":
+		reply("NOT_FOUND")
+	default:
+		reply("NOT_STORED")
+	}
+	if write.Lease != 0 && (err != nil || !ok) {
+		s.node.HandleLeaseRevoke(iface.RequestMeta{}, write.Lease)
+	}
+	return nil
+}
+
+// incr adds to or subtracts from an item holding an unsigned 64-bit decimal,
+// keeping its expiry. As in memcached, increments wrap around and decrements
+// stop at 0. The leader computes the result inside one transaction.
+func (s *MemcacheServer) incr(decr bool, key, amount string, reply func(string, ...any)) {
+	delta, err := strconv.ParseUint(amount, 10, 64)
+	if err != nil {
+		reply("CLIENT_ERROR invalid numeric delta argument")
+		return
+	}
+	ok, current, err := s.node.HandleTxn(iface.RequestMeta{}, iface.Txn{
+		Compares: []storage.Compare{{Key: key, Target: storage.CMP_EXISTS, Op: storage.CMP_EQ, Exists: true}},
+		Success:  []storage.BatchOp{{Key: key, Increment: true, Delta: int64(delta), Unsigned: true, Decrement: decr}},
+	})
+	if err != nil || !ok {
+		reply("%s", memcacheOutcome(ok, err, ""))
+		return
+	}
+	n, err := storage.AddUnsigned(current[key].Value, true, delta, decr)
+	if err != nil {
+		reply("%s", memcacheError(err))
+		return
+	}
+	reply("%d", n)
+}
+
+// memcacheTTL turns an expiration time into a TTL: 0 means none, up to 30
+// days it counts seconds from now and beyond it is a Unix time. A negative
+// TTL means the item has already expired.
+func memcacheTTL(exptime string) (time.Duration, error) {
+	n, err := strconv.ParseInt(exptime, 10, 64)
+	switch {
+	case err != nil:
+		return 0, fmt.Errorf("invalid exptime argument")
+	case n == 0:
+		return 0, nil
+	case n < 0:
+		return -1, nil
+	case n > memcacheMaxTTL:
+		if ttl := time.Until(time.Unix(n, 0)); ttl > 0 {
+			return ttl, nil
+		}
+		return -1, nil
+	}
+	return time.Duration(n) * time.Second, nil
+}
+
+// memcacheOutcome returns the reply of a command acting on an existing key.
+func memcacheOutcome(ok bool, err error, done string) string {
+	switch {
+	case err != nil:
+		return memcacheError(err)
+	case !ok:
+		return "NOT_FOUND"
+	}
+	return done
+}
@@ -0,0 +1,19 @@
+package server
+
+import "kvstore/storage"
+
+func EntriesToProto(entries []storage.Entry) []*KeyValue {
+	out := make([]*KeyValue, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, &KeyValue{Key: e.Key, Value: e.Value, Meta: KeyMetaToProto(e.Meta)})
+	}
+	return out
+}
+
+func EntriesFromProto(kvs []*KeyValue) []storage.Entry {
+	out := make([]storage.Entry, 0, len(kvs))
+	for _, kv := range kvs {
+		out = append(out, storage.Entry{Key: kv.GetKey(), Value: kv.GetValue(), Meta: KeyMetaFromProto(kv.GetMeta())})
+	}
+	return out
+}
@@ -0,0 +1,54 @@
+package server
+
+import (
+	"kvstore/iface"
+	"time"
+)
+
+type opLogServer struct {
+	node iface.NodeAPI
+	UnimplementedOpLogServer
+}
+
+func (s *opLogServer) Tail(req *TailRequest, stream OpLog_TailServer) error {
+	err := s.node.HandleTail(stream.Context(), req.LogId, req.FromIndex, req.Prefix, func(e iface.LogEntry) error {
+		return stream.Send(LogEntryToProto(e))
+	})
+	return toStatus(err)
+}
+
+func LogEntryToProto(e iface.LogEntry) *LogEntry {
+	out := &LogEntry{
+		LogId:     e.LogID,
+		Index:     e.Index,
+		Op:        e.Op,
+		Key:       e.Key,
+		Value:     e.Value,
+		HeadIndex: e.HeadIndex,
+		Snapshot:  e.Snapshot,
+		Ops:       BatchToProto(e.Ops),
+		Records:   KeyRecordsToProto(e.Records),
+	}
+	if !e.Committed.IsZero() {
+		out.Committed = e.Committed.UnixNano()
+	}
+	return out
+}
+
+func LogEntryFromProto(e *LogEntry) iface.LogEntry {
+	out := iface.LogEntry{
+		LogID:     e.GetLogId(),
+		Index:     e.GetIndex(),
+		Op:        e.GetOp(),
+		Key:       e.GetKey(),
+		Value:     e.GetValue(),
+		HeadIndex: e.GetHeadIndex(),
+		Snapshot:  e.GetSnapshot(),
+		Ops:       BatchFromProto(e.GetOps()),
+		Records:   KeyRecordsFromProto(e.GetRecords()),
+	}
+	if e.GetCommitted() != 0 {
+		out.Committed = time.Unix(0, e.GetCommitted())
+	}
+	return out
+}
@@ -0,0 +1,53 @@
+package server
+
+import (
+	"context"
+	"kvstore/iface"
+	"kvstore/storage"
+)
+
+type replicaServer struct {
+	node iface.NodeAPI
+	UnimplementedReplicaServer
+}
+
+func (s *replicaServer) Store(ctx context.Context, req *StoreRequest) (*StoreResponse, error) {
+	err := s.node.HandleStore(req.Key, SiblingsFromProto(req.Siblings), int(req.HintFor))
+	return &StoreResponse{}, toStatus(err)
+}
+
+func (s *replicaServer) Fetch(ctx context.Context, req *FetchRequest) (*FetchResponse, error) {
+	return &FetchResponse{Siblings: SiblingsToProto(s.node.HandleFetch(req.Key))}, nil
+}
+
+func ClockToProto(vc storage.VectorClock) *VectorClock {
+	out := &VectorClock{Counters: make(map[int32]uint64, len(vc))}
+	for id, c := range vc {
+		out.Counters[int32(id)] = c
+	}
+	return out
+}
+
+func ClockFromProto(vc *VectorClock) storage.VectorClock {
+	out := make(storage.VectorClock, len(vc.GetCounters()))
+	for id, c := range vc.GetCounters() {
+		out[int(id)] = c
+	}
+	return out
+}
+
+func SiblingsToProto(siblings []storage.Sibling) []*Sibling {
+	out := make([]*Sibling, 0, len(siblings))
+	for _, s := range siblings {
+		out = append(out, &Sibling{Value: s.Value, Deleted: s.Deleted, Clock: ClockToProto(s.Clock)})
+	}
+	return out
+}
+
+func SiblingsFromProto(siblings []*Sibling) []storage.Sibling {
+	out := make([]storage.Sibling, 0, len(siblings))
+	for _, s := range siblings {
+		out = append(out, storage.Sibling{Value: s.GetValue(), Deleted: s.GetDeleted(), Clock: ClockFromProto(s.GetClock())})
+	}
+	return out
+}
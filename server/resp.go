@@ -0,0 +1,679 @@
+package server
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"kvstore/iface"
+	"kvstore/storage"
+	"log"
+	"maps"
+	"math"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	respMaxBulk = 512 << 20 // largest bulk string a client may send, as in Redis
+	respMaxArgs = 1 << 20   // most arguments of one command
+	scanCount   = 10        // keys per SCAN page when the client gives no COUNT
+	scanCursors = 16        // SCAN iterations a connection keeps open
+)
+
+// RESPServer speaks the Redis protocol, RESP2 and RESP3 after HELLO 3, for
+// the plain key commands. Commands go through the same NodeAPI calls as the
+// gRPC server, so followers forward writes to their leader and keys of other
+// shards are routed to their owner. When the owner's group publishes a
+// RESPAddr, key commands are answered with a MOVED redirect to it instead,
+// as a Redis cluster would.
+type RESPServer struct {
+	node iface.NodeAPI
+}
+
+func NewRESPServer(n iface.NodeAPI) *RESPServer {
+	return &RESPServer{node: n}
+}
+
+// respConn is a client connection and the protocol version it speaks.
+type respConn struct {
+	r     *bufio.Reader
+	w     *bufio.Writer
+	proto int
+
+	cursors    map[uint64]scanCursor // open SCAN iterations by cursor
+	lastCursor uint64
+}
+
+// scanCursor is where a SCAN iteration stands: the last key it returned and
+// the revision all its pages read.
+type scanCursor struct {
+	after    string
+	revision int64
+}
+
+func (s *RESPServer) StartRESPServer(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Printf("failed to listen: %v", err)
+		return err
+	}
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go s.serve(conn)
+	}
+}
+
+// serve runs the commands of conn until the client quits or the connection
+// fails. Replies are flushed once no pipelined command is left to read.
+func (s *RESPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	c := &respConn{r: bufio.NewReader(conn), w: bufio.NewWriter(conn), proto: 2}
+	for {
+		args, err := c.readCommand()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				c.writeError("ERR Protocol error: " + err.Error())
+				c.w.Flush()
+			}
+			return
+		}
+		if len(args) == 0 {
+			continue
+		}
+		quit := strings.EqualFold(args[0], "quit")
+		if quit {
+			c.writeSimple("OK")
+		} else {
+			s.exec(c, args)
+		}
+		if c.r.Buffered() == 0 || quit {
+			if err := c.w.Flush(); err != nil || quit {
+				return
+			}
+		}
+	}
+}
+
+// respArity is the least number of arguments of each command, its name
+// included, and whether its arguments from the second on are keys.
+var respArity = map[string]struct {
+	min  int
+	keys bool
+}{
+	"ping": {1, false}, "echo": {2, false}, "hello": {1, false}, "select": {2, false}, "client": {2, false}, "command": {1, false},
+	"get": {2, true}, "set": {3, true}, "del": {2, true}, "exists": {2, true}, "expire": {3, true},
+	"incr": {2, true}, "decr": {2, true}, "incrby": {3, true}, "decrby": {3, true},
+	"dbsize": {1, false}, "keys": {2, false}, "scan": {2, false},
+}
+
+func (s *RESPServer) exec(c *respConn, args []string) {
+	cmd := strings.ToLower(args[0])
+	arity, ok := respArity[cmd]
+	if !ok {
+		c.writeError(fmt.Sprintf("ERR unknown command '%s'", args[0]))
+		return
+	} else if len(args) < arity.min {
+		c.writeError(fmt.Sprintf("ERR wrong number of arguments for '%s' command", cmd))
+		return
+	}
+	if arity.keys {
+		keys := args[1:2]
+		if cmd == "del" || cmd == "exists" {
+			keys = args[1:]
+		}
+		if redirect := s.redirect(keys); redirect != "" {
+			c.writeError(redirect)
+			return
+		}
+	}
+
+	switch cmd {
+	case "ping":
+		if len(args) > 1 {
+			c.writeBulk(args[1])
+		} else {
+			c.writeSimple("PONG")
+		}
+	case "echo":
+		c.writeBulk(args[1])
+	case "hello":
+		s.hello(c, args[1:])
+	case "select":
+		if args[1] != "0" {
+			c.writeError("ERR DB index is out of range")
+			return
+		}
+		c.writeSimple("OK")
+	case "client":
+		c.writeSimple("OK") // SETNAME, SETINFO and the like have nothing to keep
+	case "command":
+		c.writeArray(0)
+	case "get":
+		s.get(c, args[1])
+	case "set":
+		s.set(c, args[1], args[2], args[3:])
+	case "del":
+		s.del(c, args[1:])
+	case "exists":
+		s.exists(c, args[1:])
+	case "expire":
+		s.expire(c, args[1], args[2])
+	case "incr", "decr", "incrby", "decrby":
+		s.incr(c, cmd, args[1:])
+	case "dbsize":
+		count, err := s.node.HandleCount(false)
+		if err != nil {
+			c.writeErr(err)
+			return
+		}
+		c.writeInt(count)
+	case "keys":
+		s.keys(c, args[1])
+	case "scan":
+		s.scan(c, args[1], args[2:])
+	}
+}
+
+// redirect returns the MOVED error sending the client to the group owning
+// keys when it has a Redis listener, or an empty string when this node
+// serves them. Keys served by different listeners are refused.
+func (s *RESPServer) redirect(keys []string) string {
+	target := ""
+	for i, key := range keys {
+		addr := ""
+		if g, remote := s.node.KeyOwner(key); remote {
+			addr = g.RESPAddr
+		}
+		if i > 0 && addr != target {
+			return "CROSSSLOT Keys in request don't hash to the same slot"
+		}
+		target = addr
+	}
+	if target == "" {
+		return ""
+	}
+	return fmt.Sprintf("MOVED %d %s", keySlot(keys[0]), target)
+}
+
+func (s *RESPServer) hello(c *respConn, args []string) {
+	if len(args) > 0 {
+		proto, err := strconv.Atoi(args[0])
+		if err != nil || (proto != 2 && proto != 3) {
+			c.writeError("NOPROTO unsupported protocol version")
+			return
+		}
+		c.proto = proto
+	}
+	mode := "standalone"
+	if len(s.node.ClusterMetadata().Shards) > 1 {
+		mode = "cluster"
+	}
+	c.writeMap(3)
+	c.writeBulk("server")
+	c.writeBulk("kvstore")
+	c.writeBulk("proto")
+	c.writeInt(int64(c.proto))
+	c.writeBulk("mode")
+	c.writeBulk(mode)
+}
+
+func (s *RESPServer) get(c *respConn, key string) {
+	value, meta, err := s.node.HandleGetMeta(key)
+	switch {
+	case err != nil:
+		c.writeErr(err)
+	case meta.Version == 0:
+		c.writeNull()
+	default:
+		c.writeBulk(value)
+	}
+}
+
+// set handles SET with its EX, PX, NX and XX options. An expiry attaches the
+// key to a new lease with that TTL; a plain SET detaches the key from its
+// lease, which clears its expiry as in Redis.
+func (s *RESPServer) set(c *respConn, key, value string, opts []string) {
+	var ttl time.Duration
+	var nx, xx bool
+	for i := 0; i < len(opts); i++ {
+		switch opt := strings.ToLower(opts[i]); {
+		case opt == "nx":
+			nx = true
+		case opt == "xx":
+			xx = true
+		case (opt == "ex" || opt == "px") && i+1 < len(opts):
+			n, err := strconv.ParseInt(opts[i+1], 10, 64)
+			if err != nil || n <= 0 || n > math.MaxInt64/int64(time.Second) {
+				c.writeError("ERR invalid expire time in 'set' command")
+				return
+			}
+			i++
+			ttl = time.Duration(n) * time.Millisecond
+			if opt == "ex" {
+				ttl *= 1000
+			}
+		default:
+			c.writeError("ERR syntax error")
+			return
+		}
+	}
+	if nx && xx {
+		c.writeError("ERR syntax error")
+		return
+	}
+
+	if s.node.Leaderless() {
+		// The node refuses the other commands itself
+		c.writeErr(fmt.Errorf("%w: values of the leaderless mode need their vector clock", iface.ErrUnsupported))
+		return
+	}
+	var lease int64
+	if ttl > 0 {
+		id, err := s.node.HandleLeaseGrant(iface.RequestMeta{}, 0, ttl)
+		if err != nil {
+			c.writeErr(err)
+			return
+		}
+		lease = id
+	}
+	meta := iface.RequestMeta{}
+	ok, err := true, error(nil)
+	switch {
+	case nx || xx:
+		ok, _, err = s.node.HandleTxn(meta, iface.Txn{
+			Compares: []storage.Compare{{Key: key, Target: storage.CMP_EXISTS, Op: storage.CMP_EQ, Exists: xx}},
+			Success:  []storage.BatchOp{{Key: key, Value: value, Lease: lease}},
+		})
+	case lease != 0:
+		err = s.node.HandlePutWithLease(meta, key, value, lease)
+	default:
+		err = s.node.HandlePut(meta, key, value)
+	}
+	if lease != 0 && (err != nil || !ok) {
+		// No key was attached to the lease, which would otherwise be left
+		// granted until its TTL runs out
+		if err := s.node.HandleLeaseRevoke(meta, lease); err != nil {
+			log.Printf("Could not revoke lease %d of a SET that did not write: %v", lease, err)
+		}
+	}
+	switch {
+	case err != nil:
+		c.writeErr(err)
+	case !ok:
+		c.writeNull()
+	default:
+		c.writeSimple("OK")
+	}
+}
+
+// del deletes keys one at a time and counts those that existed.
+func (s *RESPServer) del(c *respConn, keys []string) {
+	var deleted int64
+	for _, key := range keys {
+		ok, err := deleteIfExists(s.node, key)
+		if err != nil {
+			c.writeErr(err)
+			return
+		} else if ok {
+			deleted++
+		}
+	}
+	c.writeInt(deleted)
+}
+
+// exists counts the keys that exist, collections included; a key given twice
+// counts twice.
+func (s *RESPServer) exists(c *respConn, keys []string) {
+	var count int64
+	for _, key := range keys {
+		_, meta, err := s.node.HandleGetMeta(key)
+		switch {
+		case errors.Is(err, storage.ErrWrongType):
+			count++
+		case err != nil:
+			c.writeErr(err)
+			return
+		case meta.Version > 0:
+			count++
+		}
+	}
+	c.writeInt(count)
+}
+
+// expire sets the TTL of key. Like in Redis, a TTL that is not positive
+// deletes the key.
+func (s *RESPServer) expire(c *respConn, key, seconds string) {
+	n, err := strconv.ParseInt(seconds, 10, 64)
+	if err != nil || n > math.MaxInt64/int64(time.Second) {
+		c.writeError("ERR value is not an integer or out of range")
+		return
+	}
+	if n <= 0 {
+		c.writeBool(deleteIfExists(s.node, key))
+		return
+	}
+	c.writeBool(setExpiry(s.node, key, time.Duration(n)*time.Second))
+}
+
+func (s *RESPServer) incr(c *respConn, cmd string, args []string) {
+	delta := int64(1)
+	if cmd == "incrby" || cmd == "decrby" {
+		n, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			c.writeError("ERR value is not an integer or out of range")
+			return
+		}
+		delta = n
+	}
+	if cmd == "decr" || cmd == "decrby" {
+		if delta == math.MinInt64 {
+			c.writeErr(fmt.Errorf("%w: cannot negate %d", storage.ErrOverflow, delta))
+			return
+		}
+		delta = -delta
+	}
+	value, err := s.node.HandleIncrement(iface.RequestMeta{}, args[0], delta)
+	if err != nil {
+		c.writeErr(err)
+		return
+	}
+	c.writeInt(value)
+}
+
+// keys lists the keys matching pattern, collections included as in EXISTS
+// and DBSIZE, scanning only below the literal prefix of the pattern.
+func (s *RESPServer) keys(c *respConn, pattern string) {
+	start, end := globRange(pattern)
+	found, _, _, err := s.node.HandleScanKeys(start, end, 0, 0)
+	if err != nil {
+		c.writeErr(err)
+		return
+	}
+	var keys []string
+	for _, key := range found {
+		if globMatch(pattern, key) {
+			keys = append(keys, key)
+		}
+	}
+	c.writeStrings(keys)
+}
+
+// scan serves a page of SCAN. Redis clients expect numeric cursors, so the
+// connection keeps where each iteration stands under the cursor it returned:
+// the page starts after the last key returned and reads the revision the
+// first page read, so a key that exists through the whole iteration is
+// returned exactly once; collections keep no history and are listed as each
+// page finds them. The oldest iterations are forgotten past scanCursors.
+// MATCH filters a page after it was read, so pages may come back short or
+// empty.
+func (s *RESPServer) scan(c *respConn, cursor string, opts []string) {
+	pos, err := strconv.ParseUint(cursor, 10, 64)
+	if err != nil {
+		c.writeError("ERR invalid cursor")
+		return
+	}
+	pattern, count := "*", scanCount
+	for i := 0; i < len(opts); i += 2 {
+		if i+1 == len(opts) {
+			c.writeError("ERR syntax error")
+			return
+		}
+		switch strings.ToLower(opts[i]) {
+		case "match":
+			pattern = opts[i+1]
+		case "count":
+			if count, err = strconv.Atoi(opts[i+1]); err != nil || count < 1 {
+				c.writeError("ERR value is not an integer or out of range")
+				return
+			}
+		default:
+			c.writeError("ERR syntax error")
+			return
+		}
+	}
+
+	start, end := globRange(pattern)
+	at, ok := c.cursors[pos]
+	if pos != 0 && !ok {
+		c.writeError("ERR invalid cursor")
+		return
+	}
+	delete(c.cursors, pos)
+	if pos != 0 {
+		start = max(start, at.after+"\x00")
+	}
+	found, read, more, err := s.node.HandleScanKeys(start, end, count, at.revision)
+	if err != nil {
+		c.writeErr(err)
+		return
+	}
+	var keys []string
+	for _, key := range found {
+		if globMatch(pattern, key) {
+			keys = append(keys, key)
+		}
+	}
+	next := "0"
+	if more && len(found) > 0 {
+		next = strconv.FormatUint(c.openCursor(scanCursor{after: found[len(found)-1], revision: read}), 10)
+	}
+	c.writeArray(2)
+	c.writeBulk(next)
+	c.writeStrings(keys)
+}
+
+// openCursor keeps at under a new cursor, forgetting the oldest one when
+// scanCursors are open.
+func (c *respConn) openCursor(at scanCursor) uint64 {
+	if c.cursors == nil {
+		c.cursors = make(map[uint64]scanCursor)
+	}
+	if len(c.cursors) >= scanCursors {
+		delete(c.cursors, slices.Min(slices.Collect(maps.Keys(c.cursors))))
+	}
+	c.lastCursor++
+	c.cursors[c.lastCursor] = at
+	return c.lastCursor
+}
+
+// readCommand reads an array of bulk strings, or an inline command as typed
+// into telnet.
+func (c *respConn) readCommand() ([]string, error) {
+	line, err := c.readLine()
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "*") {
+		return strings.Fields(line), nil
+	}
+	n, err := strconv.Atoi(line[1:])
+	if err != nil || n > respMaxArgs {
+		return nil, fmt.Errorf("invalid multibulk length")
+	}
+	args := make([]string, 0, max(n, 0))
+	for i := 0; i < n; i++ {
+		line, err := c.readLine()
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimPrefix(line, "$"))
+		if !strings.HasPrefix(line, "$") || err != nil || size < 0 || size > respMaxBulk {
+			return nil, fmt.Errorf("invalid bulk length")
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(c.r, buf); err != nil {
+			return nil, err
+		}
+		if string(buf[size:]) != "\r\n" {
+			return nil, fmt.Errorf("bulk string not terminated by CRLF")
+		}
+		args = append(args, string(buf[:size]))
+	}
+	return args, nil
+}
+
+func (c *respConn) readLine() (string, error) {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (c *respConn) writeSimple(s string) { fmt.Fprintf(c.w, "+%s\r\n", s) }
+func (c *respConn) writeError(s string)  { fmt.Fprintf(c.w, "-%s\r\n", s) }
+func (c *respConn) writeErr(err error)   { c.writeError(respError(err)) }
+func (c *respConn) writeInt(n int64)     { fmt.Fprintf(c.w, ":%d\r\n", n) }
+func (c *respConn) writeBulk(s string)   { fmt.Fprintf(c.w, "$%d\r\n%s\r\n", len(s), s) }
+func (c *respConn) writeArray(n int)     { fmt.Fprintf(c.w, "*%d\r\n", n) }
+
+// writeNull writes the null of the connection's protocol version.
+func (c *respConn) writeNull() {
+	if c.proto == 3 {
+		c.w.WriteString("_\r\n")
+	} else {
+		c.w.WriteString("$-1\r\n")
+	}
+}
+
+// writeMap starts a map of n pairs, sent as a flat array under RESP2.
+func (c *respConn) writeMap(n int) {
+	if c.proto == 3 {
+		fmt.Fprintf(c.w, "%%%d\r\n", n)
+	} else {
+		c.writeArray(2 * n)
+	}
+}
+
+// writeBool writes the 1 or 0 Redis answers a command with, or err.
+func (c *respConn) writeBool(ok bool, err error) {
+	switch {
+	case err != nil:
+		c.writeErr(err)
+	case ok:
+		c.writeInt(1)
+	default:
+		c.writeInt(0)
+	}
+}
+
+func (c *respConn) writeStrings(values []string) {
+	c.writeArray(len(values))
+	for _, v := range values {
+		c.writeBulk(v)
+	}
+}
+
+// globRange returns the key range holding every match of a glob pattern:
+// the keys starting with its literal prefix.
+func globRange(pattern string) (string, string) {
+	prefix := pattern
+	if i := strings.IndexAny(pattern, `*?[\`); i >= 0 {
+		prefix = pattern[:i]
+	}
+	if prefix == "" {
+		return "", ""
+	}
+	return prefix, storage.PrefixEnd(prefix)
+}
+
+// globMatch reports whether key matches a Redis glob pattern: * and ? match
+// any run of characters and any one character, [...] a class with ranges and
+// a leading ^ for negation, and \ escapes the next character. On a mismatch
+// the last * takes one more character and matching resumes after it, which
+// keeps the match linear in the pattern per character of key.
+func globMatch(pattern, key string) bool {
+	p, k := 0, 0
+	star, starKey := -1, 0
+	for k < len(key) {
+		if p < len(pattern) && pattern[p] == '*' {
+			p++
+			star, starKey = p, k
+			continue
+		}
+		if p < len(pattern) {
+			if next, ok := matchOne(pattern, p, key[k]); ok {
+				p, k = next, k+1
+				continue
+			}
+		}
+		if star < 0 {
+			return false
+		}
+		starKey++
+		p, k = star, starKey
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
+// matchOne matches b against the element of pattern at p, other than *, and
+// returns where the next element starts.
+func matchOne(pattern string, p int, b byte) (int, bool) {
+	switch pattern[p] {
+	case '?':
+		return p + 1, true
+	case '[':
+		end := strings.IndexByte(pattern[p+1:], ']')
+		if end < 0 {
+			return 0, false
+		}
+		class := pattern[p+1 : p+1+end]
+		negate := strings.HasPrefix(class, "^")
+		if negate {
+			class = class[1:]
+		}
+		return p + end + 2, classMatch(class, b) != negate
+	case '\\':
+		if p+1 < len(pattern) {
+			p++
+		}
+	}
+	return p + 1, pattern[p] == b
+}
+
+func classMatch(class string, b byte) bool {
+	for i := 0; i < len(class); i++ {
+		if i+2 < len(class) && class[i+1] == '-' {
+			if class[i] <= b && b <= class[i+2] {
+				return true
+			}
+			i += 2
+		} else if class[i] == b {
+			return true
+		}
+	}
+	return false
+}
+
+// keySlot returns the Redis cluster slot of key, the CRC16 of its hash tag
+// or of the whole key, for MOVED replies. Keys are placed by the node's own
+// ring, so the slot only keeps the reply well formed for cluster clients.
+func keySlot(key string) int {
+	if i := strings.IndexByte(key, '{'); i >= 0 {
+		if j := strings.IndexByte(key[i+1:], '}'); j > 0 {
+			key = key[i+1 : i+1+j]
+		}
+	}
+	var crc uint16
+	for i := 0; i < len(key); i++ {
+		crc ^= uint16(key[i]) << 8
+		for b := 0; b < 8; b++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return int(crc % 16384)
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"fmt"
+	"kvstore/iface"
+	"kvstore/storage"
+	"time"
+)
+
+// casRetries bounds the attempts of a read-modify-write against concurrent
+// writes of its key.
+const casRetries = 3
+
+// The text protocols express some commands as a read of the key followed by
+// a transaction that writes it only if nobody did in between. Transactions
+// run on the leader of the key's group, so these commands are forwarded and
+// routed like any other write.
+
+// deleteIfExists deletes key and reports whether it existed.
+func deleteIfExists(n iface.NodeAPI, key string) (bool, error) {
+	ok, _, err := n.HandleTxn(iface.RequestMeta{}, iface.Txn{
+		Compares: []storage.Compare{{Key: key, Target: storage.CMP_EXISTS, Op: storage.CMP_EQ, Exists: true}},
+		Success:  []storage.BatchOp{{Key: key, Delete: true}},
+	})
+	return ok, err
+}
+
+// rewrite replaces key with the write change makes of its current value and
+// metadata, provided the key's version did not move in between. It reports
+// false when the key does not exist, and ErrBusy when it kept changing.
+func rewrite(n iface.NodeAPI, key string, change func(value string, meta storage.KeyMeta) (storage.BatchOp, error)) (storage.BatchOp, bool, error) {
+	for i := 0; i < casRetries; i++ {
+		value, meta, err := n.HandleGetMeta(key)
+		if err != nil || meta.Version == 0 {
+			return storage.BatchOp{}, false, err
+		}
+		op, err := change(value, meta)
+		if err != nil {
+			return storage.BatchOp{}, true, err
+		}
+		ok, _, err := n.HandleTxn(iface.RequestMeta{}, iface.Txn{
+			Compares: []storage.Compare{{Key: key, Target: storage.CMP_VERSION, Op: storage.CMP_EQ, Version: meta.Version}},
+			Success:  []storage.BatchOp{op},
+		})
+		if err != nil || ok {
+			return op, true, err
+		}
+	}
+	return storage.BatchOp{}, true, fmt.Errorf("%w: %s kept changing", iface.ErrBusy, key)
+}
+
+// setExpiry attaches key to a new lease of ttl, or detaches it from its lease
+// when ttl is 0, by rewriting its value. It reports false when the key does
+// not exist. Expiries are leases, so each one costs a lease of its own.
+func setExpiry(n iface.NodeAPI, key string, ttl time.Duration) (bool, error) {
+	meta := iface.RequestMeta{}
+	var lease int64
+	if ttl > 0 {
+		id, err := n.HandleLeaseGrant(meta, 0, ttl)
+		if err != nil {
+			return false, err
+		}
+		lease = id
+	}
+	_, ok, err := rewrite(n, key, func(value string, _ storage.KeyMeta) (storage.BatchOp, error) {
+		return storage.BatchOp{Key: key, Value: value, Lease: lease}, nil
+	})
+	if lease != 0 && (!ok || err != nil) {
+		n.HandleLeaseRevoke(meta, lease)
+	}
+	return ok, err
+}
@@ -2,8 +2,11 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"kvstore/iface"
+	"kvstore/storage"
 	"log"
+	"math"
 	"net"
 	"strconv"
 
@@ -21,39 +24,156 @@ func NewServer(n iface.NodeAPI) *GRPCServer {
 	return &GRPCServer{node: n}
 }
 
-func (s *GRPCServer) Put(ctx context.Context, req *PutRequest) (*PutResponse, error) {
-	var requesterID int
-	if md, ok := metadata.FromIncomingContext(ctx); ok {
-		if val, ok := md["requesterid"]; ok && len(val) > 0 {
-			id, err := strconv.Atoi(val[0])
-			if err == nil {
-				requesterID = id
-			}
+// requestMeta reads the requester id, leader epoch and leader set by peers, and the
+// flag marking writes of a cross-cluster replication link.
+func requestMeta(ctx context.Context) iface.RequestMeta {
+	var meta iface.RequestMeta
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return meta
+	}
+	if val, ok := md["requesterid"]; ok && len(val) > 0 {
+		id, err := strconv.Atoi(val[0])
+		if err == nil {
+			meta.RequesterID = id
+		}
+	}
+	if val, ok := md["epoch"]; ok && len(val) > 0 {
+		epoch, err := strconv.ParseUint(val[0], 10, 64)
+		if err == nil {
+			meta.Epoch = epoch
+		}
+	}
+	if val, ok := md["leader"]; ok && len(val) > 0 {
+		id, err := strconv.Atoi(val[0])
+		if err == nil {
+			meta.Leader = id
 		}
 	}
+	if val, ok := md["replicated"]; ok && len(val) > 0 {
+		meta.Replicated = val[0] == "true"
+	}
+	return meta
+}
+
+func (s *GRPCServer) Put(ctx context.Context, req *PutRequest) (*PutResponse, error) {
+	meta := requestMeta(ctx)
+	meta.ClientID, meta.Seq, meta.Stamp = req.ClientId, req.Sequence, StampFromProto(req.Stamp)
+	if req.IfAbsent || req.IfVersion > 0 {
+		cond := iface.Condition{IfAbsent: req.IfAbsent, IfVersion: req.IfVersion}
+		ok, current, err := s.node.HandleConditionalPut(meta, req.Key, req.Value, cond)
+		return &PutResponse{Success: ok, Epoch: s.node.GetEpoch(), Current: failedState(ok, err, current)}, toStatus(err)
+	}
+	if req.Lease != 0 {
+		err := s.node.HandlePutWithLease(meta, req.Key, req.Value, req.Lease)
+		return &PutResponse{Success: err == nil, Epoch: s.node.GetEpoch()}, toStatus(err)
+	}
+	if s.node.Leaderless() {
+		clock, err := s.node.HandleVersionedPut(req.Key, req.Value, ClockFromProto(req.Context))
+		return &PutResponse{Success: err == nil, Context: ClockToProto(clock)}, toStatus(err)
+	}
 
-	err := s.node.HandlePut(requesterID, req.Key, req.Value)
-	return &PutResponse{Success: err == nil}, err
+	err := s.node.HandlePut(meta, req.Key, req.Value)
+	return &PutResponse{Success: err == nil, Epoch: s.node.GetEpoch()}, toStatus(err)
 }
 
 func (s *GRPCServer) Get(ctx context.Context, req *GetRequest) (*GetResponse, error) {
-	value, err := s.node.HandleGet(req.Key)
-	return &GetResponse{Value: value}, err
+	if s.node.Leaderless() && req.Revision == 0 {
+		siblings, clock, err := s.node.HandleVersionedGet(req.Key)
+		resp := &GetResponse{Siblings: SiblingsToProto(siblings), Context: ClockToProto(clock)}
+		if len(siblings) == 1 {
+			resp.Value = siblings[0].Value
+		}
+		return resp, toStatus(err)
+	}
+
+	value, meta, err := s.node.HandleGetAt(req.Key, req.Revision)
+	return &GetResponse{Value: value, Epoch: s.node.GetEpoch(), Meta: KeyMetaToProto(meta)}, toStatus(err)
 }
 
 func (s *GRPCServer) Delete(ctx context.Context, req *DeleteRequest) (*DeleteResponse, error) {
-	var requesterID int
-	if md, ok := metadata.FromIncomingContext(ctx); ok {
-		if val, ok := md["requesterid"]; ok && len(val) > 0 {
-			id, err := strconv.Atoi(val[0])
-			if err == nil {
-				requesterID = id
-			}
+	meta := requestMeta(ctx)
+	meta.ClientID, meta.Seq, meta.Stamp = req.ClientId, req.Sequence, StampFromProto(req.Stamp)
+	if req.IfVersion > 0 {
+		ok, current, err := s.node.HandleConditionalDelete(meta, req.Key, iface.Condition{IfVersion: req.IfVersion})
+		return &DeleteResponse{Success: ok, Epoch: s.node.GetEpoch(), Current: failedState(ok, err, current)}, toStatus(err)
+	}
+	if s.node.Leaderless() {
+		clock, err := s.node.HandleVersionedDelete(req.Key, ClockFromProto(req.Context))
+		return &DeleteResponse{Success: err == nil, Context: ClockToProto(clock)}, toStatus(err)
+	}
+
+	err := s.node.HandleDelete(meta, req.Key)
+	return &DeleteResponse{Success: err == nil, Epoch: s.node.GetEpoch()}, toStatus(err)
+}
+
+func (s *GRPCServer) Txn(ctx context.Context, req *TxnRequest) (*TxnResponse, error) {
+	meta := requestMeta(ctx)
+	meta.ClientID, meta.Seq, meta.Stamp = req.ClientId, req.Sequence, StampFromProto(req.Stamp)
+	succeeded, current, err := s.node.HandleTxn(meta, TxnFromProto(req))
+	return &TxnResponse{Succeeded: succeeded, Epoch: s.node.GetEpoch(), Current: KeyStatesToProto(current)}, toStatus(err)
+}
+
+func (s *GRPCServer) CompareAndSwap(ctx context.Context, req *CompareAndSwapRequest) (*CompareAndSwapResponse, error) {
+	meta := requestMeta(ctx)
+	meta.ClientID, meta.Seq = req.ClientId, req.Sequence
+	swapped, current, err := s.node.HandleCompareAndSwap(meta, req.Key, req.Expected, req.Value)
+	return &CompareAndSwapResponse{Swapped: swapped, Epoch: s.node.GetEpoch(), Current: failedState(swapped, err, current)}, toStatus(err)
+}
+
+func (s *GRPCServer) Increment(ctx context.Context, req *IncrementRequest) (*IncrementResponse, error) {
+	meta := requestMeta(ctx)
+	meta.ClientID, meta.Seq = req.ClientId, req.Sequence
+	value, err := s.node.HandleIncrement(meta, req.Key, req.Delta)
+	return &IncrementResponse{Value: value, Epoch: s.node.GetEpoch()}, toStatus(err)
+}
+
+func (s *GRPCServer) Decrement(ctx context.Context, req *IncrementRequest) (*IncrementResponse, error) {
+	if req.Delta == math.MinInt64 {
+		return nil, toStatus(fmt.Errorf("%w: cannot negate %d", storage.ErrOverflow, req.Delta))
+	}
+	return s.Increment(ctx, &IncrementRequest{Key: req.Key, Delta: -req.Delta, ClientId: req.ClientId, Sequence: req.Sequence})
+}
+
+func (s *GRPCServer) Scan(ctx context.Context, req *ScanRequest) (*ScanResponse, error) {
+	start, end := req.Start, req.End
+	if req.Prefix != "" {
+		start, end = req.Prefix, storage.PrefixEnd(req.Prefix)
+	}
+	if req.Collections {
+		keys, revision, more, err := s.node.HandleScanKeys(start, end, int(req.Limit), req.Revision)
+		kvs := make([]*KeyValue, len(keys))
+		for i, key := range keys {
+			kvs[i] = &KeyValue{Key: key}
 		}
+		return &ScanResponse{Kvs: kvs, Revision: revision, More: more, Epoch: s.node.GetEpoch()}, toStatus(err)
 	}
-	err := s.node.HandleDelete(requesterID, req.Key)
+	entries, revision, more, err := s.node.HandleScan(start, end, int(req.Limit), req.Revision)
+	return &ScanResponse{Kvs: EntriesToProto(entries), Revision: revision, More: more, Epoch: s.node.GetEpoch()}, toStatus(err)
+}
 
-	return &DeleteResponse{Success: err == nil}, err
+func (s *GRPCServer) Count(ctx context.Context, req *CountRequest) (*CountResponse, error) {
+	count, err := s.node.HandleCount(req.GroupOnly)
+	return &CountResponse{Count: count, Epoch: s.node.GetEpoch()}, toStatus(err)
+}
+
+func (s *GRPCServer) DeleteRange(ctx context.Context, req *DeleteRangeRequest) (*DeleteRangeResponse, error) {
+	deleted, err := s.node.HandleDeleteRange(requestMeta(ctx), req.Start, req.End, req.GroupOnly)
+	return &DeleteRangeResponse{Deleted: deleted, Epoch: s.node.GetEpoch()}, toStatus(err)
+}
+
+func (s *GRPCServer) Compact(ctx context.Context, req *CompactRequest) (*CompactResponse, error) {
+	err := s.node.HandleCompact(requestMeta(ctx), req.Revision)
+	return &CompactResponse{Epoch: s.node.GetEpoch()}, toStatus(err)
+}
+
+// failedState returns the key's state for the response of a write whose
+// condition failed, so the client can retry against it.
+func failedState(ok bool, err error, current storage.KeyState) *KeyState {
+	if ok || err != nil {
+		return nil
+	}
+	return KeyStateToProto(current)
 }
 
 func (s *GRPCServer) StartGRPCServer(addr string) error {
@@ -66,5 +186,15 @@ func (s *GRPCServer) StartGRPCServer(addr string) error {
 	grpcServer := grpc.NewServer()
 	reflection.Register(grpcServer)
 	RegisterKVStoreServer(grpcServer, &GRPCServer{node: s.node})
+	RegisterGossipServer(grpcServer, &gossipServer{node: s.node})
+	RegisterAdminServer(grpcServer, &adminServer{node: s.node})
+	RegisterReplicaServer(grpcServer, &replicaServer{node: s.node})
+	RegisterCRDTServer(grpcServer, &crdtServer{node: s.node})
+	RegisterChainServer(grpcServer, &chainServer{node: s.node})
+	RegisterOpLogServer(grpcServer, &opLogServer{node: s.node})
+	RegisterLeaseServer(grpcServer, &leaseServer{node: s.node})
+	RegisterLockServer(grpcServer, &lockServer{node: s.node})
+	RegisterElectionServer(grpcServer, &electionServer{node: s.node})
+	RegisterCollectionsServer(grpcServer, &collectionsServer{node: s.node})
 	return grpcServer.Serve(listener)
 }
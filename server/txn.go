@@ -0,0 +1,109 @@
+package server
+
+import (
+	"kvstore/iface"
+	"kvstore/storage"
+	"time"
+)
+
+func TxnToProto(txn iface.Txn) *TxnRequest {
+	out := &TxnRequest{
+		Success:   BatchToProto(txn.Success),
+		Failure:   BatchToProto(txn.Failure),
+		Resolved:  txn.Resolved,
+		Succeeded: txn.Succeeded,
+	}
+	for _, c := range txn.Compares {
+		out.Compare = append(out.Compare, &Compare{Key: c.Key, Target: c.Target, Op: c.Op, Value: c.Value, Version: c.Version, Exists: c.Exists, Revision: c.Revision})
+	}
+	return out
+}
+
+func TxnFromProto(req *TxnRequest) iface.Txn {
+	txn := iface.Txn{
+		Success:   BatchFromProto(req.GetSuccess()),
+		Failure:   BatchFromProto(req.GetFailure()),
+		Resolved:  req.GetResolved(),
+		Succeeded: req.GetSucceeded(),
+	}
+	for _, c := range req.GetCompare() {
+		txn.Compares = append(txn.Compares, storage.Compare{
+			Key:      c.GetKey(),
+			Target:   c.GetTarget(),
+			Op:       c.GetOp(),
+			Value:    c.GetValue(),
+			Version:  c.GetVersion(),
+			Exists:   c.GetExists(),
+			Revision: c.GetRevision(),
+		})
+	}
+	return txn
+}
+
+func BatchToProto(ops []storage.BatchOp) []*TxnOp {
+	out := make([]*TxnOp, 0, len(ops))
+	for _, op := range ops {
+		out = append(out, &TxnOp{Key: op.Key, Value: op.Value, Delete: op.Delete, Lease: op.Lease, IfAbsent: op.IfAbsent, Increment: op.Increment, Delta: op.Delta, Unsigned: op.Unsigned, Decrement: op.Decrement})
+	}
+	return out
+}
+
+func BatchFromProto(ops []*TxnOp) []storage.BatchOp {
+	out := make([]storage.BatchOp, 0, len(ops))
+	for _, op := range ops {
+		out = append(out, storage.BatchOp{Key: op.GetKey(), Value: op.GetValue(), Delete: op.GetDelete(), Lease: op.GetLease(), IfAbsent: op.GetIfAbsent(), Increment: op.GetIncrement(), Delta: op.GetDelta(), Unsigned: op.GetUnsigned(), Decrement: op.GetDecrement()})
+	}
+	return out
+}
+
+func KeyStateToProto(st storage.KeyState) *KeyState {
+	return &KeyState{Value: st.Value, Version: st.Version, Exists: st.Exists}
+}
+
+func KeyStatesToProto(states map[string]storage.KeyState) map[string]*KeyState {
+	out := make(map[string]*KeyState, len(states))
+	for key, st := range states {
+		out[key] = KeyStateToProto(st)
+	}
+	return out
+}
+
+func KeyStatesFromProto(states map[string]*KeyState) map[string]storage.KeyState {
+	out := make(map[string]storage.KeyState, len(states))
+	for key, st := range states {
+		out[key] = storage.KeyState{Value: st.GetValue(), Version: st.GetVersion(), Exists: st.GetExists()}
+	}
+	return out
+}
+
+func StampToProto(st storage.Stamp) *Stamp {
+	out := &Stamp{Revision: st.Revision, Writer: int32(st.Writer)}
+	if !st.Time.IsZero() {
+		out.Time = st.Time.UnixNano()
+	}
+	return out
+}
+
+func StampFromProto(st *Stamp) storage.Stamp {
+	out := storage.Stamp{Revision: st.GetRevision(), Writer: int(st.GetWriter())}
+	if st.GetTime() != 0 {
+		out.Time = time.Unix(0, st.GetTime())
+	}
+	return out
+}
+
+func KeyMetaToProto(m storage.KeyMeta) *KeyMeta {
+	out := &KeyMeta{CreateRevision: m.CreateRevision, ModRevision: m.ModRevision, Version: m.Version, Writer: int32(m.Writer), Lease: m.Lease}
+	if !m.ModTime.IsZero() {
+		out.ModTime = m.ModTime.UnixNano()
+	}
+	return out
+}
+
+func KeyMetaFromProto(m *KeyMeta) storage.KeyMeta {
+	out := storage.KeyMeta{CreateRevision: m.GetCreateRevision(), ModRevision: m.GetModRevision(), Version: m.GetVersion(), Writer: int(m.GetWriter()), Lease: m.GetLease()}
+	if m.GetModTime() != 0 {
+		out.ModTime = time.Unix(0, m.GetModTime())
+	}
+	return out
+}
@@ -0,0 +1,42 @@
+package server
+
+import (
+	"kvstore/iface"
+	"kvstore/storage"
+	"time"
+)
+
+func (s *GRPCServer) Watch(req *WatchRequest, stream KVStore_WatchServer) error {
+	w := iface.WatchRequest{
+		Start:            req.Start,
+		End:              req.End,
+		StartRevision:    req.StartRevision,
+		ProgressInterval: time.Duration(req.ProgressIntervalMs) * time.Millisecond,
+	}
+	switch {
+	case req.Key != "":
+		w.Start, w.End = req.Key, req.Key+"\x00"
+	case req.Prefix != "":
+		w.Start, w.End = req.Prefix, storage.PrefixEnd(req.Prefix)
+	}
+	err := s.node.HandleWatch(stream.Context(), w, func(resp iface.WatchResponse) error {
+		return stream.Send(&WatchResponse{Events: EventsToProto(resp.Events), Revision: resp.Revision, Progress: resp.Progress})
+	})
+	return toStatus(err)
+}
+
+func EventsToProto(events []storage.Event) []*KeyValue {
+	out := make([]*KeyValue, 0, len(events))
+	for _, ev := range events {
+		out = append(out, &KeyValue{Key: ev.Key, Value: ev.Value, Deleted: ev.Deleted, Meta: KeyMetaToProto(ev.Meta)})
+	}
+	return out
+}
+
+func EventsFromProto(kvs []*KeyValue) []storage.Event {
+	out := make([]storage.Event, 0, len(kvs))
+	for _, kv := range kvs {
+		out = append(out, storage.Event{Key: kv.GetKey(), Value: kv.GetValue(), Deleted: kv.GetDeleted(), Meta: KeyMetaFromProto(kv.GetMeta())})
+	}
+	return out
+}
@@ -0,0 +1,370 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Collection types
+const (
+	COLL_LIST = "list"
+	COLL_SET  = "set"
+	COLL_HASH = "hash"
+	COLL_ZSET = "zset"
+)
+
+// Collection commands, named after their Redis counterparts
+const (
+	LPUSH         = "lpush"
+	RPUSH         = "rpush"
+	LPOP          = "lpop"
+	RPOP          = "rpop"
+	LRANGE        = "lrange"
+	SADD          = "sadd"
+	SREM          = "srem"
+	SMEMBERS      = "smembers"
+	SINTER        = "sinter"
+	HSET          = "hset"
+	HGET          = "hget"
+	HDEL          = "hdel"
+	HGETALL       = "hgetall"
+	ZADD          = "zadd"
+	ZREM          = "zrem"
+	ZRANGEBYSCORE = "zrangebyscore"
+	ZRANK         = "zrank"
+)
+
+// collectionCommands maps each command to the type it works on and whether
+// it writes.
+var collectionCommands = map[string]struct {
+	typ   string
+	write bool
+}{
+	LPUSH: {COLL_LIST, true}, RPUSH: {COLL_LIST, true}, LPOP: {COLL_LIST, true}, RPOP: {COLL_LIST, true}, LRANGE: {COLL_LIST, false},
+	SADD: {COLL_SET, true}, SREM: {COLL_SET, true}, SMEMBERS: {COLL_SET, false}, SINTER: {COLL_SET, false},
+	HSET: {COLL_HASH, true}, HGET: {COLL_HASH, false}, HDEL: {COLL_HASH, true}, HGETALL: {COLL_HASH, false},
+	ZADD: {COLL_ZSET, true}, ZREM: {COLL_ZSET, true}, ZRANGEBYSCORE: {COLL_ZSET, false}, ZRANK: {COLL_ZSET, false},
+}
+
+// Collection is a typed value; the field matching Type is set.
+type Collection struct {
+	Type     string
+	Revision int64 // revision of the write that left the collection as it is
+	List     []string
+	Set      map[string]bool
+	Hash     map[string]string
+	ZSet     map[string]float64
+}
+
+func newCollection(typ string) *Collection {
+	c := &Collection{Type: typ}
+	switch typ {
+	case COLL_SET:
+		c.Set = make(map[string]bool)
+	case COLL_HASH:
+		c.Hash = make(map[string]string)
+	case COLL_ZSET:
+		c.ZSet = make(map[string]float64)
+	}
+	return c
+}
+
+func (c *Collection) len() int {
+	return len(c.List) + len(c.Set) + len(c.Hash) + len(c.ZSet)
+}
+
+// CollectionOp is a collection command. Its arguments are in the fields the
+// command uses: Members holds the values pushed, the set or sorted set
+// members, or the hash fields, with Values and Scores parallel to it.
+type CollectionOp struct {
+	Cmd     string
+	Key     string
+	Keys    []string  // SINTER: the keys intersected with Key
+	Members []string  // pushed values, members or fields
+	Values  []string  // HSET: the values of the fields
+	Scores  []float64 // ZADD: the scores of the members
+	Start   int64     // LRANGE: first index, negative counting from the end
+	Stop    int64     // LRANGE: last index, included
+	Min     float64   // ZRANGEBYSCORE: lowest score, included
+	Max     float64   // ZRANGEBYSCORE: highest score, included
+	Count   int       // LPOP and RPOP: how many, 0 meaning 1
+}
+
+// Writes reports whether op changes the collection.
+func (op CollectionOp) Writes() bool {
+	return collectionCommands[op.Cmd].write
+}
+
+// CollectionResult is what a collection command returns.
+type CollectionResult struct {
+	Elements []string  // list elements, members or hash fields
+	Values   []string  // hash values, parallel to Elements
+	Scores   []float64 // sorted set scores, parallel to Elements
+	Count    int64     // elements added or removed, list length after a push, or a rank
+	Found    bool      // ZRANK: whether the member exists
+}
+
+// ApplyCollection runs a collection write under st, completed like the stamp
+// of a put, and returns the record of the key it leaves behind. Replicas
+// install that record with ImportKeys rather than run the command again, so
+// they converge whatever order the writes reach them in: like a plain value,
+// a collection refuses states older than its revision. A collection left
+// empty is removed, like in Redis, and its tombstone refuses older states
+// too. Collections have no history or leases, and watches do not see them.
+func (s *MemoryStorage) ApplyCollection(op CollectionOp, st Stamp) (CollectionResult, KeyRecord, Stamp, error) {
+	if err := op.validate(); err != nil {
+		return CollectionResult{}, KeyRecord{}, Stamp{}, err
+	}
+	if !op.Writes() {
+		return CollectionResult{}, KeyRecord{}, Stamp{}, fmt.Errorf("%s does not write, read it with ReadCollection", op.Cmd)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.collectionLocked(op.Key, collectionCommands[op.Cmd].typ)
+	if err != nil {
+		return CollectionResult{}, KeyRecord{}, Stamp{}, err
+	}
+	if c == nil {
+		c = newCollection(collectionCommands[op.Cmd].typ)
+	}
+	st = s.stampLocked(st)
+	res := c.apply(op)
+	rec := KeyRecord{Key: op.Key}
+	if c.len() == 0 {
+		s.dropCollectionLocked(op.Key, st)
+	} else {
+		c.Revision = st.Revision
+		s.colls[op.Key] = c
+		rec.Collection = c.clone()
+	}
+	return res, rec, st, nil
+}
+
+// dropCollectionLocked removes the collection at key, leaving a tombstone at
+// st in the key's history so that older states of it are refused. Caller
+// holds s.mu and has checked st is the key's latest change.
+func (s *MemoryStorage) dropCollectionLocked(key string, st Stamp) {
+	delete(s.colls, key)
+	tombstone := keyRevision{meta: KeyMeta{ModRevision: st.Revision, ModTime: st.Time, Writer: st.Writer}, deleted: true}
+	s.history[key] = append(s.history[key], tombstone)
+}
+
+// ReadCollection runs a collection read. A missing key reads as an empty
+// collection.
+func (s *MemoryStorage) ReadCollection(op CollectionOp) (CollectionResult, error) {
+	if err := op.validate(); err != nil {
+		return CollectionResult{}, err
+	}
+	if op.Writes() {
+		return CollectionResult{}, fmt.Errorf("%s writes, apply it with ApplyCollection", op.Cmd)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	typ := collectionCommands[op.Cmd].typ
+	c, err := s.collectionLocked(op.Key, typ)
+	if err != nil {
+		return CollectionResult{}, err
+	}
+	if c == nil {
+		c = newCollection(typ)
+	}
+	if op.Cmd != SINTER {
+		return c.read(op), nil
+	}
+
+	members := c.Set
+	for _, key := range op.Keys {
+		other, err := s.collectionLocked(key, COLL_SET)
+		if err != nil {
+			return CollectionResult{}, err
+		}
+		common := make(map[string]bool)
+		for m := range members {
+			if other != nil && other.Set[m] {
+				common[m] = true
+			}
+		}
+		members = common
+	}
+	return CollectionResult{Elements: sortedMembers(members)}, nil
+}
+
+// collectionLocked returns the collection at key, nil when there is none,
+// or ErrWrongType when key holds a plain value or a collection of another
+// type. Caller holds s.mu.
+func (s *MemoryStorage) collectionLocked(key, typ string) (*Collection, error) {
+	if _, ok := s.data[key]; ok {
+		return nil, fmt.Errorf("%w: %s holds a plain value", ErrWrongType, key)
+	}
+	c, ok := s.colls[key]
+	if !ok {
+		return nil, nil
+	}
+	if c.Type != typ {
+		return nil, fmt.Errorf("%w: %s holds a %s", ErrWrongType, key, c.Type)
+	}
+	return c, nil
+}
+
+func (op CollectionOp) validate() error {
+	if op.Key == "" {
+		return fmt.Errorf("%w: key cannot be empty", ErrInvalidArgument)
+	}
+	if _, ok := collectionCommands[op.Cmd]; !ok {
+		return fmt.Errorf("%w: unknown collection command %q", ErrInvalidArgument, op.Cmd)
+	}
+	switch {
+	case op.Cmd == HSET && len(op.Values) != len(op.Members):
+		return fmt.Errorf("%w: hset needs a value for each of its %d fields", ErrInvalidArgument, len(op.Members))
+	case op.Cmd == ZADD && len(op.Scores) != len(op.Members):
+		return fmt.Errorf("%w: zadd needs a score for each of its %d members", ErrInvalidArgument, len(op.Members))
+	case op.Count < 0:
+		return fmt.Errorf("%w: count cannot be negative", ErrInvalidArgument)
+	}
+	return nil
+}
+
+func (c *Collection) apply(op CollectionOp) CollectionResult {
+	var res CollectionResult
+	switch op.Cmd {
+	case LPUSH:
+		for _, v := range op.Members {
+			c.List = append([]string{v}, c.List...)
+		}
+		res.Count = int64(len(c.List))
+	case RPUSH:
+		c.List = append(c.List, op.Members...)
+		res.Count = int64(len(c.List))
+	case LPOP, RPOP:
+		n := min(max(op.Count, 1), len(c.List))
+		for i := 0; i < n; i++ {
+			if op.Cmd == LPOP {
+				res.Elements, c.List = append(res.Elements, c.List[0]), c.List[1:]
+			} else {
+				last := len(c.List) - 1
+				res.Elements, c.List = append(res.Elements, c.List[last]), c.List[:last]
+			}
+		}
+	case SADD:
+		for _, m := range op.Members {
+			if !c.Set[m] {
+				c.Set[m] = true
+				res.Count++
+			}
+		}
+	case SREM:
+		for _, m := range op.Members {
+			if c.Set[m] {
+				delete(c.Set, m)
+				res.Count++
+			}
+		}
+	case HSET:
+		for i, f := range op.Members {
+			if _, ok := c.Hash[f]; !ok {
+				res.Count++
+			}
+			c.Hash[f] = op.Values[i]
+		}
+	case HDEL:
+		for _, f := range op.Members {
+			if _, ok := c.Hash[f]; ok {
+				delete(c.Hash, f)
+				res.Count++
+			}
+		}
+	case ZADD:
+		for i, m := range op.Members {
+			if _, ok := c.ZSet[m]; !ok {
+				res.Count++
+			}
+			c.ZSet[m] = op.Scores[i]
+		}
+	case ZREM:
+		for _, m := range op.Members {
+			if _, ok := c.ZSet[m]; ok {
+				delete(c.ZSet, m)
+				res.Count++
+			}
+		}
+	}
+	return res
+}
+
+func (c *Collection) read(op CollectionOp) CollectionResult {
+	var res CollectionResult
+	switch op.Cmd {
+	case LRANGE:
+		n := int64(len(c.List))
+		start, stop := op.Start, op.Stop
+		if start < 0 {
+			start = max(n+start, 0)
+		}
+		if stop < 0 {
+			stop = n + stop
+		}
+		stop = min(stop, n-1)
+		if start <= stop {
+			res.Elements = append(res.Elements, c.List[start:stop+1]...)
+		}
+	case SMEMBERS:
+		res.Elements = sortedMembers(c.Set)
+	case HGET:
+		for _, f := range op.Members {
+			if v, ok := c.Hash[f]; ok {
+				res.Elements, res.Values = append(res.Elements, f), append(res.Values, v)
+			}
+		}
+	case HGETALL:
+		for f := range c.Hash {
+			res.Elements = append(res.Elements, f)
+		}
+		sort.Strings(res.Elements)
+		for _, f := range res.Elements {
+			res.Values = append(res.Values, c.Hash[f])
+		}
+	case ZRANGEBYSCORE:
+		for _, m := range c.ranked() {
+			if score := c.ZSet[m]; score >= op.Min && score <= op.Max {
+				res.Elements, res.Scores = append(res.Elements, m), append(res.Scores, score)
+			}
+		}
+	case ZRANK:
+		for i, m := range c.ranked() {
+			if len(op.Members) > 0 && m == op.Members[0] {
+				res.Count, res.Found = int64(i), true
+				break
+			}
+		}
+	}
+	return res
+}
+
+// ranked returns the members of a sorted set by score, then by member.
+func (c *Collection) ranked() []string {
+	members := make([]string, 0, len(c.ZSet))
+	for m := range c.ZSet {
+		members = append(members, m)
+	}
+	sort.Slice(members, func(i, j int) bool {
+		a, b := members[i], members[j]
+		if c.ZSet[a] != c.ZSet[b] {
+			return c.ZSet[a] < c.ZSet[b]
+		}
+		return a < b
+	})
+	return members
+}
+
+func sortedMembers(set map[string]bool) []string {
+	members := make([]string, 0, len(set))
+	for m := range set {
+		members = append(members, m)
+	}
+	sort.Strings(members)
+	return members
+}
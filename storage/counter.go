@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+var (
+	ErrNotNumber = errors.New("value is not an integer")
+	ErrOverflow  = errors.New("increment would overflow")
+)
+
+// addDelta returns the integer value plus delta.
+func addDelta(value string, exists bool, delta int64) (int64, error) {
+	n := int64(0)
+	if exists {
+		var err error
+		if n, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return 0, fmt.Errorf("%w: %q", ErrNotNumber, value)
+		}
+	}
+	if (delta > 0 && n > math.MaxInt64-delta) || (delta < 0 && n < math.MinInt64-delta) {
+		return 0, fmt.Errorf("%w: %d%+d", ErrOverflow, n, delta)
+	}
+	return n + delta, nil
+}
+
+// AddUnsigned returns the unsigned 64-bit value plus delta, or minus delta
+// when decrement is set. As in memcached, additions wrap around and
+// subtractions stop at 0.
+func AddUnsigned(value string, exists bool, delta uint64, decrement bool) (uint64, error) {
+	n := uint64(0)
+	if exists {
+		var err error
+		if n, err = strconv.ParseUint(value, 10, 64); err != nil {
+			return 0, fmt.Errorf("%w: %q", ErrNotNumber, value)
+		}
+	}
+	switch {
+	case !decrement:
+		return n + delta, nil
+	case delta > n:
+		return 0, nil
+	}
+	return n - delta, nil
+}
@@ -0,0 +1,284 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// CRDT types
+const (
+	COUNTER  = "counter"  // PN-Counter
+	SET      = "set"      // OR-Set
+	REGISTER = "register" // LWW-Register
+	MAP      = "map"      // last-writer-wins map
+)
+
+var ErrWrongType = errors.New("WRONGTYPE operation against a key holding the wrong kind of value")
+
+// PNCounter is a pair of grow-only counters, one entry per node.
+type PNCounter struct {
+	P map[int]int64
+	N map[int]int64
+}
+
+func (c *PNCounter) Increment(node int, delta int64) {
+	if delta >= 0 {
+		c.P[node] += delta
+	} else {
+		c.N[node] -= delta
+	}
+}
+
+func (c *PNCounter) Value() int64 {
+	var v int64
+	for _, p := range c.P {
+		v += p
+	}
+	for _, n := range c.N {
+		v -= n
+	}
+	return v
+}
+
+func (c *PNCounter) merge(other *PNCounter) {
+	if other == nil {
+		return
+	}
+	for node, p := range other.P {
+		c.P[node] = max(c.P[node], p)
+	}
+	for node, n := range other.N {
+		c.N[node] = max(c.N[node], n)
+	}
+}
+
+// ORSet is an observed-remove set: a remove only cancels the adds it has
+// seen, so a concurrent add wins.
+type ORSet struct {
+	Adds    map[string]map[string]bool // member -> unique add tags
+	Removed map[string]bool            // tags cancelled by a remove
+}
+
+func (s *ORSet) Add(member, tag string) {
+	if s.Adds[member] == nil {
+		s.Adds[member] = make(map[string]bool)
+	}
+	s.Adds[member][tag] = true
+}
+
+func (s *ORSet) Remove(member string) {
+	for tag := range s.Adds[member] {
+		s.Removed[tag] = true
+	}
+}
+
+func (s *ORSet) Members() []string {
+	var members []string
+	for member, tags := range s.Adds {
+		for tag := range tags {
+			if !s.Removed[tag] {
+				members = append(members, member)
+				break
+			}
+		}
+	}
+	sort.Strings(members)
+	return members
+}
+
+func (s *ORSet) merge(other *ORSet) {
+	if other == nil {
+		return
+	}
+	for member, tags := range other.Adds {
+		for tag := range tags {
+			s.Add(member, tag)
+		}
+	}
+	for tag := range other.Removed {
+		s.Removed[tag] = true
+	}
+}
+
+// LWWRegister keeps the value with the highest timestamp, node id breaking ties.
+type LWWRegister struct {
+	Value     string
+	Deleted   bool
+	Timestamp int64
+	Node      int
+}
+
+func (r *LWWRegister) newer(other LWWRegister) bool {
+	if r.Timestamp != other.Timestamp {
+		return r.Timestamp > other.Timestamp
+	}
+	return r.Node > other.Node
+}
+
+func (r *LWWRegister) Set(value string, timestamp int64, node int) {
+	update := LWWRegister{Value: value, Timestamp: timestamp, Node: node}
+	if update.newer(*r) {
+		*r = update
+	}
+}
+
+// LWWMap is a map whose fields are independent LWW registers.
+type LWWMap struct {
+	Fields map[string]LWWRegister
+}
+
+func (m *LWWMap) apply(field string, update LWWRegister) {
+	if cur, ok := m.Fields[field]; !ok || update.newer(cur) {
+		m.Fields[field] = update
+	}
+}
+
+func (m *LWWMap) Put(field, value string, timestamp int64, node int) {
+	m.apply(field, LWWRegister{Value: value, Timestamp: timestamp, Node: node})
+}
+
+func (m *LWWMap) Remove(field string, timestamp int64, node int) {
+	m.apply(field, LWWRegister{Deleted: true, Timestamp: timestamp, Node: node})
+}
+
+func (m *LWWMap) Entries() map[string]string {
+	entries := make(map[string]string)
+	for field, r := range m.Fields {
+		if !r.Deleted {
+			entries[field] = r.Value
+		}
+	}
+	return entries
+}
+
+// CRDT is a typed conflict-free value; exactly one field matches Type.
+type CRDT struct {
+	Type     string
+	Counter  *PNCounter   `json:",omitempty"`
+	Set      *ORSet       `json:",omitempty"`
+	Register *LWWRegister `json:",omitempty"`
+	Map      *LWWMap      `json:",omitempty"`
+}
+
+func NewCRDT(typ string) (*CRDT, error) {
+	c := &CRDT{Type: typ}
+	switch typ {
+	case COUNTER:
+		c.Counter = &PNCounter{P: make(map[int]int64), N: make(map[int]int64)}
+	case SET:
+		c.Set = &ORSet{Adds: make(map[string]map[string]bool), Removed: make(map[string]bool)}
+	case REGISTER:
+		c.Register = &LWWRegister{}
+	case MAP:
+		c.Map = &LWWMap{Fields: make(map[string]LWWRegister)}
+	default:
+		return nil, fmt.Errorf("%w: unknown CRDT type %q", ErrInvalidArgument, typ)
+	}
+	return c, nil
+}
+
+// Merge folds other into c. Merging is commutative, associative and
+// idempotent, so replicas converge whatever the order they exchange states.
+func (c *CRDT) Merge(other *CRDT) error {
+	if other == nil {
+		return nil
+	}
+	if other.Type != c.Type {
+		return ErrWrongType
+	}
+	switch c.Type {
+	case COUNTER:
+		c.Counter.merge(other.Counter)
+	case SET:
+		c.Set.merge(other.Set)
+	case REGISTER:
+		if other.Register != nil && other.Register.newer(*c.Register) {
+			*c.Register = *other.Register
+		}
+	case MAP:
+		if other.Map != nil {
+			for field, r := range other.Map.Fields {
+				c.Map.apply(field, r)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *CRDT) Copy() *CRDT {
+	out, _ := NewCRDT(c.Type)
+	out.Merge(c)
+	return out
+}
+
+func (c *CRDT) Encode() ([]byte, error) {
+	return json.Marshal(c)
+}
+
+func DecodeCRDT(data []byte) (*CRDT, error) {
+	var c CRDT
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	empty, err := NewCRDT(c.Type)
+	if err != nil {
+		return nil, err
+	}
+	// Merging into a fresh value fills the maps a peer left out
+	return empty, empty.Merge(&c)
+}
+
+// CRDTStorage holds the CRDT values of a node.
+type CRDTStorage struct {
+	data map[string]*CRDT
+	mu   sync.RWMutex
+}
+
+func NewCRDTStorage() *CRDTStorage {
+	return &CRDTStorage{data: make(map[string]*CRDT)}
+}
+
+// Get returns a copy of the value of key, nil if there is none.
+func (s *CRDTStorage) Get(key string) *CRDT {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if c, ok := s.data[key]; ok {
+		return c.Copy()
+	}
+	return nil
+}
+
+// Merge folds state into the value of key and returns the result.
+func (s *CRDTStorage) Merge(key string, state *CRDT) (*CRDT, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cur, ok := s.data[key]
+	if !ok {
+		cur, _ = NewCRDT(state.Type)
+		if cur == nil {
+			return nil, fmt.Errorf("unknown CRDT type %q", state.Type)
+		}
+	}
+	if err := cur.Merge(state); err != nil {
+		return nil, err
+	}
+	s.data[key] = cur
+	return cur.Copy(), nil
+}
+
+func (s *CRDTStorage) Keys() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]string, 0, len(s.data))
+	for k := range s.data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
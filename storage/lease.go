@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+var ErrLeaseNotFound = errors.New("lease not found")
+
+// Lease is a lease and the keys attached to it, which are deleted together
+// when the lease is revoked.
+type Lease struct {
+	ID   int64
+	TTL  time.Duration
+	Keys []string
+}
+
+type leaseRecord struct {
+	ttl  time.Duration // 0 until granted
+	keys map[string]struct{}
+}
+
+// GrantLease creates the lease id. Expiry is up to the caller, which revokes
+// the lease once its TTL has passed without a keep-alive. A replica may have
+// attached keys to the lease before the grant reached it; they are kept. The
+// id of a revoked lease is not granted again, so a grant reaching a replica
+// after the revoke is refused.
+func (s *MemoryStorage) GrantLease(id int64, ttl time.Duration) error {
+	if id <= 0 || ttl <= 0 {
+		return fmt.Errorf("%w: lease needs a positive id and ttl, got %d and %v", ErrInvalidArgument, id, ttl)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.revoked[id]; ok {
+		return fmt.Errorf("lease %d was revoked", id)
+	}
+	l, ok := s.leases[id]
+	if ok && l.ttl != 0 {
+		return fmt.Errorf("lease %d already exists", id)
+	} else if !ok {
+		l = &leaseRecord{keys: make(map[string]struct{})}
+		s.leases[id] = l
+	}
+	l.ttl = ttl
+	return nil
+}
+
+// RevokeLease drops the lease id and deletes its keys in one batch stamped
+// with st. It returns the deletions and the stamp they got. A replica applying
+// the leader's revoke, stamped already, deletes exactly the leader's
+// deletions in ops, whether or not the grant reached it.
+func (s *MemoryStorage) RevokeLease(id int64, ops []BatchOp, st Stamp) ([]BatchOp, Stamp, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l, ok := s.leases[id]
+	if st.Revision == 0 {
+		if !ok || l.ttl == 0 {
+			return nil, Stamp{}, fmt.Errorf("%w: %d", ErrLeaseNotFound, id)
+		}
+		ops = make([]BatchOp, 0, len(l.keys))
+		for key := range l.keys {
+			ops = append(ops, BatchOp{Key: key, Delete: true})
+		}
+		sort.Slice(ops, func(i, j int) bool { return ops[i].Key < ops[j].Key })
+	}
+	st = s.applyLocked(ops, st)
+	delete(s.leases, id)
+	s.revoked[id] = s.revision
+	return ops, st, nil
+}
+
+// Lease returns the lease id with its keys.
+func (s *MemoryStorage) Lease(id int64) (Lease, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	l, ok := s.leases[id]
+	if !ok || l.ttl == 0 {
+		return Lease{}, false
+	}
+	out := Lease{ID: id, TTL: l.ttl}
+	for key := range l.keys {
+		out.Keys = append(out.Keys, key)
+	}
+	sort.Strings(out.Keys)
+	return out, true
+}
+
+// Leases returns the ids and TTLs of every granted lease.
+func (s *MemoryStorage) Leases() map[int64]time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make(map[int64]time.Duration, len(s.leases))
+	for id, l := range s.leases {
+		if l.ttl != 0 {
+			out[id] = l.ttl
+		}
+	}
+	return out
+}
+
+// checkLeasesLocked fails when ops attach a key to a lease that has not been
+// granted. Only the node stamping a write checks: a replica applies the
+// leader's stamped writes as they come, even ahead of the grant they depend
+// on. Caller holds s.mu.
+func (s *MemoryStorage) checkLeasesLocked(ops []BatchOp, st Stamp) error {
+	if st.Revision != 0 {
+		return nil
+	}
+	for _, op := range ops {
+		if op.Lease == 0 || op.Delete {
+			continue
+		}
+		if l, ok := s.leases[op.Lease]; !ok || l.ttl == 0 {
+			return fmt.Errorf("%w: %d", ErrLeaseNotFound, op.Lease)
+		}
+	}
+	return nil
+}
+
+// attachLocked moves key to lease, 0 detaching it. Caller holds s.mu.
+func (s *MemoryStorage) attachLocked(key string, lease int64) {
+	if old := s.meta[key].Lease; old != 0 && old != lease {
+		if l, ok := s.leases[old]; ok {
+			delete(l.keys, key)
+		}
+	}
+	if lease == 0 {
+		return
+	}
+	l, ok := s.leases[lease]
+	if !ok {
+		l = &leaseRecord{keys: make(map[string]struct{})}
+		s.leases[lease] = l
+	}
+	l.keys[key] = struct{}{}
+}
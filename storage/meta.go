@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+)
+
+// Stamp identifies a write: the revision the leader gave it, and when and by
+// which node it was accepted. Replicas apply a write with the leader's stamp
+// so their metadata matches; a zero Revision or Time is filled in locally.
+type Stamp struct {
+	Revision int64
+	Time     time.Time
+	Writer   int
+}
+
+// KeyMeta describes the history of a key.
+type KeyMeta struct {
+	CreateRevision int64     // revision that created the key
+	ModRevision    int64     // revision of the last write
+	Version        int64     // writes since the key was created
+	ModTime        time.Time // time of the last write
+	Writer         int       // node that accepted the last write
+	Lease          int64     // lease the key is attached to, 0 for none
+}
+
+// stampLocked completes st with the next revision and the current time, and
+// moves the revision counter up to it. Caller holds s.mu.
+func (s *MemoryStorage) stampLocked(st Stamp) Stamp {
+	if st.Revision == 0 {
+		st.Revision = s.revision + 1
+	}
+	if st.Revision > s.revision {
+		s.revision = st.Revision
+	}
+	if st.Time.IsZero() {
+		st.Time = time.Now()
+	}
+	return st
+}
+
+// GetWithMeta returns the value of key with its metadata, which is zero when
+// the key does not exist.
+func (s *MemoryStorage) GetWithMeta(key string) (string, KeyMeta, error) {
+	if key == "" {
+		return "", KeyMeta{}, fmt.Errorf("%w: key cannot be empty", ErrInvalidArgument)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.data[key], s.meta[key], nil
+}
+
+// Revision returns the revision of the last write applied.
+func (s *MemoryStorage) Revision() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.revision
+}
@@ -0,0 +1,198 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var (
+	ErrCompacted      = errors.New("requested revision has been compacted")
+	ErrFutureRevision = errors.New("requested revision is newer than the store")
+)
+
+// keyRevision is one version of a key in its history.
+type keyRevision struct {
+	value   string
+	meta    KeyMeta
+	deleted bool // tombstone: the key was deleted at meta.ModRevision
+}
+
+// Entry is a key as a scan read it.
+type Entry struct {
+	Key   string
+	Value string
+	Meta  KeyMeta
+}
+
+// recordLocked adds e to the history of key and to the changes passed to the
+// observer. Replicated writes can arrive out of revision order, so e is
+// inserted after every version that is not newer. Caller holds s.mu.
+func (s *MemoryStorage) recordLocked(key string, e keyRevision) {
+	h := s.history[key]
+	i := sort.Search(len(h), func(i int) bool { return h[i].meta.ModRevision > e.meta.ModRevision })
+	h = append(h, keyRevision{})
+	copy(h[i+1:], h[i:])
+	h[i] = e
+	s.history[key] = h
+	s.pending = append(s.pending, Event{Key: key, Value: e.value, Deleted: e.deleted, Meta: e.meta})
+}
+
+// atLocked returns the version of key visible at revision. Caller holds s.mu.
+func (s *MemoryStorage) atLocked(key string, revision int64) (keyRevision, bool) {
+	h := s.history[key]
+	i := sort.Search(len(h), func(i int) bool { return h[i].meta.ModRevision > revision })
+	if i == 0 || h[i-1].deleted {
+		return keyRevision{}, false
+	}
+	return h[i-1], true
+}
+
+// readRevisionLocked resolves the revision a read asked for, 0 meaning the
+// latest. Caller holds s.mu.
+func (s *MemoryStorage) readRevisionLocked(revision int64) (int64, error) {
+	switch {
+	case revision == 0:
+		return s.revision, nil
+	case revision > s.revision:
+		return 0, fmt.Errorf("%w: %d, store is at %d", ErrFutureRevision, revision, s.revision)
+	case revision < s.compacted:
+		return 0, fmt.Errorf("%w: %d, compacted up to %d", ErrCompacted, revision, s.compacted)
+	}
+	return revision, nil
+}
+
+// GetAt returns key as it was at revision, 0 for the latest. A key that did
+// not exist then has an empty value and zero metadata.
+func (s *MemoryStorage) GetAt(key string, revision int64) (string, KeyMeta, error) {
+	if key == "" {
+		return "", KeyMeta{}, fmt.Errorf("%w: key cannot be empty", ErrInvalidArgument)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.colls[key]; ok && revision == 0 {
+		return "", KeyMeta{}, fmt.Errorf("%w: %s holds a collection", ErrWrongType, key)
+	}
+	revision, err := s.readRevisionLocked(revision)
+	if err != nil {
+		return "", KeyMeta{}, err
+	}
+	e, _ := s.atLocked(key, revision)
+	return e.value, e.meta, nil
+}
+
+// ScanAt returns up to limit keys in [start, end) in key order as they were
+// at revision, 0 for the latest; an empty end is unbounded and limit 0 means
+// no limit. The whole scan reads one snapshot, and it returns the revision it
+// read so further pages can ask for the same one. more is set when keys were
+// left out by the limit.
+func (s *MemoryStorage) ScanAt(start, end string, limit int, revision int64) (entries []Entry, read int64, more bool, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	read, err = s.readRevisionLocked(revision)
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	var keys []string
+	for k := range s.history {
+		if k >= start && (end == "" || k < end) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		e, ok := s.atLocked(k, read)
+		if !ok {
+			continue
+		}
+		if limit > 0 && len(entries) == limit {
+			return entries, read, true, nil
+		}
+		entries = append(entries, Entry{Key: k, Value: e.value, Meta: e.meta})
+	}
+	return entries, read, false, nil
+}
+
+// ScanKeysAt returns up to limit keys in [start, end) holding a value as of
+// revision or a collection, like ScanAt. Collections keep no history, so
+// they are listed as they are now.
+func (s *MemoryStorage) ScanKeysAt(start, end string, limit int, revision int64) (keys []string, read int64, more bool, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	read, err = s.readRevisionLocked(revision)
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	var candidates []string
+	for k := range s.history {
+		if k >= start && (end == "" || k < end) {
+			candidates = append(candidates, k)
+		}
+	}
+	for k := range s.colls {
+		if _, ok := s.history[k]; !ok && k >= start && (end == "" || k < end) {
+			candidates = append(candidates, k)
+		}
+	}
+	sort.Strings(candidates)
+	for _, k := range candidates {
+		if _, ok := s.colls[k]; !ok {
+			if _, ok := s.atLocked(k, read); !ok {
+				continue
+			}
+		}
+		if limit > 0 && len(keys) == limit {
+			return keys, read, true, nil
+		}
+		keys = append(keys, k)
+	}
+	return keys, read, false, nil
+}
+
+// Compact discards the history before revision. Reads at an earlier revision
+// fail with ErrCompacted afterwards, while every key keeps the version that
+// was visible at revision.
+func (s *MemoryStorage) Compact(revision int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if revision > s.revision {
+		return fmt.Errorf("%w: %d, store is at %d", ErrFutureRevision, revision, s.revision)
+	}
+	if revision <= s.compacted {
+		return fmt.Errorf("%w: %d, compacted up to %d", ErrCompacted, revision, s.compacted)
+	}
+
+	for key, h := range s.history {
+		i := sort.Search(len(h), func(i int) bool { return h[i].meta.ModRevision > revision })
+		if i > 0 && !h[i-1].deleted {
+			i-- // still visible at revision
+		}
+		if i == len(h) {
+			delete(s.history, key)
+		} else if i > 0 {
+			s.history[key] = append([]keyRevision(nil), h[i:]...)
+		}
+	}
+	// Replicated writes older than the compaction are not expected any more
+	for id, rev := range s.revoked {
+		if rev <= revision {
+			delete(s.revoked, id)
+		}
+	}
+	s.compacted = revision
+	return nil
+}
+
+// CompactedRevision returns the revision history was last compacted to.
+func (s *MemoryStorage) CompactedRevision() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.compacted
+}
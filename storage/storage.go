@@ -1,10 +1,16 @@
 package storage
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"sync"
 )
 
+// ErrInvalidArgument marks requests refused whatever the store holds, such as
+// a write to an empty key.
+var ErrInvalidArgument = errors.New("invalid argument")
+
 type Storage interface {
 	put(key, value string) error
 	get(key string) (string, error)
@@ -15,30 +21,105 @@ type Storage interface {
 }
 
 type MemoryStorage struct {
-	data map[string]string
-	mu   sync.RWMutex
+	data      map[string]string
+	meta      map[string]KeyMeta
+	history   map[string][]keyRevision // versions of each key by revision, see mvcc.go
+	revision  int64                    // revision of the last write
+	compacted int64                    // history before this revision is gone
+	observer  func([]Event)            // told about every write, see watch.go
+	pending   []Event                  // changes of the write being applied
+	leases    map[int64]*leaseRecord   // see lease.go
+	revoked   map[int64]int64          // revoked leases, by the store's revision at the revoke
+	colls     map[string]*Collection   // keys holding collections, see collection.go
+	mu        sync.RWMutex
 }
 
 func NewMemoryStorage() *MemoryStorage {
-	return &MemoryStorage{data: make(map[string]string)}
+	return &MemoryStorage{data: make(map[string]string), meta: make(map[string]KeyMeta), history: make(map[string][]keyRevision), leases: make(map[int64]*leaseRecord), revoked: make(map[int64]int64), colls: make(map[string]*Collection)}
 }
 
 func (s *MemoryStorage) Put(key, value string) error {
 	if key == "" {
-		return fmt.Errorf("key cannot be empty")
+		return fmt.Errorf("%w: key cannot be empty", ErrInvalidArgument)
 	}
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.data[key] = value
+	s.putLocked(key, value, 0, s.stampLocked(Stamp{}))
+	s.notifyLocked()
 
 	return nil
 }
 
+// putLocked sets key to value, replacing a collection it held. A replicated
+// write older than the key's latest change, or attaching the key to a lease
+// that is revoked already, only joins its history.
+func (s *MemoryStorage) putLocked(key, value string, lease int64, st Stamp) {
+	if _, revoked := s.revoked[lease]; st.Revision < s.modRevisionLocked(key) || revoked {
+		prev, _ := s.atLocked(key, st.Revision)
+		m := prev.meta
+		if m.Version == 0 {
+			m.CreateRevision = st.Revision
+		}
+		m.ModRevision = st.Revision
+		m.Version++
+		m.ModTime, m.Writer, m.Lease = st.Time, st.Writer, lease
+		s.recordLocked(key, keyRevision{value: value, meta: m})
+		return
+	}
+	delete(s.colls, key)
+	s.attachLocked(key, lease)
+	m, ok := s.meta[key]
+	if !ok {
+		m = KeyMeta{CreateRevision: st.Revision}
+	}
+	m.ModRevision = st.Revision
+	m.Version++
+	m.ModTime, m.Writer, m.Lease = st.Time, st.Writer, lease
+
+	s.data[key] = value
+	s.meta[key] = m
+	s.recordLocked(key, keyRevision{value: value, meta: m})
+}
+
+// deleteLocked removes key. Like putLocked, a replicated delete older than
+// the key's latest change only joins its history.
+func (s *MemoryStorage) deleteLocked(key string, st Stamp) {
+	tombstone := keyRevision{meta: KeyMeta{ModRevision: st.Revision, ModTime: st.Time, Writer: st.Writer}, deleted: true}
+	if st.Revision < s.modRevisionLocked(key) {
+		if _, ok := s.atLocked(key, st.Revision); ok {
+			s.recordLocked(key, tombstone)
+		}
+		return
+	}
+	if _, ok := s.colls[key]; ok {
+		s.dropCollectionLocked(key, st)
+	}
+	if _, ok := s.data[key]; ok {
+		s.attachLocked(key, 0)
+		s.recordLocked(key, tombstone)
+	}
+	delete(s.data, key)
+	delete(s.meta, key)
+}
+
+// modRevisionLocked returns the revision of the latest change to key,
+// deletions and collection writes included. Caller holds s.mu.
+func (s *MemoryStorage) modRevisionLocked(key string) int64 {
+	rev := s.meta[key].ModRevision
+	if c, ok := s.colls[key]; ok {
+		rev = max(rev, c.Revision)
+	}
+	if h := s.history[key]; len(h) > 0 {
+		rev = max(rev, h[len(h)-1].meta.ModRevision)
+	}
+	return rev
+}
+
 func (s *MemoryStorage) Get(key string) (string, error) {
 	if key == "" {
-		return "", fmt.Errorf("key cannot be empty")
+		return "", fmt.Errorf("%w: key cannot be empty", ErrInvalidArgument)
 	}
 
 	s.mu.RLock()
@@ -51,16 +132,33 @@ func (s *MemoryStorage) Get(key string) (string, error) {
 
 func (s *MemoryStorage) Delete(key string) (bool, error) {
 	if key == "" {
-		return false, fmt.Errorf("key cannot be empty")
+		return false, fmt.Errorf("%w: key cannot be empty", ErrInvalidArgument)
 	}
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	delete(s.data, key)
+	s.deleteLocked(key, s.stampLocked(Stamp{}))
+	s.notifyLocked()
 
 	return true, nil
 }
 
+// Version returns the number of writes to key since it was created, 0 when
+// it does not exist.
+func (s *MemoryStorage) Version(key string) int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.meta[key].Version
+}
+
+// existsLocked reports whether key holds a value or a collection. Caller
+// holds s.mu.
+func (s *MemoryStorage) existsLocked(key string) bool {
+	_, value := s.data[key]
+	_, coll := s.colls[key]
+	return value || coll
+}
+
 func (s *MemoryStorage) Has(key string) bool {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -70,10 +168,37 @@ func (s *MemoryStorage) Has(key string) bool {
 	return ok
 }
 
+// Size returns the number of keys holding a value or a collection.
 func (s *MemoryStorage) Size() int {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	return len(s.data)
+	return len(s.data) + len(s.colls)
+}
+
+// KeysInRange returns the sorted keys in [start, end); an empty end is unbounded.
+func (s *MemoryStorage) KeysInRange(start, end string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var keys []string
+	for k := range s.data {
+		if k >= start && (end == "" || k < end) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// PrefixEnd returns the first key after every key starting with prefix, ""
+// when there is none, so [prefix, PrefixEnd(prefix)) holds the prefixed keys.
+func PrefixEnd(prefix string) string {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] < 0xff {
+			return prefix[:i] + string([]byte{prefix[i] + 1})
+		}
+	}
+	return ""
 }
 
 func (s *MemoryStorage) Print() {
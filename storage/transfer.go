@@ -0,0 +1,165 @@
+package storage
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+)
+
+// KeyVersion is one version of a key in a KeyRecord.
+type KeyVersion struct {
+	Value   string
+	Meta    KeyMeta
+	Deleted bool // tombstone: the key was deleted at Meta.ModRevision
+}
+
+// KeyRecord is the whole state of a key as a range move hands it to another
+// group: its history since the last compaction, oldest first, or the
+// collection it holds. A record with neither removes the key.
+type KeyRecord struct {
+	Key        string
+	History    []KeyVersion
+	Collection *Collection
+}
+
+// Current returns the value of the key the record leaves behind, and whether
+// it leaves a plain value.
+func (r KeyRecord) Current() (string, bool) {
+	if len(r.History) == 0 || r.History[len(r.History)-1].Deleted {
+		return "", false
+	}
+	return r.History[len(r.History)-1].Value, true
+}
+
+// Lease returns the lease the key is attached to once the record is
+// imported, 0 for none.
+func (r KeyRecord) Lease() int64 {
+	if _, ok := r.Current(); !ok {
+		return 0
+	}
+	return r.History[len(r.History)-1].Meta.Lease
+}
+
+// ExportRange returns the records of the keys in [start, end) holding a
+// value or a collection, in key order. An empty end is unbounded.
+func (s *MemoryStorage) ExportRange(start, end string) []KeyRecord {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var keys []string
+	for k := range s.data {
+		if k >= start && (end == "" || k < end) {
+			keys = append(keys, k)
+		}
+	}
+	for k := range s.colls {
+		if k >= start && (end == "" || k < end) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return s.exportLocked(keys)
+}
+
+// ExportKeys returns the records of keys, which remove the keys that hold
+// nothing and have no history.
+func (s *MemoryStorage) ExportKeys(keys []string) []KeyRecord {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.exportLocked(keys)
+}
+
+func (s *MemoryStorage) exportLocked(keys []string) []KeyRecord {
+	records := make([]KeyRecord, 0, len(keys))
+	for _, key := range keys {
+		r := KeyRecord{Key: key}
+		if c, ok := s.colls[key]; ok {
+			r.Collection = c.clone()
+		} else {
+			for _, e := range s.history[key] {
+				r.History = append(r.History, KeyVersion{Value: e.value, Meta: e.meta, Deleted: e.deleted})
+			}
+		}
+		records = append(records, r)
+	}
+	return records
+}
+
+// ImportKeys replaces the state of the keys of records, stamped with st. A
+// replica keeps a key written after st, by a write that overtook the import,
+// and a key a record removes keeps a tombstone at st refusing older records.
+// The revision counter moves past the imported history so later writes stay
+// newer than it. The leases the records attach keys to must be granted.
+func (s *MemoryStorage) ImportKeys(records []KeyRecord, st Stamp) (Stamp, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if st.Revision == 0 {
+		for _, r := range records {
+			if lease := r.Lease(); lease != 0 {
+				if l, ok := s.leases[lease]; !ok || l.ttl == 0 {
+					return Stamp{}, fmt.Errorf("%w: %d", ErrLeaseNotFound, lease)
+				}
+			}
+		}
+	}
+	st = s.stampLocked(st)
+	for _, r := range records {
+		if s.modRevisionLocked(r.Key) > st.Revision {
+			continue
+		}
+		_, had := s.data[r.Key]
+		value, ok := r.Current()
+		m := KeyMeta{ModRevision: st.Revision, ModTime: st.Time, Writer: st.Writer}
+		if ok {
+			m = r.History[len(r.History)-1].Meta
+		}
+		s.attachLocked(r.Key, r.Lease())
+		delete(s.data, r.Key)
+		delete(s.meta, r.Key)
+		delete(s.colls, r.Key)
+		delete(s.history, r.Key)
+		if ok {
+			s.data[r.Key], s.meta[r.Key] = value, m
+		}
+		for _, v := range r.History {
+			s.history[r.Key] = append(s.history[r.Key], keyRevision{value: v.Value, meta: v.Meta, deleted: v.Deleted})
+			s.revision = max(s.revision, v.Meta.ModRevision)
+		}
+		switch {
+		case r.Collection != nil:
+			c := r.Collection.clone()
+			c.Revision = st.Revision
+			s.colls[r.Key] = c
+		case !ok:
+			s.dropCollectionLocked(r.Key, st)
+		}
+		if ok || had {
+			s.pending = append(s.pending, Event{Key: r.Key, Value: value, Deleted: !ok, Meta: m})
+		}
+	}
+	s.notifyLocked()
+	return st, nil
+}
+
+// CollectionsInRange returns the records of the keys in [start, end) holding
+// a collection, in key order. An empty end is unbounded.
+func (s *MemoryStorage) CollectionsInRange(start, end string) []KeyRecord {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var keys []string
+	for k := range s.colls {
+		if k >= start && (end == "" || k < end) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return s.exportLocked(keys)
+}
+
+func (c *Collection) clone() *Collection {
+	out := *c
+	out.List, out.Set, out.Hash, out.ZSet = append([]string(nil), c.List...), maps.Clone(c.Set), maps.Clone(c.Hash), maps.Clone(c.ZSet)
+	return &out
+}
@@ -0,0 +1,265 @@
+package storage
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Targets of a Compare
+const (
+	CMP_VALUE   = "value"
+	CMP_VERSION = "version"
+	CMP_EXISTS  = "exists"
+	CMP_CREATE  = "create"
+)
+
+// Operators of a Compare; CMP_EXISTS only takes CMP_EQ and CMP_NE
+const (
+	CMP_EQ = "="
+	CMP_NE = "!="
+	CMP_LT = "<"
+	CMP_GT = ">"
+)
+
+// Compare is a condition of a transaction on the current state of Key. A
+// missing key has an empty value, version 0 and create revision 0.
+type Compare struct {
+	Key      string
+	Target   string
+	Op       string
+	Value    string // CMP_VALUE
+	Version  int64  // CMP_VERSION
+	Exists   bool   // CMP_EXISTS
+	Revision int64  // CMP_CREATE
+}
+
+// KeyState is the state of a key a transaction compared.
+type KeyState struct {
+	Value   string
+	Version int64
+	Exists  bool
+}
+
+// TxnResult tells which branch of a transaction ran and what it wrote under
+// which stamp. Current holds the compared keys as they were when the compares
+// ran.
+type TxnResult struct {
+	Succeeded bool
+	Applied   []BatchOp
+	Stamp     Stamp
+	Current   map[string]KeyState
+}
+
+// BatchOp is one write of a batch: a put, or a removal when Delete is set.
+// A put attaches the key to Lease, or detaches it when Lease is 0. A
+// transaction drops a put with IfAbsent when the key exists, and turns an
+// Increment into a put of the key's integer value plus Delta; ApplyBatch
+// expects plain writes. An Unsigned increment counts as memcached does, see
+// AddUnsigned, with Delta holding the bits of the unsigned amount.
+type BatchOp struct {
+	Key       string
+	Value     string
+	Delete    bool
+	Lease     int64
+	IfAbsent  bool
+	Increment bool
+	Delta     int64
+	Unsigned  bool
+	Decrement bool // an Unsigned increment subtracts Delta
+}
+
+func (c Compare) validate() error {
+	if c.Key == "" {
+		return fmt.Errorf("%w: key cannot be empty", ErrInvalidArgument)
+	}
+	switch c.Op {
+	case CMP_EQ, CMP_NE:
+	case CMP_LT, CMP_GT:
+		if c.Target == CMP_EXISTS {
+			return fmt.Errorf("%w: operator %q does not apply to %s", ErrInvalidArgument, c.Op, c.Target)
+		}
+	default:
+		return fmt.Errorf("%w: unknown compare operator %q", ErrInvalidArgument, c.Op)
+	}
+	switch c.Target {
+	case CMP_VALUE, CMP_VERSION, CMP_EXISTS, CMP_CREATE:
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown compare target %q", ErrInvalidArgument, c.Target)
+	}
+}
+
+// holdsLocked evaluates c. Caller holds s.mu.
+func (s *MemoryStorage) holdsLocked(c Compare) bool {
+	var cmp int
+	switch c.Target {
+	case CMP_VALUE:
+		cmp = compareOrdered(s.data[c.Key], c.Value)
+	case CMP_VERSION:
+		cmp = compareOrdered(s.meta[c.Key].Version, c.Version)
+	case CMP_CREATE:
+		cmp = compareOrdered(s.meta[c.Key].CreateRevision, c.Revision)
+	case CMP_EXISTS:
+		if s.existsLocked(c.Key) != c.Exists {
+			cmp = 1
+		}
+	}
+	switch c.Op {
+	case CMP_EQ:
+		return cmp == 0
+	case CMP_NE:
+		return cmp != 0
+	case CMP_LT:
+		return cmp < 0
+	default:
+		return cmp > 0
+	}
+}
+
+func compareOrdered[T string | int64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func validateBatch(ops []BatchOp) error {
+	for _, op := range ops {
+		if err := op.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate reports whether op can be part of a batch, so callers building
+// large batches can keep a bad op from failing the others.
+func (op BatchOp) Validate() error {
+	if op.Key == "" {
+		return fmt.Errorf("%w: key cannot be empty", ErrInvalidArgument)
+	}
+	if op.Delete && (op.IfAbsent || op.Increment) {
+		return fmt.Errorf("%w: a deletion of %s cannot be conditional or an increment", ErrInvalidArgument, op.Key)
+	}
+	return nil
+}
+
+// applyLocked applies ops under one stamp, which an empty batch does not use.
+func (s *MemoryStorage) applyLocked(ops []BatchOp, st Stamp) Stamp {
+	if len(ops) == 0 {
+		return Stamp{}
+	}
+	st = s.stampLocked(st)
+	for _, op := range ops {
+		if op.Delete {
+			s.deleteLocked(op.Key, st)
+		} else {
+			s.putLocked(op.Key, op.Value, op.Lease, st)
+		}
+	}
+	s.notifyLocked()
+	return st
+}
+
+// resolveLocked turns ops into the plain writes they amount to at their
+// point of the batch: IfAbsent puts of keys that exist are dropped, and
+// increments become puts of the resulting value that keep the key's lease.
+// The resolved writes can be replayed as they are. The keys of IfAbsent puts
+// and increments are added to current as they were before the batch. Caller
+// holds s.mu.
+func (s *MemoryStorage) resolveLocked(ops []BatchOp, current map[string]KeyState) ([]BatchOp, error) {
+	resolved := make([]BatchOp, 0, len(ops))
+	written := make(map[string]BatchOp) // last write of each key by earlier ops
+	for _, op := range ops {
+		value, exists := s.data[op.Key], s.existsLocked(op.Key)
+		lease := s.meta[op.Key].Lease
+		if _, ok := current[op.Key]; !ok && (op.IfAbsent || op.Increment) {
+			current[op.Key] = KeyState{Value: value, Version: s.meta[op.Key].Version, Exists: exists}
+		}
+		if w, ok := written[op.Key]; ok {
+			value, exists, lease = w.Value, !w.Delete, w.Lease
+		}
+
+		if op.IfAbsent && exists {
+			continue
+		}
+		if _, ok := s.colls[op.Key]; ok && op.Increment {
+			return nil, fmt.Errorf("%s: %w", op.Key, ErrWrongType)
+		}
+		if op.Increment && op.Unsigned {
+			n, err := AddUnsigned(value, exists, uint64(op.Delta), op.Decrement)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op.Key, err)
+			}
+			op.Value, op.Lease = strconv.FormatUint(n, 10), lease
+		} else if op.Increment {
+			n, err := addDelta(value, exists, op.Delta)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op.Key, err)
+			}
+			op.Value, op.Lease = strconv.FormatInt(n, 10), lease
+		}
+		op.IfAbsent, op.Increment, op.Delta, op.Unsigned, op.Decrement = false, false, 0, false, false
+		resolved = append(resolved, op)
+		written[op.Key] = op
+	}
+	return resolved, nil
+}
+
+// ApplyBatch applies ops in order under one lock and one revision, so readers
+// see all of them or none. It returns the stamp the writes got.
+func (s *MemoryStorage) ApplyBatch(ops []BatchOp, st Stamp) (Stamp, error) {
+	if err := validateBatch(ops); err != nil {
+		return Stamp{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkLeasesLocked(ops, st); err != nil {
+		return Stamp{}, err
+	}
+	return s.applyLocked(ops, st), nil
+}
+
+// Txn evaluates cmps and applies success when they all hold, failure
+// otherwise, under one lock and stamped with st.
+func (s *MemoryStorage) Txn(cmps []Compare, success, failure []BatchOp, st Stamp) (TxnResult, error) {
+	for _, c := range cmps {
+		if err := c.validate(); err != nil {
+			return TxnResult{}, err
+		}
+	}
+	if err := validateBatch(success); err != nil {
+		return TxnResult{}, err
+	}
+	if err := validateBatch(failure); err != nil {
+		return TxnResult{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := TxnResult{Succeeded: true, Applied: success, Current: make(map[string]KeyState, len(cmps))}
+	for _, c := range cmps {
+		value, exists := s.data[c.Key], s.existsLocked(c.Key)
+		res.Current[c.Key] = KeyState{Value: value, Version: s.meta[c.Key].Version, Exists: exists}
+		if res.Succeeded && !s.holdsLocked(c) {
+			res.Succeeded, res.Applied = false, failure
+		}
+	}
+	applied, err := s.resolveLocked(res.Applied, res.Current)
+	if err != nil {
+		return TxnResult{}, err
+	}
+	res.Applied = applied
+	if err := s.checkLeasesLocked(res.Applied, st); err != nil {
+		return TxnResult{}, err
+	}
+	res.Stamp = s.applyLocked(res.Applied, st)
+	return res, nil
+}
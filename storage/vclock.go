@@ -0,0 +1,66 @@
+package storage
+
+// Results of VectorClock.Compare
+const (
+	EQUAL      = 0
+	BEFORE     = 1
+	AFTER      = 2
+	CONCURRENT = 3
+)
+
+// VectorClock counts the writes each node coordinated for a value.
+type VectorClock map[int]uint64
+
+func (vc VectorClock) Copy() VectorClock {
+	out := make(VectorClock, len(vc))
+	for id, c := range vc {
+		out[id] = c
+	}
+	return out
+}
+
+// Increment returns a copy of vc with the counter of node id bumped.
+func (vc VectorClock) Increment(id int) VectorClock {
+	out := vc.Copy()
+	out[id]++
+	return out
+}
+
+// Merge returns the pairwise maximum of both clocks.
+func (vc VectorClock) Merge(other VectorClock) VectorClock {
+	out := vc.Copy()
+	for id, c := range other {
+		if c > out[id] {
+			out[id] = c
+		}
+	}
+	return out
+}
+
+// Compare tells whether vc happened BEFORE or AFTER other, is EQUAL to it or
+// CONCURRENT with it.
+func (vc VectorClock) Compare(other VectorClock) int {
+	less, greater := false, false
+	for id, c := range vc {
+		if c > other[id] {
+			greater = true
+		} else if c < other[id] {
+			less = true
+		}
+	}
+	for id, c := range other {
+		if _, ok := vc[id]; !ok && c > 0 {
+			less = true
+		}
+	}
+	switch {
+	case less && greater:
+		return CONCURRENT
+	case less:
+		return BEFORE
+	case greater:
+		return AFTER
+	default:
+		return EQUAL
+	}
+}
@@ -0,0 +1,61 @@
+package storage
+
+import "sync"
+
+// Sibling is one version of a value. Deleted siblings are tombstones kept so
+// the deletion wins over the versions it replaced.
+type Sibling struct {
+	Value   string
+	Deleted bool
+	Clock   VectorClock
+}
+
+// Reconcile drops the siblings another one descends from and duplicates of
+// the same version, leaving only concurrent versions.
+func Reconcile(siblings []Sibling) []Sibling {
+	var out []Sibling
+	for i, s := range siblings {
+		keep := true
+		for j, other := range siblings {
+			if i == j {
+				continue
+			}
+			cmp := s.Clock.Compare(other.Clock)
+			if cmp == BEFORE || (cmp == EQUAL && j < i) {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// VersionedStorage keeps concurrent versions of each key, as used by the
+// leaderless replication mode.
+type VersionedStorage struct {
+	data map[string][]Sibling
+	mu   sync.RWMutex
+}
+
+func NewVersionedStorage() *VersionedStorage {
+	return &VersionedStorage{data: make(map[string][]Sibling)}
+}
+
+// Merge adds incoming versions of key and returns what is kept.
+func (s *VersionedStorage) Merge(key string, incoming []Sibling) []Sibling {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	merged := Reconcile(append(append([]Sibling(nil), s.data[key]...), incoming...))
+	s.data[key] = merged
+	return append([]Sibling(nil), merged...)
+}
+
+func (s *VersionedStorage) Get(key string) []Sibling {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]Sibling(nil), s.data[key]...)
+}
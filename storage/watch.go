@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Event is a change to a key: a put, or a deletion when Deleted is set.
+// Meta.ModRevision is the revision of the change.
+type Event struct {
+	Key     string
+	Value   string
+	Deleted bool
+	Meta    KeyMeta
+}
+
+// Observe has fn called with the changes of every write, in the order the
+// writes are applied. fn runs under the storage lock, so it must not block or
+// call back into the storage.
+func (s *MemoryStorage) Observe(fn func([]Event)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.observer = fn
+}
+
+// notifyLocked hands the changes of the write just applied to the observer.
+// Caller holds s.mu.
+func (s *MemoryStorage) notifyLocked() {
+	if s.observer != nil && len(s.pending) > 0 {
+		s.observer(s.pending)
+	}
+	s.pending = nil
+}
+
+// ChangesSince returns the changes to keys in [start, end) from revision on,
+// in revision order, along with the revision of the last write they cover.
+// An empty end is unbounded.
+func (s *MemoryStorage) ChangesSince(start, end string, from int64) ([]Event, int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if from < s.compacted {
+		return nil, 0, fmt.Errorf("%w: %d, compacted up to %d", ErrCompacted, from, s.compacted)
+	}
+	var events []Event
+	for key, h := range s.history {
+		if key < start || (end != "" && key >= end) {
+			continue
+		}
+		i := sort.Search(len(h), func(i int) bool { return h[i].meta.ModRevision >= from })
+		for _, e := range h[i:] {
+			events = append(events, Event{Key: key, Value: e.value, Deleted: e.deleted, Meta: e.meta})
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].Meta.ModRevision != events[j].Meta.ModRevision {
+			return events[i].Meta.ModRevision < events[j].Meta.ModRevision
+		}
+		return events[i].Key < events[j].Key
+	})
+	return events, s.revision, nil
+}
@@ -0,0 +1,45 @@
+package test
+
+import (
+	"kvstore/iface"
+	"kvstore/node"
+	"kvstore/storage"
+	"testing"
+)
+
+func TestBatch(t *testing.T) {
+	leader := node.NewNode(131, "localhost:50192", node.LEADER)
+	follower := node.NewNode(132, "localhost:50193", node.FOLLOWER)
+	if err := follower.Join("localhost:50192"); err != nil {
+		t.Fatalf("Expected join to succeed, got %v", err)
+	}
+	waitForMembers(t, 2, leader, follower)
+	epoch := max(leader.GetEpoch(), follower.GetEpoch()) + 1
+	for _, n := range []*node.Node{leader, follower} {
+		if err := n.SetLeader(131, "localhost:50192", epoch); err != nil {
+			t.Fatalf("SetLeader failed: %v", err)
+		}
+	}
+	meta := iface.RequestMeta{}
+	leader.HandlePut(meta, "gone", "x")
+
+	// Written through the follower, the batch is one revision on every replica
+	ops := []storage.BatchOp{{Key: "a", Value: "1"}, {Key: ""}, {Key: "b", Value: "2"}, {Key: "gone", Delete: true}, {Key: "c", Delete: true, IfAbsent: true}}
+	errs := follower.HandleBatchWrite(meta, ops)
+	if errs[0] != nil || errs[1] == nil || errs[2] != nil || errs[3] != nil || errs[4] == nil {
+		t.Fatalf("Expected only the invalid ops to fail, got %v", errs)
+	}
+	waitForValue(t, follower, "b", "2")
+
+	results := follower.HandleBatchGet([]string{"b", "gone", "a"})
+	if len(results) != 3 {
+		t.Fatalf("Expected a result per key, got %+v", results)
+	}
+	a, b, gone := results[2], results[0], results[1]
+	if !a.Found || a.Value != "1" || !b.Found || b.Value != "2" || gone.Found || gone.Err != nil {
+		t.Errorf("Expected a and b found and gone missing, got %+v", results)
+	}
+	if a.Meta.ModRevision != b.Meta.ModRevision || a.Meta.ModRevision != 2 {
+		t.Errorf("Expected the batch at revision 2, got %d and %d", a.Meta.ModRevision, b.Meta.ModRevision)
+	}
+}
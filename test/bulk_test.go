@@ -0,0 +1,68 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"kvstore/iface"
+	"kvstore/node"
+	"kvstore/server"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestBulkLoad(t *testing.T) {
+	n := node.NewNode(133, "localhost:50194", node.LEADER)
+	if err := n.SetLeader(133, "localhost:50194", n.GetEpoch()+1); err != nil {
+		t.Fatalf("SetLeader failed: %v", err)
+	}
+	conn, err := grpc.NewClient("localhost:50194", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer conn.Close()
+	client := server.NewKVStoreClient(conn)
+	n.HandlePut(iface.RequestMeta{}, "k0007", "old")
+
+	load := func(skip bool, chunks ...[]*server.KeyValue) *server.BulkLoadResponse {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		stream, err := client.BulkLoad(ctx, grpc.WaitForReady(true))
+		if err != nil {
+			t.Fatalf("BulkLoad failed: %v", err)
+		}
+		for _, records := range chunks {
+			if err := stream.Send(&server.BulkLoadRequest{Records: records, SkipExisting: skip}); err != nil {
+				t.Fatalf("Send failed: %v", err)
+			}
+		}
+		resp, err := stream.CloseAndRecv()
+		if err != nil {
+			t.Fatalf("BulkLoad failed: %v", err)
+		}
+		return resp
+	}
+
+	// Spread over several batches, with an existing key, a repeat and an empty key
+	var records []*server.KeyValue
+	for i := 0; i < 2500; i++ {
+		records = append(records, &server.KeyValue{Key: fmt.Sprintf("k%04d", i), Value: "new"})
+	}
+	records = append(records, &server.KeyValue{Key: "k0001", Value: "again"}, &server.KeyValue{Key: ""})
+	resp := load(true, records[:1200], records[1200:])
+	if resp.Loaded != 2499 || resp.Skipped != 2 || resp.Failed != 1 || len(resp.Errors) != 1 {
+		t.Fatalf("Expected 2499 loaded, 2 skipped and 1 failed, got %+v", resp)
+	}
+	for key, want := range map[string]string{"k0007": "old", "k0001": "new", "k2499": "new"} {
+		if value, _ := n.HandleGet(key); value != want {
+			t.Errorf("Expected %s to be %q, got %q", key, want, value)
+		}
+	}
+
+	resp = load(false, []*server.KeyValue{{Key: "k0007", Value: "replaced"}})
+	if value, _ := n.HandleGet("k0007"); resp.Loaded != 1 || value != "replaced" {
+		t.Errorf("Expected the existing key to be overwritten, got %q with %+v", value, resp)
+	}
+}
@@ -0,0 +1,39 @@
+package test
+
+import (
+	"kvstore/iface"
+	"kvstore/node"
+	"testing"
+)
+
+func TestCompareAndSwap(t *testing.T) {
+	n := node.NewNode(121, "localhost:50182", node.LEADER)
+	meta := iface.RequestMeta{}
+
+	ok, _, err := n.HandleConditionalPut(meta, "lock", "a", iface.Condition{IfAbsent: true})
+	if err != nil || !ok {
+		t.Fatalf("Expected the key to be created, got %v (%v)", ok, err)
+	}
+	ok, current, err := n.HandleConditionalPut(meta, "lock", "b", iface.Condition{IfAbsent: true})
+	if err != nil || ok || current.Value != "a" || current.Version != 1 {
+		t.Fatalf("Expected if-absent to fail with the current value, got %v %+v (%v)", ok, current, err)
+	}
+
+	if ok, _, err := n.HandleCompareAndSwap(meta, "lock", "a", "b"); err != nil || !ok {
+		t.Fatalf("Expected the swap to succeed, got %v (%v)", ok, err)
+	}
+	ok, current, err = n.HandleCompareAndSwap(meta, "lock", "a", "c")
+	if err != nil || ok || current.Value != "b" || current.Version != 2 {
+		t.Fatalf("Expected the swap to fail with the current value, got %v %+v (%v)", ok, current, err)
+	}
+
+	if ok, _, _ := n.HandleConditionalDelete(meta, "lock", iface.Condition{IfVersion: 1}); ok {
+		t.Errorf("Expected a delete at a stale version to fail")
+	}
+	if ok, _, err := n.HandleConditionalDelete(meta, "lock", iface.Condition{IfVersion: 2}); err != nil || !ok {
+		t.Errorf("Expected the delete to succeed, got %v (%v)", ok, err)
+	}
+	if value, _ := n.HandleGet("lock"); value != "" {
+		t.Errorf("Expected the key to be deleted, got %q", value)
+	}
+}
@@ -0,0 +1,106 @@
+package test
+
+import (
+	"kvstore/iface"
+	"kvstore/node"
+	"testing"
+	"time"
+)
+
+func TestChainReplication(t *testing.T) {
+	head := node.NewNode(113, "localhost:50173", node.LEADER)
+	mid := node.NewNode(115, "localhost:50175", node.FOLLOWER)
+	tail := node.NewNode(116, "localhost:50176", node.FOLLOWER)
+	head.SetClusterMetadata(iface.ClusterMetadata{
+		Version:     1,
+		Shards:      []iface.ShardGroup{{ID: 0, LeaderID: 113, LeaderAddr: "localhost:50173"}},
+		Replication: iface.CHAIN,
+	})
+	for _, n := range []*node.Node{mid, tail} {
+		if err := n.Join("localhost:50173"); err != nil {
+			t.Fatalf("Expected join to succeed, got %v", err)
+		}
+	}
+	waitForMembers(t, 3, head, mid, tail)
+
+	epoch := max(head.GetEpoch(), mid.GetEpoch(), tail.GetEpoch()) + 1
+	for _, n := range []*node.Node{head, mid, tail} {
+		if err := n.SetLeader(113, "localhost:50173", epoch); err != nil {
+			t.Fatalf("SetLeader failed: %v", err)
+		}
+	}
+
+	// Writes entering at a follower go to the head and are applied by the
+	// tail before the call returns.
+	if err := mid.HandlePut(iface.RequestMeta{}, "key", "v1"); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if value, _, err := tail.HandleChainRead("key", 0); err != nil || value != "v1" {
+		t.Fatalf("Expected the tail to hold v1, got %q (%v)", value, err)
+	}
+
+	// A retry is sent down the chain again without being applied twice
+	session := iface.RequestMeta{ClientID: "c1", Seq: 1}
+	for i := 0; i < 2; i++ {
+		if err := mid.HandlePut(session, "retried", "v1"); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+	if _, meta, err := tail.HandleChainRead("retried", 0); err != nil || meta.Version != 1 {
+		t.Errorf("Expected the tail to hold the write once, got version %d (%v)", meta.Version, err)
+	}
+
+	// A member that never answers sits between the head and mid; the head
+	// reconfigures the chain around it.
+	dead := iface.Member{ID: 114, Addr: "localhost:50174", Status: iface.ALIVE}
+	head.HandlePing(dead, []iface.Member{dead})
+
+	if err := head.HandlePut(iface.RequestMeta{}, "key", "v2"); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	for _, n := range []*node.Node{head, mid} {
+		if value, err := n.HandleGet("key"); err != nil || value != "v2" {
+			t.Errorf("Expected v2 from the tail, got %q (%v)", value, err)
+		}
+	}
+
+	if err := head.HandleDelete(iface.RequestMeta{}, "key"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if value, _, _ := tail.HandleChainRead("key", 0); value != "" {
+		t.Errorf("Expected the key to be deleted on the tail, got %q", value)
+	}
+
+	// The member comes back; it is brought up to date before it rejoins
+	if err := head.HandlePut(iface.RequestMeta{}, "missed", "v3"); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	back := node.NewNode(114, "localhost:50174", node.FOLLOWER)
+	if err := back.Join("localhost:50173"); err != nil {
+		t.Fatalf("Expected join to succeed, got %v", err)
+	}
+	if err := back.SetLeader(113, "localhost:50173", epoch); err != nil {
+		t.Fatalf("SetLeader failed: %v", err)
+	}
+	deadline := time.Now().Add(30 * time.Second)
+	for {
+		if err := head.HandlePut(iface.RequestMeta{}, "later", "v4"); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+		missed, _, _ := back.HandleChainRead("missed", 0)
+		later, _, _ := back.HandleChainRead("later", 0)
+		if missed == "v3" && later == "v4" {
+			break
+		}
+		if missed == "" && later != "" {
+			t.Fatalf("Expected the member to get the writes it missed before new ones")
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the member to be caught up, got %q and %q", missed, later)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if value, _, _ := back.HandleChainRead("key", 0); value != "" {
+		t.Errorf("Expected the member to get the deletion it missed, got %q", value)
+	}
+}
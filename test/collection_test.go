@@ -0,0 +1,169 @@
+package test
+
+import (
+	"errors"
+	"kvstore/iface"
+	"kvstore/node"
+	"kvstore/storage"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStorageCollections(t *testing.T) {
+	s := storage.NewMemoryStorage()
+	run := func(op storage.CollectionOp) storage.CollectionResult {
+		res, err := s.ReadCollection(op)
+		if op.Writes() {
+			res, _, _, err = s.ApplyCollection(op, storage.Stamp{})
+		}
+		if err != nil {
+			t.Fatalf("%s failed: %v", op.Cmd, err)
+		}
+		return res
+	}
+
+	run(storage.CollectionOp{Cmd: storage.RPUSH, Key: "l", Members: []string{"b", "c"}})
+	if res := run(storage.CollectionOp{Cmd: storage.LPUSH, Key: "l", Members: []string{"a"}}); res.Count != 3 {
+		t.Errorf("Expected 3 elements after the push, got %d", res.Count)
+	}
+	if res := run(storage.CollectionOp{Cmd: storage.LRANGE, Key: "l", Start: 1, Stop: -1}); !reflect.DeepEqual(res.Elements, []string{"b", "c"}) {
+		t.Errorf("Expected [b c], got %v", res.Elements)
+	}
+	if res := run(storage.CollectionOp{Cmd: storage.RPOP, Key: "l", Count: 5}); !reflect.DeepEqual(res.Elements, []string{"c", "b", "a"}) {
+		t.Errorf("Expected to pop [c b a], got %v", res.Elements)
+	}
+
+	run(storage.CollectionOp{Cmd: storage.SADD, Key: "s1", Members: []string{"x", "y", "z"}})
+	run(storage.CollectionOp{Cmd: storage.SADD, Key: "s2", Members: []string{"y", "z", "w"}})
+	if res := run(storage.CollectionOp{Cmd: storage.SINTER, Key: "s1", Keys: []string{"s2"}}); !reflect.DeepEqual(res.Elements, []string{"y", "z"}) {
+		t.Errorf("Expected [y z] in common, got %v", res.Elements)
+	}
+
+	run(storage.CollectionOp{Cmd: storage.HSET, Key: "h", Members: []string{"f1", "f2"}, Values: []string{"1", "2"}})
+	run(storage.CollectionOp{Cmd: storage.HDEL, Key: "h", Members: []string{"f1"}})
+	if res := run(storage.CollectionOp{Cmd: storage.HGETALL, Key: "h"}); !reflect.DeepEqual(res.Elements, []string{"f2"}) || res.Values[0] != "2" {
+		t.Errorf("Expected only f2=2, got %v %v", res.Elements, res.Values)
+	}
+
+	run(storage.CollectionOp{Cmd: storage.ZADD, Key: "z", Members: []string{"c", "a", "b"}, Scores: []float64{3, 1, 2}})
+	if res := run(storage.CollectionOp{Cmd: storage.ZRANGEBYSCORE, Key: "z", Min: 2, Max: 3}); !reflect.DeepEqual(res.Elements, []string{"b", "c"}) {
+		t.Errorf("Expected [b c] scored 2 to 3, got %v", res.Elements)
+	}
+	if res := run(storage.CollectionOp{Cmd: storage.ZRANK, Key: "z", Members: []string{"c"}}); !res.Found || res.Count != 2 {
+		t.Errorf("Expected c at rank 2, got %+v", res)
+	}
+
+	// Keys keep to one type
+	s.Put("plain", "v")
+	if _, _, _, err := s.ApplyCollection(storage.CollectionOp{Cmd: storage.SADD, Key: "plain", Members: []string{"x"}}, storage.Stamp{}); !errors.Is(err, storage.ErrWrongType) {
+		t.Errorf("Expected a plain value to refuse a set command, got %v", err)
+	}
+	if _, err := s.ReadCollection(storage.CollectionOp{Cmd: storage.LRANGE, Key: "s1", Stop: -1}); !errors.Is(err, storage.ErrWrongType) {
+		t.Errorf("Expected a set to refuse a list command, got %v", err)
+	}
+	if _, _, err := s.GetAt("h", 0); !errors.Is(err, storage.ErrWrongType) {
+		t.Errorf("Expected reading a hash as a plain value to fail, got %v", err)
+	}
+
+	// A collection is a key that exists to conditions
+	if _, err := s.Txn(nil, []storage.BatchOp{{Key: "s1", Value: "v", IfAbsent: true}}, nil, storage.Stamp{}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if _, err := s.ReadCollection(storage.CollectionOp{Cmd: storage.SMEMBERS, Key: "s1"}); err != nil {
+		t.Errorf("Expected an IfAbsent put to leave the set, got %v", err)
+	}
+	exists := storage.Compare{Key: "s1", Target: storage.CMP_EXISTS, Op: storage.CMP_EQ, Exists: true}
+	if res, err := s.Txn([]storage.Compare{exists}, nil, nil, storage.Stamp{}); err != nil || !res.Succeeded || !res.Current["s1"].Exists {
+		t.Errorf("Expected the set to exist, got %+v (%v)", res, err)
+	}
+
+	// Malformed commands are the caller's error
+	if _, _, _, err := s.ApplyCollection(storage.CollectionOp{Cmd: storage.LPOP, Key: "l", Count: -1}, storage.Stamp{}); !errors.Is(err, storage.ErrInvalidArgument) {
+		t.Errorf("Expected a negative count to be an invalid argument, got %v", err)
+	}
+}
+
+func TestCollectionReplicaOrder(t *testing.T) {
+	leader, replica := storage.NewMemoryStorage(), storage.NewMemoryStorage()
+	write := func(op storage.CollectionOp) (storage.KeyRecord, storage.Stamp) {
+		_, rec, st, err := leader.ApplyCollection(op, storage.Stamp{})
+		if err != nil {
+			t.Fatalf("%s failed: %v", op.Cmd, err)
+		}
+		return rec, st
+	}
+	install := func(rec storage.KeyRecord, st storage.Stamp) {
+		if _, err := replica.ImportKeys([]storage.KeyRecord{rec}, st); err != nil {
+			t.Fatalf("Install failed: %v", err)
+		}
+	}
+	lrange := storage.CollectionOp{Cmd: storage.LRANGE, Key: "l", Stop: -1}
+
+	// Two pushes reach the replica in the wrong order
+	first, st1 := write(storage.CollectionOp{Cmd: storage.RPUSH, Key: "l", Members: []string{"a"}})
+	second, st2 := write(storage.CollectionOp{Cmd: storage.RPUSH, Key: "l", Members: []string{"b"}})
+	install(second, st2)
+	install(first, st1)
+	if res, _ := replica.ReadCollection(lrange); !reflect.DeepEqual(res.Elements, []string{"a", "b"}) {
+		t.Errorf("Expected the replica to keep the latest list, got %v", res.Elements)
+	}
+
+	// A delete overtaken by a later push leaves the push, and a push
+	// overtaken by the delete stays deleted
+	replica.ApplyBatch([]storage.BatchOp{{Key: "l", Delete: true}}, storage.Stamp{Revision: st1.Revision})
+	if res, _ := replica.ReadCollection(lrange); len(res.Elements) != 2 {
+		t.Errorf("Expected an older delete to leave the list, got %v", res.Elements)
+	}
+	replica.ApplyBatch([]storage.BatchOp{{Key: "l", Delete: true}}, storage.Stamp{Revision: st2.Revision + 10})
+	install(second, st2)
+	if res, _ := replica.ReadCollection(lrange); len(res.Elements) != 0 {
+		t.Errorf("Expected a newer delete to keep the list out, got %v", res.Elements)
+	}
+}
+
+func TestReplicatedCollections(t *testing.T) {
+	leader := node.NewNode(136, "localhost:50197", node.LEADER)
+	follower := node.NewNode(137, "localhost:50198", node.FOLLOWER)
+	if err := follower.Join("localhost:50197"); err != nil {
+		t.Fatalf("Expected join to succeed, got %v", err)
+	}
+	waitForMembers(t, 2, leader, follower)
+	epoch := max(leader.GetEpoch(), follower.GetEpoch()) + 1
+	for _, n := range []*node.Node{leader, follower} {
+		if err := n.SetLeader(136, "localhost:50197", epoch); err != nil {
+			t.Fatalf("SetLeader failed: %v", err)
+		}
+	}
+	meta := iface.RequestMeta{}
+
+	// Written through the follower, applied by the leader and replicated back
+	res, err := follower.HandleCollection(meta, storage.CollectionOp{Cmd: storage.RPUSH, Key: "queue", Members: []string{"a", "b"}})
+	if err != nil || res.Count != 2 {
+		t.Fatalf("Expected a list of 2, got %+v (%v)", res, err)
+	}
+	res, err = follower.HandleCollection(meta, storage.CollectionOp{Cmd: storage.LPOP, Key: "queue"})
+	if err != nil || !reflect.DeepEqual(res.Elements, []string{"a"}) {
+		t.Fatalf("Expected to pop a, got %+v (%v)", res, err)
+	}
+	read := storage.CollectionOp{Cmd: storage.LRANGE, Key: "queue", Stop: -1}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		res, _ := follower.HandleCollection(meta, read)
+		if reflect.DeepEqual(res.Elements, []string{"b"}) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the follower to hold [b], got %v", res.Elements)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	// A plain write replaces the list, and the list commands then refuse the key
+	if err := follower.HandlePut(meta, "queue", "plain"); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if _, err := leader.HandleCollection(meta, storage.CollectionOp{Cmd: storage.RPUSH, Key: "queue", Members: []string{"c"}}); !errors.Is(err, storage.ErrWrongType) {
+		t.Errorf("Expected WRONGTYPE, got %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+package test
+
+import (
+	"errors"
+	"kvstore/iface"
+	"kvstore/node"
+	"kvstore/storage"
+	"math"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestStorageIncrement(t *testing.T) {
+	s := storage.NewMemoryStorage()
+	increment := func(key string, delta int64) (string, error) {
+		res, err := s.Txn(nil, []storage.BatchOp{{Key: key, Increment: true, Delta: delta}}, nil, storage.Stamp{})
+		if err != nil {
+			return "", err
+		}
+		return res.Applied[0].Value, nil
+	}
+	if value, err := increment("hits", 5); err != nil || value != "5" {
+		t.Fatalf("Expected a missing key to count from 0, got %s (%v)", value, err)
+	}
+	if value, _ := increment("hits", -7); value != "-2" {
+		t.Errorf("Expected -2, got %s", value)
+	}
+
+	s.Put("name", "x")
+	if _, err := increment("name", 1); !errors.Is(err, storage.ErrNotNumber) {
+		t.Errorf("Expected a non-numeric value to be refused, got %v", err)
+	}
+	s.Put("big", strconv.FormatInt(math.MaxInt64-1, 10))
+	if _, err := increment("big", 2); !errors.Is(err, storage.ErrOverflow) {
+		t.Errorf("Expected an overflow, got %v", err)
+	}
+	if value, _ := s.Get("big"); value != strconv.FormatInt(math.MaxInt64-1, 10) {
+		t.Errorf("Expected a failed increment to leave the key alone, got %s", value)
+	}
+
+	// Increments within a transaction see the ones before them
+	res, err := s.Txn(nil, []storage.BatchOp{{Key: "c", Increment: true, Delta: 2}, {Key: "c", Increment: true, Delta: 3}}, nil, storage.Stamp{})
+	if err != nil || len(res.Applied) != 2 || res.Applied[1].Value != "5" || res.Applied[1].Increment {
+		t.Errorf("Expected the increments to resolve to puts of 2 and 5, got %+v (%v)", res.Applied, err)
+	}
+
+	// Unsigned increments wrap around and decrements stop at 0
+	s.Put("u", strconv.FormatUint(math.MaxUint64, 10))
+	res, err = s.Txn(nil, []storage.BatchOp{
+		{Key: "u", Increment: true, Delta: 3, Unsigned: true},
+		{Key: "u", Increment: true, Delta: 5, Unsigned: true, Decrement: true},
+	}, nil, storage.Stamp{})
+	if err != nil || len(res.Applied) != 2 || res.Applied[0].Value != "2" || res.Applied[1].Value != "0" {
+		t.Errorf("Expected the unsigned increments to resolve to puts of 2 and 0, got %+v (%v)", res.Applied, err)
+	}
+}
+
+func TestReplicatedIncrement(t *testing.T) {
+	leader := node.NewNode(134, "localhost:50195", node.LEADER)
+	follower := node.NewNode(135, "localhost:50196", node.FOLLOWER)
+	if err := follower.Join("localhost:50195"); err != nil {
+		t.Fatalf("Expected join to succeed, got %v", err)
+	}
+	waitForMembers(t, 2, leader, follower)
+	epoch := max(leader.GetEpoch(), follower.GetEpoch()) + 1
+	for _, n := range []*node.Node{leader, follower} {
+		if err := n.SetLeader(134, "localhost:50195", epoch); err != nil {
+			t.Fatalf("SetLeader failed: %v", err)
+		}
+	}
+
+	// Concurrent increments through both nodes are all counted once
+	var wg sync.WaitGroup
+	results := make(chan int64, 40)
+	for i := 0; i < 40; i++ {
+		wg.Add(1)
+		go func(n *node.Node) {
+			defer wg.Done()
+			value, err := n.HandleIncrement(iface.RequestMeta{}, "counter", 1)
+			if err != nil {
+				t.Errorf("Increment failed: %v", err)
+			}
+			results <- value
+		}([]*node.Node{leader, follower}[i%2])
+	}
+	wg.Wait()
+	close(results)
+	seen := make(map[int64]bool)
+	for value := range results {
+		seen[value] = true
+	}
+	if len(seen) != 40 || !seen[1] || !seen[40] {
+		t.Errorf("Expected each increment to return a distinct value from 1 to 40, got %v", seen)
+	}
+	if value, _ := leader.HandleGet("counter"); value != "40" {
+		t.Errorf("Expected the counter at 40, got %s", value)
+	}
+
+	leader.HandlePut(iface.RequestMeta{}, "name", "x")
+	if _, err := follower.HandleIncrement(iface.RequestMeta{}, "name", 1); !errors.Is(err, storage.ErrNotNumber) {
+		t.Errorf("Expected the follower to pass on the non-numeric error")
+	}
+}
@@ -0,0 +1,87 @@
+package test
+
+import (
+	"errors"
+	"kvstore/storage"
+	"reflect"
+	"testing"
+)
+
+func TestCRDTCounterMerge(t *testing.T) {
+	a, _ := storage.NewCRDT(storage.COUNTER)
+	b := a.Copy()
+
+	a.Counter.Increment(1, 5)
+	b.Counter.Increment(2, 3)
+	b.Counter.Increment(2, -1)
+
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if v := a.Counter.Value(); v != 7 {
+		t.Errorf("Expected 7, got %d", v)
+	}
+}
+
+func TestCRDTSetAddWins(t *testing.T) {
+	a, _ := storage.NewCRDT(storage.SET)
+	a.Set.Add("x", "1-a")
+	b := a.Copy()
+
+	// A concurrent add of x survives the remove of the tag b had seen.
+	b.Set.Remove("x")
+	a.Set.Add("x", "2-a")
+	a.Set.Add("y", "2-b")
+
+	if err := b.Merge(a); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if got := b.Set.Members(); !reflect.DeepEqual(got, []string{"x", "y"}) {
+		t.Errorf("Expected [x y], got %v", got)
+	}
+
+	b.Set.Remove("x")
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if got := a.Set.Members(); !reflect.DeepEqual(got, []string{"y"}) {
+		t.Errorf("Expected [y], got %v", got)
+	}
+}
+
+func TestCRDTMapLastWriterWins(t *testing.T) {
+	a, _ := storage.NewCRDT(storage.MAP)
+	b := a.Copy()
+
+	a.Map.Put("f", "old", 1, 1)
+	b.Map.Put("f", "new", 2, 2)
+	b.Map.Put("g", "v", 1, 2)
+	a.Map.Remove("g", 3, 1)
+
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if got := a.Map.Entries(); !reflect.DeepEqual(got, map[string]string{"f": "new"}) {
+		t.Errorf("Expected {f: new}, got %v", got)
+	}
+
+	data, err := a.Encode()
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	decoded, err := storage.DecodeCRDT(data)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if !reflect.DeepEqual(decoded.Map.Entries(), a.Map.Entries()) {
+		t.Errorf("Expected decoded map to match")
+	}
+
+	counter, _ := storage.NewCRDT(storage.COUNTER)
+	if err := a.Merge(counter); !errors.Is(err, storage.ErrWrongType) {
+		t.Errorf("Expected ErrWrongType, got %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"kvstore/iface"
+	"kvstore/node"
+	"testing"
+	"time"
+)
+
+func TestElection(t *testing.T) {
+	n := node.NewNode(130, "localhost:50191", node.LEADER)
+	if err := n.SetLeader(130, "localhost:50191", n.GetEpoch()+1); err != nil {
+		t.Fatalf("SetLeader failed: %v", err)
+	}
+	meta := iface.RequestMeta{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if _, _, err := n.HandleLeader("jobs"); !errors.Is(err, iface.ErrNoLeader) {
+		t.Errorf("Expected no leader yet, got %v", err)
+	}
+	first, _ := n.HandleLeaseGrant(meta, 0, time.Minute)
+	second, _ := n.HandleLeaseGrant(meta, 0, time.Minute)
+
+	observed := make(chan string, 16)
+	go n.HandleObserve(ctx, "jobs", func(leader iface.LeaderKey, value string) error {
+		observed <- value
+		return nil
+	})
+	next := func() string {
+		select {
+		case value := <-observed:
+			return value
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expected the observer to see a change")
+			return ""
+		}
+	}
+
+	leader, err := n.HandleCampaign(ctx, "jobs", first, "a")
+	if err != nil {
+		t.Fatalf("Campaign failed: %v", err)
+	}
+	if value := next(); value != "a" {
+		t.Errorf("Expected to observe a, got %q", value)
+	}
+
+	// The second candidate waits behind the leader
+	elected := make(chan iface.LeaderKey, 1)
+	go func() {
+		if l, err := n.HandleCampaign(ctx, "jobs", second, "b"); err == nil {
+			elected <- l
+		}
+	}()
+	select {
+	case <-elected:
+		t.Fatalf("Expected the second candidate to wait")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	// Campaigning again and giving up keeps the candidacy
+	short, cancelShort := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancelShort()
+	if _, err := n.HandleCampaign(short, "jobs", second, "b"); err == nil {
+		t.Fatalf("Expected the second candidate not to lead yet")
+	}
+
+	if err := n.HandleProclaim(meta, leader, "a2"); err != nil {
+		t.Fatalf("Proclaim failed: %v", err)
+	}
+	if value := next(); value != "a2" {
+		t.Errorf("Expected to observe a2, got %q", value)
+	}
+	if got, value, err := n.HandleLeader("jobs"); err != nil || got != leader || value != "a2" {
+		t.Errorf("Expected %+v with a2, got %+v with %q (%v)", leader, got, value, err)
+	}
+
+	if err := n.HandleResign(meta, leader); err != nil {
+		t.Fatalf("Resign failed: %v", err)
+	}
+	select {
+	case l := <-elected:
+		if l.Lease != second || l.Revision <= leader.Revision {
+			t.Errorf("Expected the second candidate to lead, got %+v", l)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the second candidate to be elected")
+	}
+	if value := next(); value != "b" {
+		t.Errorf("Expected to observe b, got %q", value)
+	}
+	if err := n.HandleProclaim(meta, leader, "stale"); !errors.Is(err, iface.ErrNotElected) {
+		t.Errorf("Expected a resigned leader to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package test
+
+import (
+	"encoding/json"
+	"kvstore/node"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// call sends a request to the gateway and decodes its JSON reply.
+func call(t *testing.T, method, url, body string) (int, map[string]any) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		req, _ := http.NewRequest(method, url, strings.NewReader(body))
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil && time.Now().Before(deadline) {
+			time.Sleep(50 * time.Millisecond) // the gateway is starting
+			continue
+		} else if err != nil {
+			t.Fatalf("%s %s failed: %v", method, url, err)
+		}
+		defer resp.Body.Close()
+		var reply map[string]any
+		if err := json.NewDecoder(resp.Body).Decode(&reply); err != nil {
+			t.Fatalf("%s %s: invalid JSON: %v", method, url, err)
+		}
+		return resp.StatusCode, reply
+	}
+}
+
+func TestHTTPGateway(t *testing.T) {
+	leader := node.NewNode(144, "localhost:50209", node.LEADER)
+	follower := node.NewNode(145, "localhost:50210", node.FOLLOWER)
+	if err := follower.Join("localhost:50209"); err != nil {
+		t.Fatalf("Expected join to succeed, got %v", err)
+	}
+	waitForMembers(t, 2, leader, follower)
+	epoch := max(leader.GetEpoch(), follower.GetEpoch()) + 1
+	for _, n := range []*node.Node{leader, follower} {
+		if err := n.SetLeader(144, "localhost:50209", epoch); err != nil {
+			t.Fatalf("SetLeader failed: %v", err)
+		}
+	}
+	follower.ServeGateway("localhost:50211")
+	url := "http://localhost:50211/v1/kv"
+
+	// Writes through the follower are forwarded to the leader
+	if code, _ := call(t, "PUT", url+"/app/a", `{"value":"1"}`); code != http.StatusOK {
+		t.Fatalf("Expected the put to succeed, got %d", code)
+	}
+	if value, _ := leader.HandleGet("app/a"); value != "1" {
+		t.Fatalf("Expected the leader to hold the write, got %q", value)
+	}
+	waitForValue(t, follower, "app/a", "1")
+	code, reply := call(t, "GET", url+"/app/a", "")
+	if meta, _ := reply["meta"].(map[string]any); code != http.StatusOK || reply["value"] != "1" || meta["version"] != "1" {
+		t.Errorf("Expected app/a at version 1, got %d %v", code, reply)
+	}
+	if code, reply := call(t, "GET", url+"/missing", ""); code != http.StatusNotFound || reply["code"] != "NotFound" {
+		t.Errorf("Expected a missing key to be a 404, got %d %v", code, reply)
+	}
+
+	// Failed conditions return the key's current state
+	code, reply = call(t, "PUT", url+"/app/a", `{"value":"2","if_absent":true}`)
+	if current, _ := reply["current"].(map[string]any); code != http.StatusPreconditionFailed || current["value"] != "1" {
+		t.Errorf("Expected the condition to fail with the current value, got %d %v", code, reply)
+	}
+	if code, _ := call(t, "DELETE", url+"/app/a?if_version=9", ""); code != http.StatusPreconditionFailed {
+		t.Errorf("Expected a delete at the wrong version to fail, got %d", code)
+	}
+
+	// Scans take query parameters
+	for _, key := range []string{"app/b", "app/c"} {
+		call(t, "PUT", url+"/"+key, `{"value":"x"}`)
+		waitForValue(t, follower, key, "x")
+	}
+	code, reply = call(t, "GET", url+"?prefix=app/&limit=2", "")
+	if kvs, _ := reply["kvs"].([]any); code != http.StatusOK || len(kvs) != 2 || reply["more"] != true {
+		t.Errorf("Expected a page of 2 keys with more to come, got %d %v", code, reply)
+	}
+
+	// Errors carry the gRPC code
+	for _, c := range []struct {
+		method, path, body string
+		status             int
+		code               string
+	}{
+		{"PUT", "/app/a", `{"value":`, http.StatusBadRequest, "InvalidArgument"},
+		{"PUT", "/app/a", `{"value":"x","stamp":{"revision":"9"}}`, http.StatusBadRequest, "InvalidArgument"},
+		{"GET", "/app/a?revision=1000000", "", http.StatusBadRequest, "OutOfRange"},
+		{"GET", "?limit=x", "", http.StatusBadRequest, "InvalidArgument"},
+	} {
+		if code, reply := call(t, c.method, url+c.path, c.body); code != c.status || reply["code"] != c.code {
+			t.Errorf("%s %s: expected %d %s, got %d %v", c.method, c.path, c.status, c.code, code, reply)
+		}
+	}
+
+	if code, _ := call(t, "DELETE", url+"/app/a", ""); code != http.StatusOK {
+		t.Errorf("Expected the delete to succeed, got %d", code)
+	}
+	if value, _ := leader.HandleGet("app/a"); value != "" {
+		t.Errorf("Expected app/a to be deleted, got %q", value)
+	}
+}
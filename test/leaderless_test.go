@@ -0,0 +1,101 @@
+package test
+
+import (
+	"kvstore/iface"
+	"kvstore/node"
+	"kvstore/storage"
+	"testing"
+	"time"
+)
+
+// waitForMembers waits until gossip has told every node about count members.
+func waitForMembers(t *testing.T, count int, nodes ...*node.Node) {
+	deadline := time.Now().Add(5 * time.Second)
+	for _, n := range nodes {
+		for len(n.Members()) < count {
+			if time.Now().After(deadline) {
+				t.Fatalf("Node %d knows %d members, expected %d", n.GetID(), len(n.Members()), count)
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+	}
+}
+
+func TestLeaderlessSiblings(t *testing.T) {
+	a := node.NewNode(110, "localhost:50170", node.FOLLOWER)
+	b := node.NewNode(111, "localhost:50171", node.FOLLOWER)
+	c := node.NewNode(112, "localhost:50172", node.FOLLOWER)
+	a.SetClusterMetadata(iface.ClusterMetadata{Version: 1, Replication: iface.LEADERLESS, Replicas: 3, ReadQuorum: 2, WriteQuorum: 2})
+	for _, n := range []*node.Node{b, c} {
+		if err := n.Join("localhost:50170"); err != nil {
+			t.Fatalf("Expected join to succeed, got %v", err)
+		}
+	}
+	waitForMembers(t, 3, a, b, c)
+	if !c.Leaderless() {
+		t.Fatalf("Expected the leaderless mode to reach every node")
+	}
+
+	clock, err := a.HandleVersionedPut("key", "v1", nil)
+	if err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	// Two clients update the same version concurrently through different nodes
+	if _, err := b.HandleVersionedPut("key", "from-b", clock); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if _, err := c.HandleVersionedPut("key", "from-c", clock); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	siblings, context, err := a.HandleVersionedGet("key")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if len(siblings) != 2 {
+		t.Fatalf("Expected 2 siblings, got %v", siblings)
+	}
+
+	if _, err := a.HandleVersionedPut("key", "resolved", context); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	siblings, _, err = c.HandleVersionedGet("key")
+	if err != nil || len(siblings) != 1 || siblings[0].Value != "resolved" {
+		t.Errorf("Expected the resolved value, got %v (%v)", siblings, err)
+	}
+
+	if _, err := b.HandleVersionedDelete("key", storage.VectorClock{}.Merge(siblings[0].Clock)); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if siblings, _, _ = a.HandleVersionedGet("key"); len(siblings) != 0 {
+		t.Errorf("Expected key to be deleted, got %v", siblings)
+	}
+}
+
+func TestFetchHintedSiblings(t *testing.T) {
+	n := node.NewNode(153, "localhost:50219", node.FOLLOWER)
+	stored := []storage.Sibling{{Value: "v1", Clock: storage.VectorClock{}.Increment(153)}}
+	if err := n.HandleStore("key", stored, 0); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+
+	// A newer version accepted on behalf of an unreachable replica replaces
+	// it in fetches before it is handed off
+	hinted := []storage.Sibling{{Value: "v2", Clock: stored[0].Clock.Increment(154)}}
+	if err := n.HandleStore("key", hinted, 154); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	if siblings := n.HandleFetch("key"); len(siblings) != 1 || siblings[0].Value != "v2" {
+		t.Errorf("Expected the hinted version, got %v", siblings)
+	}
+	if siblings := n.HandleFetch("hinted-only"); len(siblings) != 0 {
+		t.Errorf("Expected no versions, got %v", siblings)
+	}
+	if err := n.HandleStore("hinted-only", hinted, 154); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	if siblings := n.HandleFetch("hinted-only"); len(siblings) != 1 || siblings[0].Value != "v2" {
+		t.Errorf("Expected the hinted version, got %v", siblings)
+	}
+}
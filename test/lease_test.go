@@ -0,0 +1,112 @@
+package test
+
+import (
+	"errors"
+	"kvstore/iface"
+	"kvstore/node"
+	"kvstore/storage"
+	"testing"
+	"time"
+)
+
+func TestLeases(t *testing.T) {
+	leader := node.NewNode(127, "localhost:50188", node.LEADER)
+	follower := node.NewNode(128, "localhost:50189", node.FOLLOWER)
+	if err := follower.Join("localhost:50188"); err != nil {
+		t.Fatalf("Expected join to succeed, got %v", err)
+	}
+	waitForMembers(t, 2, leader, follower)
+	epoch := max(leader.GetEpoch(), follower.GetEpoch()) + 1
+	for _, n := range []*node.Node{leader, follower} {
+		if err := n.SetLeader(127, "localhost:50188", epoch); err != nil {
+			t.Fatalf("SetLeader failed: %v", err)
+		}
+	}
+	meta := iface.RequestMeta{}
+
+	id, err := follower.HandleLeaseGrant(meta, 0, 300*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Grant failed: %v", err)
+	}
+	for _, key := range []string{"svc/a", "svc/b"} {
+		if err := leader.HandlePutWithLease(meta, key, "up", id); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+	if err := leader.HandlePutWithLease(meta, "svc/c", "up", id+1); !errors.Is(err, storage.ErrLeaseNotFound) {
+		t.Errorf("Expected an unknown lease to be refused, got %v", err)
+	}
+
+	// Keep-alives through the follower hold the keys past the TTL
+	for i := 0; i < 6; i++ {
+		if _, err := follower.HandleLeaseKeepAlive(id); err != nil {
+			t.Fatalf("KeepAlive failed: %v", err)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if value, _ := follower.HandleGet("svc/a"); value != "up" {
+		t.Fatalf("Expected the key to be kept alive, got %q", value)
+	}
+	info, err := follower.HandleLeaseTimeToLive(id)
+	if err != nil || len(info.Keys) != 2 || info.Remaining <= 0 {
+		t.Errorf("Expected the lease with both keys, got %+v (%v)", info, err)
+	}
+
+	// Without keep-alives the lease lapses and takes its keys along
+	waitForValue(t, follower, "svc/a", "")
+	waitForValue(t, follower, "svc/b", "")
+	if _, err := leader.HandleLeaseKeepAlive(id); !errors.Is(err, storage.ErrLeaseNotFound) {
+		t.Errorf("Expected the lease to be gone, got %v", err)
+	}
+
+	id, _ = leader.HandleLeaseGrant(meta, 0, time.Minute)
+	leader.HandlePutWithLease(meta, "svc/d", "up", id)
+	waitForValue(t, follower, "svc/d", "up")
+	if err := leader.HandleLeaseRevoke(meta, id); err != nil {
+		t.Fatalf("Revoke failed: %v", err)
+	}
+	waitForValue(t, follower, "svc/d", "")
+
+	// A new leader keeps the replicated lease with a full TTL
+	id, _ = leader.HandleLeaseGrant(meta, 0, time.Minute)
+	leader.HandlePutWithLease(meta, "svc/e", "up", id)
+	waitForValue(t, follower, "svc/e", "up")
+	for _, n := range []*node.Node{leader, follower} {
+		if err := n.SetLeader(128, "localhost:50189", epoch+1); err != nil {
+			t.Fatalf("SetLeader failed: %v", err)
+		}
+	}
+	info, err = follower.HandleLeaseTimeToLive(id)
+	if err != nil || info.Remaining < 55*time.Second || len(info.Keys) != 1 || info.Keys[0] != "svc/e" {
+		t.Errorf("Expected the new leader to hold the lease, got %+v (%v)", info, err)
+	}
+}
+
+func TestReplicatedLeaseRevoke(t *testing.T) {
+	s := storage.NewMemoryStorage()
+	s.Put("kept", "1") // revision 1
+
+	// The leader's revoke at revision 4 arrives ahead of the grant and of one
+	// of the writes attaching keys to the lease
+	s.ApplyBatch([]storage.BatchOp{{Key: "a", Value: "up", Lease: 7}}, storage.Stamp{Revision: 2})
+	ops := []storage.BatchOp{{Key: "a", Delete: true}, {Key: "b", Delete: true}}
+	if _, _, err := s.RevokeLease(7, ops, storage.Stamp{Revision: 4}); err != nil {
+		t.Fatalf("Revoke failed: %v", err)
+	}
+	s.ApplyBatch([]storage.BatchOp{{Key: "b", Value: "up", Lease: 7}}, storage.Stamp{Revision: 3})
+	if err := s.GrantLease(7, time.Minute); err == nil {
+		t.Errorf("Expected a late grant of a revoked lease to be refused")
+	}
+
+	for _, key := range []string{"a", "b"} {
+		if value, _ := s.Get(key); value != "" {
+			t.Errorf("Expected %s to be deleted with the lease, got %q", key, value)
+		}
+	}
+	if value, _ := s.Get("kept"); value != "1" {
+		t.Errorf("Expected only the leader's keys to be deleted, got %q", value)
+	}
+	if _, ok := s.Lease(7); ok || len(s.Leases()) != 0 {
+		t.Errorf("Expected no lease left behind, got %v", s.Leases())
+	}
+}
@@ -0,0 +1,158 @@
+package test
+
+import (
+	"context"
+	"kvstore/client"
+	"kvstore/iface"
+	"kvstore/node"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// newSession opens a session, retrying while the node's server starts.
+func newSession(t *testing.T, conn grpc.ClientConnInterface, ttl time.Duration) *client.Session {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		s, err := client.NewSession(conn, ttl)
+		if err == nil {
+			return s
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("NewSession failed: %v", err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestLock(t *testing.T) {
+	n := node.NewNode(129, "localhost:50190", node.LEADER)
+	if err := n.SetLeader(129, "localhost:50190", n.GetEpoch()+1); err != nil {
+		t.Fatalf("SetLeader failed: %v", err)
+	}
+	conn, err := grpc.NewClient("localhost:50190", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer conn.Close()
+
+	var mutexes []*client.Mutex
+	var sessions []*client.Session
+	for i := 0; i < 3; i++ {
+		s := newSession(t, conn, time.Minute)
+		sessions = append(sessions, s)
+		mutexes = append(mutexes, client.NewMutex(conn, s, "locks/job"))
+	}
+
+	ctx := context.Background()
+	if err := mutexes[0].Lock(ctx); err != nil {
+		t.Fatalf("Lock failed: %v", err)
+	}
+
+	// The others queue up in order
+	acquired := make(chan int, 2)
+	for _, i := range []int{1, 2} {
+		go func(i int) {
+			if err := mutexes[i].Lock(ctx); err == nil {
+				acquired <- i
+			}
+		}(i)
+		time.Sleep(100 * time.Millisecond)
+	}
+	select {
+	case i := <-acquired:
+		t.Fatalf("Waiter %d got a held lock", i)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	if err := mutexes[0].Unlock(ctx); err != nil {
+		t.Fatalf("Unlock failed: %v", err)
+	}
+	if i := <-acquired; i != 1 || mutexes[1].Token() <= mutexes[0].Token() {
+		t.Fatalf("Expected the first waiter with a higher token, got %d with %d", i, mutexes[1].Token())
+	}
+
+	// The holder's session ending releases the lock
+	sessions[1].Close()
+	select {
+	case i := <-acquired:
+		if i != 2 || mutexes[2].Token() <= mutexes[1].Token() {
+			t.Errorf("Expected the last waiter with a higher token, got %d with %d", i, mutexes[2].Token())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the lock to pass on when its holder's session ended")
+	}
+
+	// Asking with a short deadline while it is held gives up
+	short, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	if err := mutexes[0].Lock(short); err == nil {
+		t.Errorf("Expected the lock to be unavailable")
+	}
+}
+
+func TestLockAcrossHashGroups(t *testing.T) {
+	a := node.NewNode(146, "localhost:50212", node.LEADER)
+	b := node.NewNode(147, "localhost:50213", node.LEADER)
+	b.SetShard(1)
+	md := iface.ClusterMetadata{
+		Version: 1,
+		Shards: []iface.ShardGroup{
+			{ID: 0, LeaderID: 146, LeaderAddr: "localhost:50212"},
+			{ID: 1, LeaderID: 147, LeaderAddr: "localhost:50213"},
+		},
+	}
+	for _, n := range []*node.Node{a, b} {
+		if err := n.SetLeader(n.GetID(), "", n.GetEpoch()+1); err != nil {
+			t.Fatalf("SetLeader failed: %v", err)
+		}
+		n.SetClusterMetadata(md)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	// The leases belong to the group owning the lock's name
+	owner := a
+	if _, remote := a.KeyOwner("jobs"); remote {
+		owner = b
+	}
+	meta := iface.RequestMeta{}
+	first, _ := owner.HandleLeaseGrant(meta, 0, time.Minute)
+	second, _ := owner.HandleLeaseGrant(meta, 0, time.Minute)
+	ctx := context.Background()
+
+	key, token, err := a.HandleLock(ctx, "jobs", first)
+	if err != nil {
+		t.Fatalf("Lock failed: %v", err)
+	}
+	acquired := make(chan int64, 2)
+	go func() {
+		if _, token, err := b.HandleLock(ctx, "jobs", second); err == nil {
+			acquired <- token
+		}
+	}()
+	select {
+	case <-acquired:
+		t.Fatalf("Expected the second session to wait")
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	// Asking again and giving up leaves the session's place in the queue
+	short, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	if _, _, err := a.HandleLock(short, "jobs", second); err == nil {
+		t.Fatalf("Expected the lock to be unavailable")
+	}
+	if err := b.HandleUnlock(meta, key); err != nil {
+		t.Fatalf("Unlock failed: %v", err)
+	}
+	select {
+	case next := <-acquired:
+		if next <= token {
+			t.Errorf("Expected a higher token than %d, got %d", token, next)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the waiting session to get the lock")
+	}
+}
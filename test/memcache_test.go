@@ -0,0 +1,114 @@
+package test
+
+import (
+	"bufio"
+	"kvstore/node"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// memcacheClient talks to a memcached listener over a plain TCP connection.
+type memcacheClient struct {
+	t    *testing.T
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func dialMemcache(t *testing.T, addr string) *memcacheClient {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		conn, err := net.Dial("tcp", addr)
+		if err == nil {
+			t.Cleanup(func() { conn.Close() })
+			return &memcacheClient{t: t, conn: conn, r: bufio.NewReader(conn)}
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Dial failed: %v", err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// expect sends the lines of a command and checks the lines of its reply.
+func (c *memcacheClient) expect(lines []string, reply ...string) {
+	c.t.Helper()
+	if _, err := c.conn.Write([]byte(strings.Join(lines, "\r\n") + "\r\n")); err != nil {
+		c.t.Fatalf("Write failed: %v", err)
+	}
+	c.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for _, want := range reply {
+		line, err := c.r.ReadString('\n')
+		if err != nil {
+			c.t.Fatalf("%q: read failed: %v", lines[0], err)
+		}
+		if got := strings.TrimSuffix(line, "\r\n"); got != want && !(strings.HasSuffix(want, "*") && strings.HasPrefix(got, want[:len(want)-1])) {
+			c.t.Errorf("%q: expected %q, got %q", lines[0], want, got)
+		}
+	}
+}
+
+func command(lines ...string) []string { return lines }
+
+func TestMemcache(t *testing.T) {
+	leader := node.NewNode(142, "localhost:50206", node.LEADER)
+	follower := node.NewNode(143, "localhost:50207", node.FOLLOWER)
+	if err := follower.Join("localhost:50206"); err != nil {
+		t.Fatalf("Expected join to succeed, got %v", err)
+	}
+	waitForMembers(t, 2, leader, follower)
+	epoch := max(leader.GetEpoch(), follower.GetEpoch()) + 1
+	for _, n := range []*node.Node{leader, follower} {
+		if err := n.SetLeader(142, "localhost:50206", epoch); err != nil {
+			t.Fatalf("SetLeader failed: %v", err)
+		}
+	}
+	follower.ServeMemcache("localhost:50208")
+	c := dialMemcache(t, "localhost:50208")
+
+	// Writes through the follower are forwarded to the leader
+	c.expect(command("set a 7 0 1", "1"), "STORED")
+	if value, _ := leader.HandleGet("a"); value != "1" {
+		t.Fatalf("Expected the leader to hold the write, got %q", value)
+	}
+	waitForValue(t, follower, "a", "1")
+	c.expect(command("get a missing"), "VALUE a 0 1", "1", "END")
+	c.expect(command("gets a"), "VALUE a 0 1 1", "1", "END")
+
+	c.expect(command("add a 0 0 1", "2"), "NOT_STORED")
+	c.expect(command("add b 0 0 2", "xx"), "STORED")
+	c.expect(command("replace c 0 0 1", "3"), "NOT_STORED")
+	c.expect(command("cas a 0 0 1 9", "2"), "EXISTS")
+	c.expect(command("cas c 0 0 1 1", "2"), "NOT_FOUND")
+	c.expect(command("cas a 0 0 1 1", "2"), "STORED")
+
+	c.expect(command("incr a 5"), "7")
+	c.expect(command("decr a 10"), "0")
+	c.expect(command("set max 0 0 20", "18446744073709551615"), "STORED")
+	c.expect(command("incr max 2"), "1")
+	c.expect(command("decr max 18446744073709551615"), "0")
+	c.expect(command("incr missing 1"), "NOT_FOUND")
+	c.expect(command("incr b 1"), "CLIENT_ERROR *")
+	c.expect(command("delete b"), "DELETED")
+	c.expect(command("delete b"), "NOT_FOUND")
+
+	// Expiries are leases; one in the past deletes the item
+	c.expect(command("set short 0 1 1", "v"), "STORED")
+	c.expect(command("touch a 1"), "TOUCHED")
+	c.expect(command("touch missing 1"), "NOT_FOUND")
+	c.expect(command("set gone 0 -1 1", "v"), "STORED")
+	waitForValue(t, follower, "short", "v")
+	waitForValue(t, follower, "short", "")
+	waitForValue(t, follower, "a", "")
+	if value, _ := leader.HandleGet("gone"); value != "" {
+		t.Errorf("Expected an expired item to be dropped, got %q", value)
+	}
+
+	// noreply leaves the next reply to the next command
+	c.expect(command("set quiet 0 0 1 noreply", "q"))
+	waitForValue(t, follower, "quiet", "q")
+	c.expect(command("get quiet"), "VALUE quiet 0 1", "q", "END")
+	c.expect(command("flush_all"), "ERROR")
+	c.expect(command("set "+strings.Repeat("k", 251)+" 0 0 1", "v"), "CLIENT_ERROR *")
+}
@@ -0,0 +1,74 @@
+package test
+
+import (
+	"kvstore/iface"
+	"kvstore/node"
+	"kvstore/storage"
+	"testing"
+	"time"
+)
+
+func TestStorageKeyMeta(t *testing.T) {
+	s := storage.NewMemoryStorage()
+	s.Put("a", "1")
+	s.Put("b", "1")
+	before := time.Now()
+	s.ApplyBatch([]storage.BatchOp{{Key: "a", Value: "2"}}, storage.Stamp{Writer: 7})
+
+	value, meta, err := s.GetWithMeta("a")
+	if err != nil || value != "2" {
+		t.Fatalf("Expected the value, got %q (%v)", value, err)
+	}
+	if meta.CreateRevision != 1 || meta.ModRevision != 3 || meta.Version != 2 || meta.Writer != 7 {
+		t.Errorf("Unexpected metadata %+v", meta)
+	}
+	if meta.ModTime.Before(before) {
+		t.Errorf("Expected the time of the last write, got %v", meta.ModTime)
+	}
+
+	s.Delete("a")
+	if _, meta, _ := s.GetWithMeta("a"); meta != (storage.KeyMeta{}) {
+		t.Errorf("Expected no metadata for a deleted key, got %+v", meta)
+	}
+	if s.Revision() != 4 {
+		t.Errorf("Expected the delete to take a revision, got %d", s.Revision())
+	}
+
+	// A replica applies the leader's stamp as it is
+	stamp := storage.Stamp{Revision: 10, Time: time.Unix(100, 0), Writer: 2}
+	s.ApplyBatch([]storage.BatchOp{{Key: "c", Value: "1"}}, stamp)
+	if _, meta, _ := s.GetWithMeta("c"); meta.ModRevision != 10 || !meta.ModTime.Equal(stamp.Time) || meta.Writer != 2 {
+		t.Errorf("Expected the given stamp, got %+v", meta)
+	}
+}
+
+func TestReplicatedKeyMeta(t *testing.T) {
+	leader := node.NewNode(122, "localhost:50183", node.LEADER)
+	follower := node.NewNode(123, "localhost:50184", node.FOLLOWER)
+	if err := follower.Join("localhost:50183"); err != nil {
+		t.Fatalf("Expected join to succeed, got %v", err)
+	}
+	waitForMembers(t, 2, leader, follower)
+	epoch := max(leader.GetEpoch(), follower.GetEpoch()) + 1
+	for _, n := range []*node.Node{leader, follower} {
+		if err := n.SetLeader(122, "localhost:50183", epoch); err != nil {
+			t.Fatalf("SetLeader failed: %v", err)
+		}
+	}
+
+	leader.HandlePut(iface.RequestMeta{}, "a", "1")
+	if err := follower.HandlePut(iface.RequestMeta{}, "a", "2"); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	waitForValue(t, follower, "a", "2")
+
+	_, want, _ := leader.HandleGetMeta("a")
+	if want.Version != 2 || want.Writer != 123 {
+		t.Errorf("Expected the forwarding follower as the writer, got %+v", want)
+	}
+	_, got, err := follower.HandleGetMeta("a")
+	if err != nil || got.ModRevision != want.ModRevision || got.CreateRevision != want.CreateRevision ||
+		got.Version != want.Version || got.Writer != want.Writer || !got.ModTime.Equal(want.ModTime) {
+		t.Errorf("Expected the follower to hold the leader's metadata %+v, got %+v (%v)", want, got, err)
+	}
+}
@@ -0,0 +1,155 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"kvstore/iface"
+	"kvstore/node"
+	"kvstore/storage"
+	"testing"
+	"time"
+)
+
+func TestStorageHistory(t *testing.T) {
+	s := storage.NewMemoryStorage()
+	s.Put("a", "1") // revision 1
+	s.Put("b", "1") // 2
+	s.Put("a", "2") // 3
+	s.Delete("b")   // 4
+	s.Put("c", "1") // 5
+
+	if value, meta, _ := s.GetAt("a", 2); value != "1" || meta.Version != 1 {
+		t.Errorf("Expected the first version of a at revision 2, got %q %+v", value, meta)
+	}
+	if value, _, _ := s.GetAt("b", 3); value != "1" {
+		t.Errorf("Expected b before its deletion, got %q", value)
+	}
+	if value, meta, _ := s.GetAt("b", 4); value != "" || meta != (storage.KeyMeta{}) {
+		t.Errorf("Expected b to be gone at revision 4, got %q %+v", value, meta)
+	}
+	if _, _, err := s.GetAt("a", 6); !errors.Is(err, storage.ErrFutureRevision) {
+		t.Errorf("Expected a future revision to be refused, got %v", err)
+	}
+
+	entries, read, more, err := s.ScanAt("a", "", 0, 3)
+	if err != nil || read != 3 || more || len(entries) != 2 || entries[0].Value != "2" || entries[1].Key != "b" {
+		t.Fatalf("Expected a and b at revision 3, got %+v at %d (%v)", entries, read, err)
+	}
+	entries, read, more, _ = s.ScanAt("a", "", 1, 0)
+	if read != 5 || !more || len(entries) != 1 || entries[0].Key != "a" {
+		t.Errorf("Expected the first page of the latest revision, got %+v at %d", entries, read)
+	}
+	// Later pages keep reading the same snapshot
+	s.Put("b", "2")
+	entries, _, _, _ = s.ScanAt("a\x00", "", 0, read)
+	if len(entries) != 1 || entries[0].Key != "c" {
+		t.Errorf("Expected only c at revision %d, got %+v", read, entries)
+	}
+
+	if err := s.Compact(4); err != nil {
+		t.Fatalf("Compact failed: %v", err)
+	}
+	if _, _, err := s.GetAt("a", 3); !errors.Is(err, storage.ErrCompacted) {
+		t.Errorf("Expected a compacted revision to be refused, got %v", err)
+	}
+	if value, _, err := s.GetAt("a", 4); err != nil || value != "2" {
+		t.Errorf("Expected the version visible at the compaction revision, got %q (%v)", value, err)
+	}
+	if err := s.Compact(4); !errors.Is(err, storage.ErrCompacted) {
+		t.Errorf("Expected compacting twice to be refused, got %v", err)
+	}
+}
+
+func TestOutOfOrderReplication(t *testing.T) {
+	s := storage.NewMemoryStorage()
+	s.ApplyBatch([]storage.BatchOp{{Key: "a", Value: "3"}}, storage.Stamp{Revision: 3})
+	s.ApplyBatch([]storage.BatchOp{{Key: "a", Value: "2"}}, storage.Stamp{Revision: 2})
+	if value, meta, _ := s.GetWithMeta("a"); value != "3" || meta.ModRevision != 3 {
+		t.Errorf("Expected an older write to leave the key alone, got %q %+v", value, meta)
+	}
+	if value, _, _ := s.GetAt("a", 2); value != "2" {
+		t.Errorf("Expected the older write in the history, got %q", value)
+	}
+
+	// A delete at revision 5 is not undone by a write stamped before it
+	s.ApplyBatch([]storage.BatchOp{{Key: "a", Delete: true}}, storage.Stamp{Revision: 5})
+	s.ApplyBatch([]storage.BatchOp{{Key: "a", Value: "4"}}, storage.Stamp{Revision: 4})
+	if value, _, _ := s.GetWithMeta("a"); value != "" {
+		t.Errorf("Expected the key to stay deleted, got %q", value)
+	}
+	if value, _, _ := s.GetAt("a", 4); value != "4" {
+		t.Errorf("Expected revision 4 in the history, got %q", value)
+	}
+
+	// And an older delete does not remove a newer write
+	s.ApplyBatch([]storage.BatchOp{{Key: "b", Value: "7"}}, storage.Stamp{Revision: 7})
+	s.ApplyBatch([]storage.BatchOp{{Key: "b", Delete: true}}, storage.Stamp{Revision: 6})
+	if value, _, _ := s.GetWithMeta("b"); value != "7" {
+		t.Errorf("Expected an older delete to leave the key alone, got %q", value)
+	}
+}
+
+func TestScanAndCompact(t *testing.T) {
+	n := node.NewNode(124, "localhost:0", node.LEADER)
+	meta := iface.RequestMeta{}
+	for _, key := range []string{"app/a", "app/b", "other"} {
+		n.HandlePut(meta, key, "1")
+	}
+	n.HandlePut(meta, "app/a", "2")
+
+	entries, revision, _, err := n.HandleScan("app/", storage.PrefixEnd("app/"), 0, 0)
+	if err != nil || revision != 4 || len(entries) != 2 || entries[0].Value != "2" {
+		t.Fatalf("Expected both app keys at revision 4, got %+v at %d (%v)", entries, revision, err)
+	}
+	if value, _, _ := n.HandleGetAt("app/a", 3); value != "1" {
+		t.Errorf("Expected the old value at revision 3, got %q", value)
+	}
+
+	if err := n.HandleCompact(meta, 4); err != nil {
+		t.Fatalf("Compact failed: %v", err)
+	}
+	if _, _, err := n.HandleGetAt("app/a", 3); !errors.Is(err, storage.ErrCompacted) {
+		t.Errorf("Expected revision 3 to be compacted, got %v", err)
+	}
+}
+
+func TestDeleteRangeAcrossGroups(t *testing.T) {
+	a := node.NewNode(148, "localhost:50214", node.LEADER)
+	b := node.NewNode(149, "localhost:50215", node.LEADER)
+	b.SetShard(1)
+	md := iface.ClusterMetadata{
+		Version: 1,
+		Shards: []iface.ShardGroup{
+			{ID: 0, LeaderID: 148, LeaderAddr: "localhost:50214"},
+			{ID: 1, LeaderID: 149, LeaderAddr: "localhost:50215"},
+		},
+	}
+	for _, n := range []*node.Node{a, b} {
+		if err := n.SetLeader(n.GetID(), "", n.GetEpoch()+1); err != nil {
+			t.Fatalf("SetLeader failed: %v", err)
+		}
+		n.SetClusterMetadata(md)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	meta := iface.RequestMeta{}
+	for i := 0; i < 20; i++ {
+		if err := a.HandlePut(meta, fmt.Sprintf("app/%d", i), "v"); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+	if err := a.HandlePut(meta, "other", "v"); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if _, err := b.HandleCollection(meta, storage.CollectionOp{Cmd: storage.SADD, Key: "app/set", Members: []string{"x"}}); err != nil {
+		t.Fatalf("SADD failed: %v", err)
+	}
+
+	deleted, err := a.HandleDeleteRange(meta, "app/", storage.PrefixEnd("app/"), false)
+	if err != nil || deleted != 21 {
+		t.Fatalf("Expected the 21 keys of both groups deleted, got %d (%v)", deleted, err)
+	}
+	if count, err := b.HandleCount(false); err != nil || count != 1 {
+		t.Errorf("Expected only the key outside the range left, got %d (%v)", count, err)
+	}
+}
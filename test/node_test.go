@@ -0,0 +1,179 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"kvstore/iface"
+	"kvstore/node"
+	"testing"
+	"time"
+)
+
+func TestEpochFencing(t *testing.T) {
+	n := node.NewNode(101, "localhost:0", node.FOLLOWER)
+
+	epoch := n.GetEpoch() + 1
+	if err := n.SetLeader(1, "localhost:0", epoch); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	err := n.HandlePut(iface.RequestMeta{RequesterID: 1, Epoch: epoch - 1}, "key", "stale")
+	if !errors.Is(err, iface.ErrStaleEpoch) {
+		t.Errorf("Expected stale epoch error, got %v", err)
+	}
+
+	err = n.HandlePut(iface.RequestMeta{RequesterID: 1, Epoch: epoch}, "key", "fresh")
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	value, _ := n.HandleGet("key")
+	if value != "fresh" {
+		t.Errorf("Expected 'fresh', got '%s'", value)
+	}
+
+	// A second node claiming the same epoch is fenced off
+	err = n.HandlePut(iface.RequestMeta{RequesterID: 2, Leader: 2, Epoch: epoch}, "key", "split")
+	if !errors.Is(err, iface.ErrStaleEpoch) {
+		t.Errorf("Expected a second leader at the same epoch to be refused, got %v", err)
+	}
+	if value, _ := n.HandleGet("key"); value != "fresh" {
+		t.Errorf("Expected 'fresh', got '%s'", value)
+	}
+
+	if err := n.SetLeader(1, "localhost:0", epoch-1); !errors.Is(err, iface.ErrStaleEpoch) {
+		t.Errorf("Expected older leader to be refused, got %v", err)
+	}
+}
+
+func TestIdempotentWrites(t *testing.T) {
+	n := node.NewNode(102, "localhost:0", node.LEADER)
+	if err := n.SetLeader(102, "localhost:0", n.GetEpoch()+1); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	first := iface.RequestMeta{ClientID: "client-1", Seq: 1}
+	if err := n.HandlePut(first, "counter", "1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := n.HandlePut(first, "counter", "2"); err != nil {
+		t.Fatalf("Expected retry to succeed, got %v", err)
+	}
+	value, _ := n.HandleGet("counter")
+	if value != "1" {
+		t.Errorf("Expected retry to be deduplicated, got '%s'", value)
+	}
+
+	if err := n.HandlePut(iface.RequestMeta{ClientID: "client-1", Seq: 2}, "counter", "2"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	value, _ = n.HandleGet("counter")
+	if value != "2" {
+		t.Errorf("Expected '2', got '%s'", value)
+	}
+
+	// Reordered requests are applied once each
+	if err := n.HandlePut(iface.RequestMeta{ClientID: "client-2", Seq: 2}, "second", "2"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := n.HandlePut(iface.RequestMeta{ClientID: "client-2", Seq: 1}, "first", fmt.Sprint(i+1)); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if value, _ := n.HandleGet("first"); value != "1" {
+		t.Errorf("Expected the late request to be applied once, got '%s'", value)
+	}
+
+	// A request older than the window is refused rather than dropped
+	if err := n.HandlePut(iface.RequestMeta{ClientID: "client-2", Seq: 1000}, "second", "3"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	err := n.HandlePut(iface.RequestMeta{ClientID: "client-2", Seq: 3}, "third", "3")
+	if !errors.Is(err, iface.ErrStaleSeq) {
+		t.Errorf("Expected a stale sequence error, got %v", err)
+	}
+}
+
+func TestGossipJoin(t *testing.T) {
+	seed := node.NewNode(103, "localhost:50163", node.LEADER)
+	joiner := node.NewNode(104, "localhost:50164", node.FOLLOWER)
+
+	if err := joiner.Join("localhost:50163"); err != nil {
+		t.Fatalf("Expected join to succeed, got %v", err)
+	}
+
+	for _, n := range []*node.Node{seed, joiner} {
+		if len(n.Members()) != 2 {
+			t.Errorf("Node %d: expected 2 members, got %d", n.GetID(), len(n.Members()))
+		}
+	}
+}
+
+func TestShardRouting(t *testing.T) {
+	a := node.NewNode(105, "localhost:50165", node.LEADER)
+	b := node.NewNode(106, "localhost:50166", node.LEADER)
+	b.SetShard(1)
+
+	md := iface.ClusterMetadata{
+		Version: 1,
+		Shards: []iface.ShardGroup{
+			{ID: 0, LeaderID: 105, LeaderAddr: "localhost:50165"},
+			{ID: 1, LeaderID: 106, LeaderAddr: "localhost:50166"},
+		},
+	}
+	for _, n := range []*node.Node{a, b} {
+		if err := n.SetLeader(n.GetID(), "", n.GetEpoch()+1); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		n.SetClusterMetadata(md)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	for i := 0; i < 20; i++ {
+		key := fmt.Sprintf("key%d", i)
+		if err := a.HandlePut(iface.RequestMeta{}, key, "v"); err != nil {
+			t.Fatalf("Put %s failed: %v", key, err)
+		}
+		value, err := b.HandleGet(key)
+		if err != nil || value != "v" {
+			t.Errorf("Expected %s to be readable through the other shard, got '%s' (%v)", key, value, err)
+		}
+	}
+}
+
+func TestRangeSplit(t *testing.T) {
+	n := node.NewNode(107, "localhost:0", node.LEADER)
+	if err := n.SetLeader(107, "", n.GetEpoch()+1); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	n.SetRangePolicy(node.RangePolicy{MaxKeys: 10, MaxQPS: 1e9, CheckInterval: 20 * time.Millisecond})
+	n.SetClusterMetadata(iface.ClusterMetadata{
+		Version:      1,
+		Partitioning: iface.RANGE,
+		Shards:       []iface.ShardGroup{{ID: 0, LeaderID: 107, Ranges: []iface.KeyRange{{Start: "", End: ""}}}},
+	})
+
+	for i := 0; i < 30; i++ {
+		if err := n.HandlePut(iface.RequestMeta{}, fmt.Sprintf("key%02d", i), "v"); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(n.ClusterMetadata().Shards[0].Ranges) < 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected range to split, got %v", n.ClusterMetadata().Shards[0].Ranges)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	ranges := n.ClusterMetadata().Shards[0].Ranges
+	if ranges[0].Start != "" || ranges[len(ranges)-1].End != "" {
+		t.Errorf("Expected split ranges to cover the keyspace, got %v", ranges)
+	}
+	for i := 1; i < len(ranges); i++ {
+		if ranges[i-1].End != ranges[i].Start {
+			t.Errorf("Expected contiguous ranges, got %v", ranges)
+		}
+	}
+}
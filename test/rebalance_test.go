@@ -0,0 +1,184 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"kvstore/iface"
+	"kvstore/node"
+	"kvstore/storage"
+	"testing"
+	"time"
+)
+
+func TestPlanRangeMoves(t *testing.T) {
+	md := iface.ClusterMetadata{
+		Partitioning: iface.RANGE,
+		Shards: []iface.ShardGroup{
+			{ID: 0, Ranges: []iface.KeyRange{{Start: "", End: "d"}, {Start: "d", End: "h"}, {Start: "h", End: "p"}, {Start: "p", End: ""}}},
+			{ID: 1},
+			{ID: 2},
+		},
+	}
+
+	moves := node.PlanRangeMoves(md, map[int]int{0: 1, 1: 1, 2: 1})
+	if len(moves) != 2 {
+		t.Fatalf("Expected 2 moves, got %v", moves)
+	}
+	targets := map[int]bool{}
+	for _, m := range moves {
+		if m.From != 0 || m.State != iface.MOVE_PENDING {
+			t.Errorf("Unexpected move %+v", m)
+		}
+		targets[m.To] = true
+	}
+	if !targets[1] || !targets[2] {
+		t.Errorf("Expected both empty groups to receive a range, got %v", moves)
+	}
+
+	if moves := node.PlanRangeMoves(md, map[int]int{0: 1, 1: 1}); len(moves) != 2 {
+		t.Errorf("Expected only live groups to be used, got %v", moves)
+	}
+
+	// Group 1 serves its ranges with three replicas, the others with one
+	moves = node.PlanRangeMoves(md, map[int]int{0: 1, 1: 3, 2: 1})
+	received := map[int]int{}
+	for _, m := range moves {
+		received[m.To]++
+	}
+	if len(moves) != 3 || received[1] != 2 || received[2] != 1 {
+		t.Errorf("Expected ranges to follow the replicas, got %v", moves)
+	}
+}
+
+func TestRebalanceMovesRange(t *testing.T) {
+	a := node.NewNode(108, "localhost:50168", node.LEADER)
+	b := node.NewNode(109, "localhost:50169", node.LEADER)
+	b.SetShard(1)
+	if err := b.Join("localhost:50168"); err != nil {
+		t.Fatalf("Expected join to succeed, got %v", err)
+	}
+
+	md := iface.ClusterMetadata{
+		Version:      1,
+		Partitioning: iface.RANGE,
+		Shards: []iface.ShardGroup{
+			{ID: 0, LeaderID: 108, LeaderAddr: "localhost:50168", Ranges: []iface.KeyRange{{Start: "", End: "key10"}, {Start: "key10", End: ""}}},
+			{ID: 1, LeaderID: 109, LeaderAddr: "localhost:50169"},
+		},
+	}
+	for _, n := range []*node.Node{a, b} {
+		if err := n.SetLeader(n.GetID(), "", n.GetEpoch()+1); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		n.SetClusterMetadata(md)
+	}
+
+	for i := 0; i < 20; i++ {
+		if err := a.HandlePut(iface.RequestMeta{}, fmt.Sprintf("key%02d", i), "v"); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+	_, first, _ := a.HandleGetMeta("key15")
+	a.HandlePut(iface.RequestMeta{}, "key15", "w")
+	_, before, _ := a.HandleGetMeta("key15")
+	push := storage.CollectionOp{Cmd: storage.RPUSH, Key: "key20", Members: []string{"x", "y"}}
+	if _, err := a.HandleCollection(iface.RequestMeta{}, push); err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+
+	if _, err := a.StartRebalance(0); err != nil {
+		t.Fatalf("Expected rebalance to start, got %v", err)
+	}
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		moves, running := a.RebalanceStatus()
+		if !running {
+			if len(moves) != 1 || moves[0].State != iface.MOVE_DONE || moves[0].Keys != 11 {
+				t.Fatalf("Expected one completed move of 11 keys, got %+v", moves)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Rebalance did not finish: %+v", moves)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	for _, g := range a.ClusterMetadata().Shards {
+		if len(g.Ranges) != 1 {
+			t.Errorf("Expected group %d to own one range, got %v", g.ID, g.Ranges)
+		}
+	}
+	for i := 0; i < 20; i++ {
+		key := fmt.Sprintf("key%02d", i)
+		if value, err := b.HandleGet(key); err != nil || (value != "v" && key != "key15") {
+			t.Errorf("Expected %s to survive the move, got '%s' (%v)", key, value, err)
+		}
+	}
+
+	// Moved keys keep their metadata, history and collections
+	if value, meta, err := b.HandleGetMeta("key15"); err != nil || value != "w" || meta.CreateRevision != before.CreateRevision ||
+		meta.Version != before.Version || !meta.ModTime.Equal(before.ModTime) {
+		t.Errorf("Expected key15 with its metadata %+v, got '%s' %+v (%v)", before, value, meta, err)
+	}
+	if value, _, err := b.HandleGetAt("key15", first.ModRevision); err != nil || value != "v" {
+		t.Errorf("Expected the history of key15 to move, got '%s' (%v)", value, err)
+	}
+	res, err := b.HandleCollection(iface.RequestMeta{}, storage.CollectionOp{Cmd: storage.LRANGE, Key: "key20", Start: 0, Stop: -1})
+	if err != nil || len(res.Elements) != 2 {
+		t.Errorf("Expected the list key20 to move, got %v (%v)", res.Elements, err)
+	}
+	if value, _ := a.HandleGet("key20"); value != "" {
+		t.Errorf("Expected no value for the list key, got '%s'", value)
+	}
+
+	// Keys are not placed by range under hash partitioning
+	md = a.ClusterMetadata()
+	md.Version++
+	md.Partitioning = iface.HASH
+	a.SetClusterMetadata(md)
+	if _, err := a.PlanRebalance(); !errors.Is(err, iface.ErrUnsupported) {
+		t.Errorf("Expected rebalancing to be refused with hash partitioning, got %v", err)
+	}
+	if _, _, err := a.HandleMoveRange(iface.KeyRange{Start: "key10"}, 1, "localhost:50169", 0); !errors.Is(err, iface.ErrUnsupported) {
+		t.Errorf("Expected a range move to be refused with hash partitioning, got %v", err)
+	}
+}
+
+func TestSeedNewReplica(t *testing.T) {
+	leader := node.NewNode(151, "localhost:50217", node.LEADER)
+	if err := leader.SetLeader(151, "localhost:50217", leader.GetEpoch()+1); err != nil {
+		t.Fatalf("SetLeader failed: %v", err)
+	}
+	meta := iface.RequestMeta{}
+	for i := 0; i < 150; i++ {
+		if err := leader.HandlePut(meta, fmt.Sprintf("key%03d", i), "v"); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+	lease, _ := leader.HandleLeaseGrant(meta, 0, time.Minute)
+	if err := leader.HandlePutWithLease(meta, "leased", "l", lease); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if _, err := leader.HandleCollection(meta, storage.CollectionOp{Cmd: storage.SADD, Key: "set", Members: []string{"x", "y"}}); err != nil {
+		t.Fatalf("SADD failed: %v", err)
+	}
+
+	// A replica joining the group gets the keys written before it joined
+	replica := node.NewNode(152, "localhost:50218", node.FOLLOWER)
+	if err := replica.Join("localhost:50217"); err != nil {
+		t.Fatalf("Expected join to succeed, got %v", err)
+	}
+	waitForValue(t, replica, "key149", "v")
+	waitForValue(t, replica, "leased", "l")
+	waitForElements(t, replica, storage.CollectionOp{Cmd: storage.SMEMBERS, Key: "set"}, 2)
+	if count, err := replica.HandleCount(true); err != nil || count != 152 {
+		t.Errorf("Expected the replica to hold all 152 keys, got %d (%v)", count, err)
+	}
+
+	// The lease came along, so revoking it on the leader reaches the replica
+	if err := leader.HandleLeaseRevoke(meta, lease); err != nil {
+		t.Fatalf("LeaseRevoke failed: %v", err)
+	}
+	waitForValue(t, replica, "leased", "")
+}
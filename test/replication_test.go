@@ -0,0 +1,151 @@
+package test
+
+import (
+	"errors"
+	"kvstore/iface"
+	"kvstore/node"
+	"kvstore/storage"
+	"os"
+	"testing"
+	"time"
+)
+
+// waitForValue polls n until key holds want.
+func waitForValue(t *testing.T, n *node.Node, key, want string) {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		value, _ := n.HandleGet(key)
+		if value == want {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %s=%q on node %d, got %q", key, want, n.GetID(), value)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// waitForElements waits until the collection read by op holds want elements on n.
+func waitForElements(t *testing.T, n *node.Node, op storage.CollectionOp, want int) {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		res, _ := n.HandleCollection(iface.RequestMeta{}, op)
+		if len(res.Elements) == want {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %d elements in %s on node %d, got %v", want, op.Key, n.GetID(), res.Elements)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestCrossClusterReplication(t *testing.T) {
+	os.RemoveAll("data/node-118") // no link left over from an earlier run
+	primary := node.NewNode(117, "localhost:50177", node.LEADER)
+	standby := node.NewNode(118, "localhost:50178", node.LEADER)
+
+	for key, value := range map[string]string{"app/a": "1", "other": "x"} {
+		if err := primary.HandlePut(iface.RequestMeta{}, key, value); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+
+	push := storage.CollectionOp{Cmd: storage.RPUSH, Key: "app/list", Members: []string{"x", "y"}}
+	if _, err := primary.HandleCollection(iface.RequestMeta{}, push); err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+
+	if _, err := standby.StartReplication("localhost:50177", "app/"); err != nil {
+		t.Fatalf("StartReplication failed: %v", err)
+	}
+	waitForValue(t, standby, "app/a", "1")
+
+	// Collections come with the snapshot and their commands with the log
+	waitForElements(t, standby, storage.CollectionOp{Cmd: storage.LRANGE, Key: "app/list", Start: 0, Stop: -1}, 2)
+	add := storage.CollectionOp{Cmd: storage.SADD, Key: "app/set", Members: []string{"a", "b", "c"}}
+	if _, err := primary.HandleCollection(iface.RequestMeta{}, add); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	waitForElements(t, standby, storage.CollectionOp{Cmd: storage.SMEMBERS, Key: "app/set"}, 3)
+
+	// Later writes are tailed from the log, filtered by prefix
+	primary.HandlePut(iface.RequestMeta{}, "app/b", "2")
+	primary.HandlePut(iface.RequestMeta{}, "other", "y")
+	primary.HandleDelete(iface.RequestMeta{}, "app/a")
+	waitForValue(t, standby, "app/a", "")
+	waitForValue(t, standby, "app/b", "2")
+	if value, _ := standby.HandleGet("other"); value != "" {
+		t.Errorf("Expected keys outside the prefix to stay out, got %q", value)
+	}
+
+	// Leased writes replicate without their lease and go away with it
+	lease, err := primary.HandleLeaseGrant(iface.RequestMeta{}, 0, time.Minute)
+	if err != nil {
+		t.Fatalf("LeaseGrant failed: %v", err)
+	}
+	if err := primary.HandlePutWithLease(iface.RequestMeta{}, "app/put", "p", lease); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	txn := iface.Txn{Success: []storage.BatchOp{{Key: "app/txn", Value: "t", Lease: lease}}}
+	if ok, _, err := primary.HandleTxn(iface.RequestMeta{}, txn); !ok || err != nil {
+		t.Fatalf("Txn failed: %v", err)
+	}
+	waitForValue(t, standby, "app/put", "p")
+	waitForValue(t, standby, "app/txn", "t")
+	if err := primary.HandleLeaseRevoke(iface.RequestMeta{}, lease); err != nil {
+		t.Fatalf("LeaseRevoke failed: %v", err)
+	}
+	waitForValue(t, standby, "app/put", "")
+	waitForValue(t, standby, "app/txn", "")
+
+	err = standby.HandlePut(iface.RequestMeta{}, "app/c", "3")
+	if !errors.Is(err, iface.ErrReadOnly) {
+		t.Errorf("Expected the standby to refuse writes, got %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for st := standby.ReplicationStatus(); st.Applied != st.SourceIndex || st.Applied == 0; st = standby.ReplicationStatus() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the link to catch up, got %+v", st)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	st, err := standby.Promote()
+	if err != nil || st.Running || st.ReadOnly {
+		t.Fatalf("Expected a writable standby after promotion, got %+v (%v)", st, err)
+	}
+	if err := standby.HandlePut(iface.RequestMeta{}, "app/c", "3"); err != nil {
+		t.Errorf("Expected the promoted standby to accept writes, got %v", err)
+	}
+}
+
+func TestStartReplicationOnce(t *testing.T) {
+	os.RemoveAll("data/node-150")
+	standby := node.NewNode(150, "localhost:50216", node.LEADER)
+	defer os.RemoveAll("data/node-150")
+
+	// Of concurrent starts, one starts the link and the others find it running
+	errs := make(chan error, 8)
+	for i := 0; i < cap(errs); i++ {
+		go func() {
+			_, err := standby.StartReplication("localhost:1", "app/")
+			errs <- err
+		}()
+	}
+	started := 0
+	for i := 0; i < cap(errs); i++ {
+		if err := <-errs; err == nil {
+			started++
+		} else if !errors.Is(err, iface.ErrBusy) {
+			t.Errorf("Expected a busy link, got %v", err)
+		}
+	}
+	if started != 1 {
+		t.Errorf("Expected one link to start, got %d", started)
+	}
+	if _, err := standby.Promote(); err != nil {
+		t.Fatalf("Promote failed: %v", err)
+	}
+}
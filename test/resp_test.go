@@ -0,0 +1,275 @@
+package test
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"kvstore/iface"
+	"kvstore/node"
+	"kvstore/storage"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// respClient talks to a RESP listener over a plain TCP connection.
+type respClient struct {
+	t    *testing.T
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// respError is an error reply.
+type respError string
+
+func dialRESP(t *testing.T, addr string) *respClient {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		conn, err := net.Dial("tcp", addr)
+		if err == nil {
+			t.Cleanup(func() { conn.Close() })
+			return &respClient{t: t, conn: conn, r: bufio.NewReader(conn)}
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Dial failed: %v", err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// do sends a command and returns its reply: a string, an int64, nil, a
+// respError or a slice of replies.
+func (c *respClient) do(args ...string) any {
+	c.send(args...)
+	return c.reply()
+}
+
+func (c *respClient) send(args ...string) {
+	cmd := fmt.Sprintf("*%d\r\n", len(args))
+	for _, a := range args {
+		cmd += fmt.Sprintf("$%d\r\n%s\r\n", len(a), a)
+	}
+	if _, err := c.conn.Write([]byte(cmd)); err != nil {
+		c.t.Fatalf("Write failed: %v", err)
+	}
+}
+
+func (c *respClient) line() string {
+	c.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		c.t.Fatalf("Read failed: %v", err)
+	}
+	return strings.TrimSuffix(line, "\r\n")
+}
+
+func (c *respClient) reply() any {
+	line := c.line()
+	switch line[0] {
+	case '+':
+		return line[1:]
+	case '-':
+		return respError(line[1:])
+	case ':':
+		n, _ := strconv.ParseInt(line[1:], 10, 64)
+		return n
+	case '_':
+		return nil
+	case '$':
+		size, _ := strconv.Atoi(line[1:])
+		if size < 0 {
+			return nil
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(c.r, buf); err != nil {
+			c.t.Fatalf("Read failed: %v", err)
+		}
+		return string(buf[:size])
+	case '*', '%':
+		n, _ := strconv.Atoi(line[1:])
+		if line[0] == '%' {
+			n *= 2
+		}
+		values := []any{}
+		for i := 0; i < n; i++ {
+			values = append(values, c.reply())
+		}
+		return values
+	}
+	c.t.Fatalf("Unexpected reply %q", line)
+	return nil
+}
+
+func (c *respClient) expect(want any, args ...string) {
+	c.t.Helper()
+	if got := c.do(args...); !reflect.DeepEqual(got, want) {
+		c.t.Errorf("%v: expected %#v, got %#v", args, want, got)
+	}
+}
+
+func TestRESP(t *testing.T) {
+	leader := node.NewNode(138, "localhost:50199", node.LEADER)
+	follower := node.NewNode(139, "localhost:50200", node.FOLLOWER)
+	if err := follower.Join("localhost:50199"); err != nil {
+		t.Fatalf("Expected join to succeed, got %v", err)
+	}
+	waitForMembers(t, 2, leader, follower)
+	epoch := max(leader.GetEpoch(), follower.GetEpoch()) + 1
+	for _, n := range []*node.Node{leader, follower} {
+		if err := n.SetLeader(138, "localhost:50199", epoch); err != nil {
+			t.Fatalf("SetLeader failed: %v", err)
+		}
+	}
+	follower.ServeRESP("localhost:50201")
+	c := dialRESP(t, "localhost:50201")
+
+	// Writes through the follower are forwarded to the leader
+	c.expect("OK", "SET", "a", "1")
+	if value, _ := leader.HandleGet("a"); value != "1" {
+		t.Fatalf("Expected the leader to hold the write, got %q", value)
+	}
+	waitForValue(t, follower, "a", "1")
+	c.expect("1", "GET", "a")
+	c.expect(nil, "GET", "missing")
+	c.expect(nil, "SET", "a", "2", "NX")
+	c.expect(nil, "SET", "b", "1", "XX")
+	c.expect("OK", "SET", "b", "1", "NX")
+	c.expect(int64(2), "INCR", "a")
+	c.expect(int64(12), "INCRBY", "a", "10")
+	c.expect(int64(11), "DECR", "a")
+	waitForValue(t, follower, "b", "1")
+	c.expect(int64(2), "EXISTS", "a", "b", "c")
+	c.expect(int64(1), "DEL", "a", "c")
+
+	// Expiries are leases
+	c.expect("OK", "SET", "short", "v", "PX", "200")
+	waitForValue(t, follower, "short", "v")
+	waitForValue(t, follower, "short", "")
+	c.expect(int64(1), "EXPIRE", "b", "1")
+	c.expect(int64(0), "EXPIRE", "missing", "1")
+	waitForValue(t, follower, "b", "")
+
+	// Listings read the follower's copy
+	for i := 1; i <= 5; i++ {
+		key := fmt.Sprintf("user:%d", i)
+		c.expect("OK", "SET", key, "x")
+		waitForValue(t, follower, key, "x")
+	}
+	c.expect(int64(5), "DBSIZE")
+	c.expect([]any{"user:1", "user:2"}, "KEYS", "user:[12]")
+	var scanned []string
+	cursor := "0"
+	for pages := 0; pages == 0 || cursor != "0"; pages++ {
+		reply, ok := c.do("SCAN", cursor, "MATCH", "user:*", "COUNT", "2").([]any)
+		if !ok || len(reply) != 2 || pages > 5 {
+			t.Fatalf("Expected a SCAN page, got %#v", reply)
+		}
+		cursor = reply[0].(string)
+		for _, key := range reply[1].([]any) {
+			scanned = append(scanned, key.(string))
+		}
+	}
+	if len(scanned) != 5 || scanned[0] != "user:1" || scanned[4] != "user:5" {
+		t.Errorf("Expected SCAN to return every user once, got %v", scanned)
+	}
+	c.expect(respError("ERR invalid cursor"), "SCAN", "12345")
+	c.expect([]any{"user:4", "user:5"}, "KEYS", "*:[^1-3]")
+	c.expect([]any{"user:5"}, "KEYS", "u*r*5")
+
+	// Patterns with many stars are matched without backtracking blowing up
+	long := strings.Repeat("a", 60)
+	c.expect("OK", "SET", long, "x")
+	waitForValue(t, follower, long, "x")
+	c.expect([]any{}, "KEYS", strings.Repeat("*a", 20)+"*b")
+	c.expect([]any{long}, "KEYS", strings.Repeat("*a", 20)+"*")
+	c.expect(int64(1), "DEL", long)
+
+	// Errors carry the Redis prefixes
+	if _, err := follower.HandleCollection(iface.RequestMeta{}, storage.CollectionOp{Cmd: storage.RPUSH, Key: "list", Members: []string{"x"}}); err != nil {
+		t.Fatalf("RPUSH failed: %v", err)
+	}
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(20 * time.Millisecond) {
+		reply, _ := c.do("GET", "list").(respError)
+		if strings.HasPrefix(string(reply), "WRONGTYPE") {
+			break
+		} else if time.Now().After(deadline) {
+			t.Fatalf("Expected a WRONGTYPE error, got %q", reply)
+		}
+	}
+	c.expect([]any{"list"}, "KEYS", "l*")
+	c.expect([]any{"0", []any{"list"}}, "SCAN", "0", "MATCH", "li?t")
+	c.expect(int64(6), "DBSIZE")
+	if reply, _ := c.do("INCR", "user:1").(respError); !strings.HasPrefix(string(reply), "ERR") {
+		t.Errorf("Expected an ERR error, got %q", reply)
+	}
+
+	// RESP3 after HELLO, and inline commands
+	if reply, ok := c.do("HELLO", "3").([]any); !ok || !reflect.DeepEqual(reply[2:4], []any{"proto", int64(3)}) {
+		t.Errorf("Expected HELLO to switch to RESP3, got %#v", reply)
+	}
+	c.send("GET", "missing")
+	if line := c.line(); line != "_" {
+		t.Errorf("Expected a RESP3 null, got %q", line)
+	}
+	c.conn.Write([]byte("PING\r\n"))
+	if reply := c.reply(); reply != "PONG" {
+		t.Errorf("Expected PONG, got %#v", reply)
+	}
+}
+
+func TestRESPRedirect(t *testing.T) {
+	a := node.NewNode(140, "localhost:50202", node.LEADER)
+	b := node.NewNode(141, "localhost:50203", node.LEADER)
+	b.SetShard(1)
+	md := iface.ClusterMetadata{
+		Version: 1,
+		Shards: []iface.ShardGroup{
+			{ID: 0, LeaderID: 140, LeaderAddr: "localhost:50202"},
+			{ID: 1, LeaderID: 141, LeaderAddr: "localhost:50203", RESPAddr: "localhost:50205"},
+		},
+	}
+	for _, n := range []*node.Node{a, b} {
+		if err := n.SetLeader(n.GetID(), "", n.GetEpoch()+1); err != nil {
+			t.Fatalf("SetLeader failed: %v", err)
+		}
+		n.SetClusterMetadata(md)
+	}
+	a.ServeRESP("localhost:50204")
+	b.ServeRESP("localhost:50205")
+
+	// Find a key of each group
+	keys := map[bool]string{}
+	for i := 0; len(keys) < 2; i++ {
+		key := fmt.Sprintf("key%d", i)
+		_, remote := a.KeyOwner(key)
+		keys[remote] = key
+	}
+	local, remote := keys[false], keys[true]
+
+	// Group 1 publishes its listener, so group 0 redirects to it
+	ca := dialRESP(t, "localhost:50204")
+	reply, _ := ca.do("GET", remote).(respError)
+	if !strings.HasPrefix(string(reply), "MOVED ") || !strings.HasSuffix(string(reply), " localhost:50205") {
+		t.Errorf("Expected a MOVED redirect, got %q", reply)
+	}
+	if reply, _ := ca.do("DEL", local, remote).(respError); !strings.HasPrefix(string(reply), "CROSSSLOT") {
+		t.Errorf("Expected keys of two listeners to be refused, got %q", reply)
+	}
+
+	// Group 0 does not, so group 1 routes its keys itself
+	cb := dialRESP(t, "localhost:50205")
+	cb.expect("OK", "SET", local, "v")
+	if value, _ := a.HandleGet(local); value != "v" {
+		t.Errorf("Expected the write on the owning group, got %q", value)
+	}
+	cb.expect("v", "GET", local)
+	cb.expect("OK", "SET", remote, "w")
+	ca.expect("OK", "SET", local, "x")
+
+	// DBSIZE counts the keys of every group
+	ca.expect(int64(2), "DBSIZE")
+	cb.expect(int64(2), "DBSIZE")
+}
@@ -0,0 +1,40 @@
+package test
+
+import (
+	"fmt"
+	"kvstore/node"
+	"testing"
+)
+
+func TestHashRingDistribution(t *testing.T) {
+	ring := node.NewHashRing([]int{0, 1, 2}, 64)
+
+	counts := make(map[int]int)
+	for i := 0; i < 3000; i++ {
+		counts[ring.Owner(fmt.Sprintf("key%d", i))]++
+	}
+	for shard := 0; shard < 3; shard++ {
+		if counts[shard] < 600 || counts[shard] > 1400 {
+			t.Errorf("Expected shard %d to own about a third of the keys, got %d", shard, counts[shard])
+		}
+	}
+}
+
+func TestHashRingMinimalMovement(t *testing.T) {
+	before := node.NewHashRing([]int{0, 1, 2}, 64)
+	after := node.NewHashRing([]int{0, 1, 2, 3}, 64)
+
+	moved := 0
+	for i := 0; i < 3000; i++ {
+		key := fmt.Sprintf("key%d", i)
+		if before.Owner(key) != after.Owner(key) {
+			if after.Owner(key) != 3 {
+				t.Fatalf("Key %s moved between existing shards", key)
+			}
+			moved++
+		}
+	}
+	if moved == 0 || moved > 1200 {
+		t.Errorf("Expected about a quarter of the keys to move, got %d", moved)
+	}
+}
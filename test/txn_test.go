@@ -0,0 +1,91 @@
+package test
+
+import (
+	"kvstore/iface"
+	"kvstore/node"
+	"kvstore/storage"
+	"testing"
+	"time"
+)
+
+func TestStorageTxn(t *testing.T) {
+	s := storage.NewMemoryStorage()
+	s.Put("account", "100")
+	s.Put("account", "90")
+
+	cmps := []storage.Compare{
+		{Key: "account", Target: storage.CMP_VERSION, Op: storage.CMP_EQ, Version: 2},
+		{Key: "index/90", Target: storage.CMP_EXISTS, Op: storage.CMP_EQ, Exists: false},
+	}
+	success := []storage.BatchOp{{Key: "account", Value: "80"}, {Key: "index/80", Value: "account"}}
+	failure := []storage.BatchOp{{Key: "conflict", Value: "1"}}
+
+	res, err := s.Txn(cmps, success, failure, storage.Stamp{})
+	if err != nil || !res.Succeeded || len(res.Applied) != 2 {
+		t.Fatalf("Expected the success branch, got %+v (%v)", res, err)
+	}
+	if v, _ := s.Get("index/80"); v != "account" || s.Version("account") != 3 {
+		t.Errorf("Expected both writes applied, got %q at version %d", v, s.Version("account"))
+	}
+
+	// The version moved on, so the same transaction takes the failure branch
+	res, err = s.Txn(cmps, success, failure, storage.Stamp{})
+	if err != nil || res.Succeeded || !s.Has("conflict") {
+		t.Errorf("Expected the failure branch, got %+v (%v)", res, err)
+	}
+	if cur := res.Current["account"]; cur.Value != "80" || cur.Version != 3 {
+		t.Errorf("Expected the current state of the compared key, got %+v", cur)
+	}
+
+	if _, err := s.Txn([]storage.Compare{{Key: "k", Target: storage.CMP_EXISTS, Op: storage.CMP_LT}}, nil, nil, storage.Stamp{}); err == nil {
+		t.Errorf("Expected an invalid compare to be rejected")
+	}
+}
+
+func TestReplicatedTxn(t *testing.T) {
+	leader := node.NewNode(119, "localhost:50180", node.LEADER)
+	follower := node.NewNode(120, "localhost:50181", node.FOLLOWER)
+	if err := follower.Join("localhost:50180"); err != nil {
+		t.Fatalf("Expected join to succeed, got %v", err)
+	}
+	waitForMembers(t, 2, leader, follower)
+	epoch := max(leader.GetEpoch(), follower.GetEpoch()) + 1
+	for _, n := range []*node.Node{leader, follower} {
+		if err := n.SetLeader(119, "localhost:50180", epoch); err != nil {
+			t.Fatalf("SetLeader failed: %v", err)
+		}
+	}
+
+	txn := iface.Txn{
+		Compares: []storage.Compare{{Key: "user/1", Target: storage.CMP_EXISTS, Op: storage.CMP_EQ, Exists: false}},
+		Success:  []storage.BatchOp{{Key: "user/1", Value: "ada"}, {Key: "email/ada", Value: "user/1"}},
+	}
+	meta := iface.RequestMeta{ClientID: "c1", Seq: 1}
+	if ok, _, err := follower.HandleTxn(meta, txn); err != nil || !ok {
+		t.Fatalf("Expected the transaction to succeed, got %v (%v)", ok, err)
+	}
+	// A retry is answered from the session, not run again
+	if ok, _, err := follower.HandleTxn(meta, txn); err != nil || !ok {
+		t.Fatalf("Expected the retry to report success, got %v (%v)", ok, err)
+	}
+	meta.Seq = 2
+	if ok, _, err := leader.HandleTxn(meta, txn); err != nil || ok {
+		t.Fatalf("Expected the compare to fail, got %v (%v)", ok, err)
+	}
+	// and its retry gets the same answer, compared keys included
+	if ok, current, err := follower.HandleTxn(meta, txn); err != nil || ok || current["user/1"].Value != "ada" {
+		t.Fatalf("Expected the retry to report the failed compare on ada, got %v %+v (%v)", ok, current, err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		value, _ := follower.HandleGet("email/ada")
+		if value == "user/1" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the transaction to reach the follower, got %q", value)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
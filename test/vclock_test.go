@@ -0,0 +1,49 @@
+package test
+
+import (
+	"kvstore/storage"
+	"testing"
+)
+
+func TestVectorClockCompare(t *testing.T) {
+	a := storage.VectorClock{1: 1}
+	b := a.Increment(2)
+	c := a.Increment(3)
+
+	if a.Compare(b) != storage.BEFORE {
+		t.Errorf("Expected a before b")
+	}
+	if b.Compare(a) != storage.AFTER {
+		t.Errorf("Expected b after a")
+	}
+	if b.Compare(c) != storage.CONCURRENT {
+		t.Errorf("Expected b and c to be concurrent")
+	}
+	if b.Merge(c).Compare(c) != storage.AFTER {
+		t.Errorf("Expected merged clock to descend from c")
+	}
+	if a.Compare(a.Copy()) != storage.EQUAL {
+		t.Errorf("Expected copy to be equal")
+	}
+}
+
+func TestVersionedStorageSiblings(t *testing.T) {
+	s := storage.NewVersionedStorage()
+	base := storage.VectorClock{1: 1}
+
+	s.Merge("key", []storage.Sibling{{Value: "v1", Clock: base}})
+	s.Merge("key", []storage.Sibling{{Value: "v2", Clock: base.Increment(2)}})
+	s.Merge("key", []storage.Sibling{{Value: "v3", Clock: base.Increment(3)}})
+
+	siblings := s.Get("key")
+	if len(siblings) != 2 {
+		t.Fatalf("Expected 2 concurrent siblings, got %v", siblings)
+	}
+
+	resolved := siblings[0].Clock.Merge(siblings[1].Clock).Increment(1)
+	s.Merge("key", []storage.Sibling{{Value: "merged", Clock: resolved}})
+	siblings = s.Get("key")
+	if len(siblings) != 1 || siblings[0].Value != "merged" {
+		t.Errorf("Expected the resolving write to replace both siblings, got %v", siblings)
+	}
+}
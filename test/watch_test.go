@@ -0,0 +1,78 @@
+package test
+
+import (
+	"context"
+	"kvstore/iface"
+	"kvstore/node"
+	"kvstore/storage"
+	"testing"
+	"time"
+)
+
+func TestWatch(t *testing.T) {
+	leader := node.NewNode(125, "localhost:50186", node.LEADER)
+	follower := node.NewNode(126, "localhost:50187", node.FOLLOWER)
+	if err := follower.Join("localhost:50186"); err != nil {
+		t.Fatalf("Expected join to succeed, got %v", err)
+	}
+	waitForMembers(t, 2, leader, follower)
+	epoch := max(leader.GetEpoch(), follower.GetEpoch()) + 1
+	for _, n := range []*node.Node{leader, follower} {
+		if err := n.SetLeader(125, "localhost:50186", epoch); err != nil {
+			t.Fatalf("SetLeader failed: %v", err)
+		}
+	}
+
+	meta := iface.RequestMeta{}
+	leader.HandlePut(meta, "app/a", "1")
+	waitForValue(t, follower, "app/a", "1")
+
+	// The follower serves the watch, replaying from revision 1
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	responses := make(chan iface.WatchResponse, 16)
+	req := iface.WatchRequest{Start: "app/", End: storage.PrefixEnd("app/"), StartRevision: 1, ProgressInterval: 50 * time.Millisecond}
+	go follower.HandleWatch(ctx, req, func(resp iface.WatchResponse) error {
+		responses <- resp
+		return nil
+	})
+	next := func() iface.WatchResponse {
+		for {
+			select {
+			case resp := <-responses:
+				if !resp.Progress {
+					return resp
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("Expected a watch event")
+			}
+		}
+	}
+
+	if resp := next(); resp.Revision != 1 || len(resp.Events) != 1 || resp.Events[0].Key != "app/a" {
+		t.Fatalf("Expected the replayed put of app/a, got %+v", resp)
+	}
+	leader.HandlePut(meta, "other", "x")
+	leader.HandlePut(meta, "app/b", "2")
+	if resp := next(); resp.Revision != 3 || resp.Events[0].Key != "app/b" || resp.Events[0].Value != "2" {
+		t.Errorf("Expected the put of app/b at revision 3, got %+v", resp)
+	}
+	waitForValue(t, follower, "app/b", "2")
+	leader.HandleDelete(meta, "app/a")
+	if resp := next(); resp.Revision != 4 || !resp.Events[0].Deleted || resp.Events[0].Key != "app/a" {
+		t.Errorf("Expected the deletion of app/a at revision 4, got %+v", resp)
+	}
+
+	// Once idle, progress notifications tell how far the watch has come
+	deadline := time.After(5 * time.Second)
+	for {
+		select {
+		case resp := <-responses:
+			if resp.Progress && resp.Revision == 4 {
+				return
+			}
+		case <-deadline:
+			t.Fatalf("Expected a progress notification at revision 4")
+		}
+	}
+}